@@ -22,6 +22,10 @@ func (h testServerHandle) GetGameConfig() config.ClientConfig {
 	return h.clientGameConfig
 }
 
+func (h testServerHandle) GetReputationLedger() gamestate.ReputationLedger {
+	return gamestate.ReputationLedger{}
+}
+
 func MakeTestClient(gamestate gamestate.ClientGameState) client {
 	c := DefaultClient(shared.Team1)
 	c.Initialise(testServerHandle{
@@ -24,6 +24,10 @@ func (m mockServerReadHandle) GetGameConfig() config.ClientConfig {
 	return m.gameConfig
 }
 
+func (m mockServerReadHandle) GetReputationLedger() gamestate.ReputationLedger {
+	return gamestate.ReputationLedger{}
+}
+
 // -----------------------------------------------------------------------------
 
 // clientPrint is a wrapper for team3 Logf function, that only prints when
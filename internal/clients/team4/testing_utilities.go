@@ -47,6 +47,10 @@ func (s fakeServerHandle) GetGameConfig() config.ClientConfig {
 	}
 }
 
+func (s fakeServerHandle) GetReputationLedger() gamestate.ReputationLedger {
+	return gamestate.ReputationLedger{}
+}
+
 func registerTestElectionRule() map[string]rules.RuleMatrix {
 	rulesStore := map[string]rules.RuleMatrix{}
 
@@ -20,6 +20,10 @@ func (h testServerHandle) GetGameConfig() config.ClientConfig {
 	return h.clientGameConfig
 }
 
+func (h testServerHandle) GetReputationLedger() gamestate.ReputationLedger {
+	return gamestate.ReputationLedger{}
+}
+
 func MakeTestClient(gamestate gamestate.ClientGameState) client {
 	c := NewTestClient(shared.Team5)
 	c.Initialise(testServerHandle{
@@ -53,3 +53,7 @@ func (s stubServerReadHandle) GetGameState() gamestate.ClientGameState {
 func (s stubServerReadHandle) GetGameConfig() config.ClientConfig {
 	return s.gameConfig
 }
+
+func (s stubServerReadHandle) GetReputationLedger() gamestate.ReputationLedger {
+	return gamestate.ReputationLedger{}
+}
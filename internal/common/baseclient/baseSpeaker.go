@@ -34,6 +34,25 @@ func (s *BaseSpeaker) DecideAgenda(ruleMatrix rules.RuleMatrix) shared.SpeakerRe
 	}
 }
 
+//DecideLegislativeSession is the interface implementation and example of a well behaved Speaker
+//who votes on the agenda items in the order they were queued, without bundling them, and allows
+//every amendment. Items the session budget cannot cover stay on the agenda for the next session.
+func (s *BaseSpeaker) DecideLegislativeSession(agenda []shared.AgendaItem, amendments []shared.Amendment) shared.LegislativeSession {
+	order := make([]uint, 0, len(agenda))
+	for _, item := range agenda {
+		order = append(order, item.ID)
+	}
+	amendmentIDs := make([]uint, 0, len(amendments))
+	for _, amendment := range amendments {
+		amendmentIDs = append(amendmentIDs, amendment.ID)
+	}
+	return shared.LegislativeSession{
+		Order:       order,
+		Amendments:  amendmentIDs,
+		ActionTaken: true,
+	}
+}
+
 //DecideVote is the interface implementation and example of a well behaved Speaker
 //who calls a vote on the proposed rule and asks all available islands to vote.
 //Return an empty string or empty []shared.ClientID for no vote to occur
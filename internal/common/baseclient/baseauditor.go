@@ -0,0 +1,21 @@
+package baseclient
+
+import (
+	"github.com/SOMAS2020/SOMAS2020/internal/common/gamestate"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+type BaseAuditor struct {
+	GameState gamestate.ClientGameState
+}
+
+// AuditAllocations checks the allocations decided by the President against the islands' requests.
+// Islands mapped to false have their allocation withheld. The bool returned is whether the audit was performed.
+// OPTIONAL: override to audit allocations differently
+func (a *BaseAuditor) AuditAllocations(requests map[shared.ClientID]shared.Resources, allocations map[shared.ClientID]shared.Resources) (map[shared.ClientID]bool, bool) {
+	audit := map[shared.ClientID]bool{}
+	for island, allocation := range allocations {
+		audit[island] = allocation <= requests[island]
+	}
+	return audit, true
+}
@@ -32,6 +32,8 @@ type Client interface {
 	GetClientPresidentPointer() roles.President
 	GetClientJudgePointer() roles.Judge
 	GetClientSpeakerPointer() roles.Speaker
+	GetClientTreasurerPointer() roles.Treasurer
+	GetClientAuditorPointer() roles.Auditor
 	GetTaxContribution() shared.Resources
 	GetSanctionPayment() shared.Resources
 	RequestAllocation() shared.Resources
@@ -45,11 +47,18 @@ type Client interface {
 	//Disasters
 	DisasterNotification(disasters.DisasterReport, disasters.DisasterEffects)
 
+	//Messaging: OPTIONAL
+	GetMessages() []shared.Message
+	ReceiveMessages(messages []shared.MessageLogEntry)
+
 	//IIFO: OPTIONAL
 	MakeDisasterPrediction() shared.DisasterPredictionInfo
 	ReceiveDisasterPredictions(receivedPredictions shared.ReceivedDisasterPredictionsDict)
 	MakeForageInfo() shared.ForageShareInfo
 	ReceiveForageInfo([]shared.ForageShareInfo)
+	RequestForageAudits() []shared.ClientID
+	ReceiveForageAudits(map[shared.ClientID]shared.ForageReportVerification)
+	GetPredictionMarketOrders() []shared.PredictionMarketOrder
 
 	//IITO: COMPULSORY
 	GetGiftRequests() shared.GiftRequestDict
@@ -58,10 +67,32 @@ type Client interface {
 	UpdateGiftInfo(receivedResponses shared.GiftResponseDict)
 	DecideGiftAmount(shared.ClientID, shared.Resources) shared.Resources
 
+	//IITO: OPTIONAL
+	ProposeContracts() []shared.Contract
+	RespondToContract(proposal shared.Contract) bool
+	DecideContractPayment(contract shared.Contract, obligation shared.ContractObligation) shared.Resources
+	GetLoanOffers() []shared.LoanOffer
+	RespondToLoanOffer(offer shared.Loan) bool
+	PetitionJudgeOverDefault(loan shared.Loan) bool
+	GetEscrowedGiftOffers() []shared.EscrowedGiftOffer
+	NegotiateGiftOffers(offers shared.GiftOfferDict, round uint) map[shared.ClientID]shared.GiftNegotiationMessage
+	RespondToCounterOffers(counterOffers map[shared.ClientID]shared.GiftNegotiationMessage, round uint) map[shared.ClientID]shared.GiftNegotiationMessage
+	GetMarketOrders() []shared.MarketOrder
+	GetCoalitionActions() []shared.CoalitionAction
+	ApproveCoalitionMember(coalition string, applicant shared.ClientID) bool
+
 	//IIGO: COMPULSORY
 	MonitorIIGORole(shared.Role) bool
 	DecideIIGOMonitoringAnnouncement(bool) (bool, bool)
 
+	//IIGO: OPTIONAL
+	FileRecallPetition(roleHolders map[shared.Role]shared.ClientID) (shared.Role, bool)
+	CoSignRecallPetition(petition shared.RecallPetition) bool
+	AppealSanction(sanction shared.Sanction, rulesBroken []string) bool
+	ReviewSanctionAppeal(appeal shared.SanctionAppeal) map[string]bool
+	ProposeAmendments(agenda []shared.AgendaItem) map[uint]rules.RuleMatrix
+	DelegateVotes(current map[shared.VoteKind]shared.ClientID) map[shared.VoteKind]shared.ClientID
+
 	//TODO: THESE ARE NOT DONE yet, how do people think we should implement the actual transfer?
 	SentGift(sent shared.Resources, to shared.ClientID)
 	ReceivedGift(received shared.Resources, from shared.ClientID)
@@ -71,6 +102,8 @@ type Client interface {
 type ServerReadHandle interface {
 	GetGameState() gamestate.ClientGameState
 	GetGameConfig() config.ClientConfig
+	// GetReputationLedger returns the facts about every island's conduct verified by the server
+	GetReputationLedger() gamestate.ReputationLedger
 }
 
 // NewClient produces a new client with the BaseClient already implemented.
@@ -18,7 +18,7 @@ func (j *BaseJudge) GetRuleViolationSeverity() map[string]shared.IIGOSanctionsSc
 }
 
 // GetSanctionThresholds returns a custom map of sanction score thresholds for different sanction tiers
-// For any unfilled sanction tiers will be filled with default values (given in judiciary.go)
+// Any unfilled tier of the sanction ladder is filled with its threshold from the IIGO config
 // OPTIONAL: override to set custom sanction thresholds
 func (j *BaseJudge) GetSanctionThresholds() map[shared.IIGOSanctionsTier]shared.IIGOSanctionsScore {
 	return map[shared.IIGOSanctionsTier]shared.IIGOSanctionsScore{}
@@ -111,3 +111,10 @@ func (j *BaseJudge) CallPresidentElection(monitoring shared.MonitorResult, turns
 func (j *BaseJudge) DecideNextPresident(winner shared.ClientID) shared.ClientID {
 	return winner
 }
+
+// CallElectionRecount decides whether the judiciary recounts the recorded ballots of the elections
+// held at the end of the previous turn. A recount flags any appointment that overrode the true winner.
+// OPTIONAL: override to decide when a recount is worth its cost
+func (j *BaseJudge) CallElectionRecount(electionsHeld []shared.Role) bool {
+	return len(electionsHeld) > 0
+}
@@ -0,0 +1,17 @@
+package baseclient
+
+import (
+	"github.com/SOMAS2020/SOMAS2020/internal/common/gamestate"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+type BaseTreasurer struct {
+	GameState gamestate.ClientGameState
+}
+
+// SetCommonPoolReserve returns the amount of the common pool the President may not allocate this turn
+// and whether the Treasurer decided to set a reserve at all.
+// OPTIONAL: override to keep resources back for disaster mitigation
+func (t *BaseTreasurer) SetCommonPoolReserve(commonPool shared.Resources) (shared.Resources, bool) {
+	return 0, true
+}
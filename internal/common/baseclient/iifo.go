@@ -170,3 +170,25 @@ func (c *BaseClient) ReceiveForageInfo(neighbourForaging []shared.ForageShareInf
 		}
 	}
 }
+
+// RequestForageAudits is called after ReceiveForageInfo for the client to choose which of the islands which shared
+// foraging information with it this turn it wants audited. Each audit costs ForageAuditCost, paid into the common pool.
+// OPTIONAL, you can implement this if you want to check who shares truthful foraging information
+func (c *BaseClient) RequestForageAudits() []shared.ClientID {
+	return []shared.ClientID{}
+}
+
+// ReceiveForageAudits gives the client the results of the audits it requested: whether the foraging information each
+// island shared with it matches that island's most recent foraging decision and its return.
+// OPTIONAL, you can implement this if you want to check who shares truthful foraging information
+func (c *BaseClient) ReceiveForageAudits(results map[shared.ClientID]shared.ForageReportVerification) {
+}
+
+// GetPredictionMarketOrders is called in the IIFO session for the client to buy shares in the disaster prediction
+// markets. A Yes share pays 1 resource if the event happens and a No share pays 1 resource if it does not. Prices
+// are set by a market maker and move with every purchase, so they give the crowd's forecast of the event. The
+// markets and their prices can be read from the ClientGameState.
+// OPTIONAL, you can implement this if you want to bet on disasters
+func (c *BaseClient) GetPredictionMarketOrders() []shared.PredictionMarketOrder {
+	return []shared.PredictionMarketOrder{}
+}
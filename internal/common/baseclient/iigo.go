@@ -58,6 +58,18 @@ func (c *BaseClient) GetClientSpeakerPointer() roles.Speaker {
 	return &BaseSpeaker{GameState: c.ServerReadHandle.GetGameState()}
 }
 
+// GetClientTreasurerPointer is called by IIGO to get the client's implementation of the Treasurer Role
+// OPTIONAL: override to return a pointer to your own Treasurer object. Only used if the Treasurer is enabled
+func (c *BaseClient) GetClientTreasurerPointer() roles.Treasurer {
+	return &BaseTreasurer{GameState: c.ServerReadHandle.GetGameState()}
+}
+
+// GetClientAuditorPointer is called by IIGO to get the client's implementation of the Auditor Role
+// OPTIONAL: override to return a pointer to your own Auditor object. Only used if the Auditor is enabled
+func (c *BaseClient) GetClientAuditorPointer() roles.Auditor {
+	return &BaseAuditor{GameState: c.ServerReadHandle.GetGameState()}
+}
+
 // GetTaxContribution gives value of how much the island wants to pay in taxes
 // The tax is the minimum contribution, you can pay more if you want to
 // COMPULSORY
@@ -179,3 +191,59 @@ func (c *BaseClient) GetRecommendation(variable rules.VariableFieldName) (compli
 	}
 	return c.LocalVariableCache[variable], false
 }
+
+// FileRecallPetition is called at the end of IIGO to let the island petition the recall of an IIGO
+// role holder. roleHolders contains the island holding each role once the elections are over.
+// If enough islands co-sign the petition an election for the role is forced.
+// OPTIONAL: return the role to recall and true to file a petition
+func (c *BaseClient) FileRecallPetition(roleHolders map[shared.Role]shared.ClientID) (shared.Role, bool) {
+	return shared.President, false
+}
+
+// CoSignRecallPetition is called when another island has filed a petition to recall an IIGO role holder.
+// OPTIONAL: return true to add your signature to the petition
+func (c *BaseClient) CoSignRecallPetition(petition shared.RecallPetition) bool {
+	return false
+}
+
+// AppealSanction is called when the Judge has sanctioned the island this turn for breaking rulesBroken.
+// Appealing costs the AppealFee in the IIGO config, which is refunded if the sanction is voided.
+// OPTIONAL: return true to appeal the sanction
+func (c *BaseClient) AppealSanction(sanction shared.Sanction, rulesBroken []string) bool {
+	return false
+}
+
+// ReviewSanctionAppeal is called when the island sits on the panel hearing another island's appeal.
+// It returns, for each contested rule, whether the appellant broke it. The base implementation
+// re-evaluates each rule against the variables recorded for the appellant and sides with the Judge
+// if a rule cannot be evaluated.
+// OPTIONAL: override to review appeals differently
+func (c *BaseClient) ReviewSanctionAppeal(appeal shared.SanctionAppeal) map[string]bool {
+	rulesInfo := c.ServerReadHandle.GetGameState().RulesInfo
+	variables := rules.CopyVariableMap(rulesInfo.VariableMap)
+	for _, pair := range appeal.RecordedVariables {
+		variables[pair.VariableName] = pair
+	}
+	verdicts := map[string]bool{}
+	for _, rule := range appeal.RulesBroken {
+		ret := rules.EvaluateRuleFromCaches(rule, rulesInfo.CurrentRulesInPlay, variables)
+		verdicts[rule] = ret.EvalError != nil || !ret.RulePasses
+	}
+	return verdicts
+}
+
+// ProposeAmendments is called before each legislative session with the items queued on the agenda.
+// It returns, for the IDs of the items the island wishes to amend, the amended rule. The amended
+// rule must keep the name of a rule in the item, and is put to a vote if the Speaker allows it.
+// OPTIONAL: override to amend proposals before they are voted on
+func (c *BaseClient) ProposeAmendments(agenda []shared.AgendaItem) map[uint]rules.RuleMatrix {
+	return map[uint]rules.RuleMatrix{}
+}
+
+// DelegateVotes is called at the start of every IIGO session with the islands the island currently
+// delegates its votes to. Delegated votes are cast by the delegate, or by whoever the delegate has
+// delegated to in turn. Leave a kind of vote out of the returned map to cast it yourself.
+// OPTIONAL: override to delegate or revoke delegations
+func (c *BaseClient) DelegateVotes(current map[shared.VoteKind]shared.ClientID) map[shared.VoteKind]shared.ClientID {
+	return current
+}
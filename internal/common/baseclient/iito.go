@@ -114,3 +114,97 @@ func (c *BaseClient) ReceiveIntendedContribution(receivedIntendedContribution sh
 func (c *BaseClient) DecideGiftAmount(toTeam shared.ClientID, giftOffer shared.Resources) shared.Resources {
 	return giftOffer
 }
+
+// ProposeContracts is called in the IITO session for the client to propose binding agreements to
+// other islands. Only the Counterparty and the Obligations of each proposal are used, the server sets the rest.
+// Each obligation must be paid by either the client or the counterparty.
+// OPTIONAL, you can implement this if you want to make agreements lasting several turns
+func (c *BaseClient) ProposeContracts() []shared.Contract {
+	return []shared.Contract{}
+}
+
+// RespondToContract is called for each contract proposed to the client. Returning true signs the contract,
+// which the server then enforces: failing to make a payment due breaks the agreement.
+// OPTIONAL, you can implement this if you want to make agreements lasting several turns
+func (c *BaseClient) RespondToContract(proposal shared.Contract) bool {
+	return false
+}
+
+// DecideContractPayment is executed at the end of each turn for every payment the client owes under a
+// signed contract. Paying less than the amount due breaks the contract, and nothing is paid.
+// OPTIONAL, you can implement this if you want to make agreements lasting several turns
+func (c *BaseClient) DecideContractPayment(contract shared.Contract, obligation shared.ContractObligation) shared.Resources {
+	return obligation.Amount
+}
+
+// GetLoanOffers is called in the IITO session for the client to offer loans to other islands.
+// The principal of an accepted offer is taken from the client straight away, and the borrower repays it with
+// interest in equal instalments at the end of the following turns.
+// OPTIONAL, you can implement this if you want to lend resources
+func (c *BaseClient) GetLoanOffers() []shared.LoanOffer {
+	return []shared.LoanOffer{}
+}
+
+// RespondToLoanOffer is called for each loan offered to the client. Returning true accepts the loan.
+// Instalments are taken automatically at the end of each turn, and failing to pay one is a default.
+// OPTIONAL, you can implement this if you want to borrow resources. This placeholder implementation
+// accepts loans when the client is critical.
+func (c *BaseClient) RespondToLoanOffer(offer shared.Loan) bool {
+	return c.ServerReadHandle.GetGameState().ClientInfo.LifeStatus == shared.Critical
+}
+
+// PetitionJudgeOverDefault is called when a borrower defaults on a loan made by the client.
+// Returning true reports the default to the Judge, who may sanction the borrower.
+// OPTIONAL, you can implement this if you want to lend resources
+func (c *BaseClient) PetitionJudgeOverDefault(loan shared.Loan) bool {
+	return true
+}
+
+// GetEscrowedGiftOffers is called in the IITO session for the client to make gifts held by the server in escrow.
+// The amount is taken from the client straight away. The gift is released to the recipient if its condition
+// becomes true before it expires, and returned to the client otherwise, e.g. to insure another island against
+// disasters. SentGift and ReceivedGift are called when a gift is released.
+// OPTIONAL, you can implement this if you want to make conditional gifts
+func (c *BaseClient) GetEscrowedGiftOffers() []shared.EscrowedGiftOffer {
+	return []shared.EscrowedGiftOffer{}
+}
+
+// NegotiateGiftOffers is called in every round of the gift negotiation with the offers currently standing to
+// the client, if negotiation is enabled. Return GiftCounterOffer moves, keyed by giver, to ask for a different
+// amount. Offers which are not countered stand as they are.
+// OPTIONAL, you can implement this if you want to bargain over gifts
+func (c *BaseClient) NegotiateGiftOffers(offers shared.GiftOfferDict, round uint) map[shared.ClientID]shared.GiftNegotiationMessage {
+	return map[shared.ClientID]shared.GiftNegotiationMessage{}
+}
+
+// RespondToCounterOffers is called in every round of the gift negotiation with the counter-offers made to the
+// client's gift offers. Return GiftOfferRevised moves, keyed by recipient, to change the amount offered, or
+// GiftOfferWithdrawn moves to withdraw an offer. Offers which are not revised stand as they are.
+// OPTIONAL, you can implement this if you want to bargain over gifts
+func (c *BaseClient) RespondToCounterOffers(counterOffers map[shared.ClientID]shared.GiftNegotiationMessage, round uint) map[shared.ClientID]shared.GiftNegotiationMessage {
+	return map[shared.ClientID]shared.GiftNegotiationMessage{}
+}
+
+// GetMarketOrders is called in the IITO session for the client to post bids and asks to the market.
+// The order book of each asset is cleared at a single price per turn: bids at or above it buy from asks at or
+// below it. Buyers pay sellers straight away, and sellers deliver at the end of the turn, either the share of
+// their foraging return sold or the resources promised by their IOUs. Failing to deliver breaks an agreement.
+// OPTIONAL, you can implement this if you want to trade resources
+func (c *BaseClient) GetMarketOrders() []shared.MarketOrder {
+	return []shared.MarketOrder{}
+}
+
+// GetCoalitionActions is called in the IITO session for the client to form, join or leave a coalition, or to
+// contribute to the treasury of its coalition. An island is a member of at most one coalition at a time. Leaving
+// happens first, then forming, joining and contributing, in the order of the actions.
+// OPTIONAL, you can implement this if you want to take part in coalitions
+func (c *BaseClient) GetCoalitionActions() []shared.CoalitionAction {
+	return []shared.CoalitionAction{}
+}
+
+// ApproveCoalitionMember is called on each member of a coalition with ApprovedMembership when an island applies to
+// join it. The island joins if a majority of the members approve.
+// OPTIONAL, you can implement this if you want to choose who joins your coalition
+func (c *BaseClient) ApproveCoalitionMember(coalition string, applicant shared.ClientID) bool {
+	return true
+}
@@ -0,0 +1,16 @@
+package baseclient
+
+import "github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+
+// GetMessages is called at the start of each turn for the client to send messages to other islands: to one island,
+// a group of islands, or every other island if a message has no recipients. At most MaxMessagesPerTurn messages are
+// sent. Messages are only talk: nothing binds the sender to what it says.
+// OPTIONAL, you can implement this if you want to talk to other islands
+func (c *BaseClient) GetMessages() []shared.Message {
+	return []shared.Message{}
+}
+
+// ReceiveMessages gives the client the messages other islands sent it this turn, in the order they were sent.
+// OPTIONAL, you can implement this if you want to listen to other islands
+func (c *BaseClient) ReceiveMessages(messages []shared.MessageLogEntry) {
+}
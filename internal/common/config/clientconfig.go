@@ -7,8 +7,11 @@ type ClientConfig struct {
 	CostOfLiving                shared.Resources
 	MinimumResourceThreshold    shared.Resources
 	MaxCriticalConsecutiveTurns uint
+	MaxMessagesPerTurn          uint
 	DisasterConfig              ClientDisasterConfig
 	IIGOClientConfig            IIGOConfig
+	IIFOConfig                  IIFOConfig
+	IITOConfig                  IITOConfig
 }
 
 // ClientIIGOConfig contains iigo config fields that is visible to clients
@@ -51,8 +54,11 @@ func (c Config) GetClientConfig() ClientConfig {
 		CostOfLiving:                c.CostOfLiving,
 		MinimumResourceThreshold:    c.MinimumResourceThreshold,
 		MaxCriticalConsecutiveTurns: c.MaxCriticalConsecutiveTurns,
+		MaxMessagesPerTurn:          c.MaxMessagesPerTurn,
 		DisasterConfig:              c.DisasterConfig.GetClientDisasterConfig(),
 		IIGOClientConfig:            c.IIGOConfig.GetClientIIGOConfig(),
+		IIFOConfig:                  c.IIFOConfig,
+		IITOConfig:                  c.IITOConfig,
 	}
 }
 
@@ -74,7 +80,85 @@ func (c DisasterConfig) GetClientDisasterConfig() ClientDisasterConfig {
 	}
 }
 
-// GetClientIIGOConfig gets ClientIIGOConfig
+// GetClientIIGOConfig gets ClientIIGOConfig. Parameters governed by rules hold their current value.
 func (c IIGOConfig) GetClientIIGOConfig() IIGOConfig {
+	c.IIGOTermLengths = copyRoleUintMap(c.IIGOTermLengths)
+	c.AdditionalRoles = copyRoleConfigMap(c.AdditionalRoles)
+	c.MonitoringGraph = copyRoleMap(c.MonitoringGraph)
+	c.SanctionLadder = copySanctionLadder(c.SanctionLadder)
+	c.ElectionVotingMethods = copyRoleVotingMethodMap(c.ElectionVotingMethods)
+	c.Constitution = copyConstitution(c.Constitution)
+	if c.CollectiveSanctionRules != nil {
+		c.CollectiveSanctionRules = append([]string{}, c.CollectiveSanctionRules...)
+	}
 	return c
 }
+
+func copyRoleConfigMap(m map[shared.Role]IIGORoleConfig) map[shared.Role]IIGORoleConfig {
+	if m == nil {
+		return nil
+	}
+	ret := make(map[shared.Role]IIGORoleConfig, len(m))
+	for k, v := range m {
+		ret[k] = v
+	}
+	return ret
+}
+
+func copyRoleMap(m map[shared.Role]shared.Role) map[shared.Role]shared.Role {
+	if m == nil {
+		return nil
+	}
+	ret := make(map[shared.Role]shared.Role, len(m))
+	for k, v := range m {
+		ret[k] = v
+	}
+	return ret
+}
+
+func copySanctionLadder(ladder []SanctionTierConfig) []SanctionTierConfig {
+	if ladder == nil {
+		return nil
+	}
+	ret := make([]SanctionTierConfig, len(ladder))
+	for i, tier := range ladder {
+		if tier.Consequences != nil {
+			tier.Consequences = append([]shared.SanctionConsequence{}, tier.Consequences...)
+		}
+		ret[i] = tier
+	}
+	return ret
+}
+
+func copyRoleVotingMethodMap(m map[shared.Role]shared.ElectionVotingMethod) map[shared.Role]shared.ElectionVotingMethod {
+	if m == nil {
+		return nil
+	}
+	ret := make(map[shared.Role]shared.ElectionVotingMethod, len(m))
+	for k, v := range m {
+		ret[k] = v
+	}
+	return ret
+}
+
+func copyConstitution(m map[string]AmendmentProcedure) map[string]AmendmentProcedure {
+	if m == nil {
+		return nil
+	}
+	ret := make(map[string]AmendmentProcedure, len(m))
+	for k, v := range m {
+		ret[k] = v
+	}
+	return ret
+}
+
+func copyRoleUintMap(m map[shared.Role]uint) map[shared.Role]uint {
+	if m == nil {
+		return nil
+	}
+	ret := make(map[shared.Role]uint, len(m))
+	for k, v := range m {
+		ret[k] = v
+	}
+	return ret
+}
@@ -3,6 +3,8 @@ package config
 import (
 	"reflect"
 	"testing"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
 )
 
 // Tests GetClientDisasterConfig too
@@ -18,6 +20,7 @@ func TestGetClientConfig(t *testing.T) {
 				CostOfLiving:                1,
 				MinimumResourceThreshold:    2,
 				MaxCriticalConsecutiveTurns: 3,
+				MaxMessagesPerTurn:          10,
 				MaxSeasons:                  4, // not visible
 				DisasterConfig: DisasterConfig{
 					CommonpoolThreshold:        6,
@@ -53,6 +56,7 @@ func TestGetClientConfig(t *testing.T) {
 				CostOfLiving:                1,
 				MinimumResourceThreshold:    2,
 				MaxCriticalConsecutiveTurns: 3,
+				MaxMessagesPerTurn:          10,
 				DisasterConfig: ClientDisasterConfig{
 					CommonpoolThreshold: SelectivelyVisibleResources{
 						Value: 6,
@@ -167,3 +171,34 @@ func TestGetClientConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestGetClientIIGOConfigDoesNotShareReferences(t *testing.T) {
+	makeConfig := func() IIGOConfig {
+		return IIGOConfig{
+			IIGOTermLengths: map[shared.Role]uint{shared.President: 4},
+			AdditionalRoles: map[shared.Role]IIGORoleConfig{shared.Auditor: {AppointedBy: shared.Judge, ActionCost: 10}},
+			MonitoringGraph: map[shared.Role]shared.Role{shared.Speaker: shared.President},
+			SanctionLadder: []SanctionTierConfig{
+				{Threshold: 1, Consequences: []shared.SanctionConsequence{shared.LoseVotingRight}},
+			},
+			ElectionVotingMethods:   map[shared.Role]shared.ElectionVotingMethod{shared.Speaker: shared.Runoff},
+			Constitution:            map[string]AmendmentProcedure{"rule_a": {Majority: 1}},
+			CollectiveSanctionRules: []string{"rule_b"},
+		}
+	}
+	serverConfig := makeConfig()
+
+	clientConfig := serverConfig.GetClientIIGOConfig()
+	clientConfig.IIGOTermLengths[shared.President] = 1
+	clientConfig.AdditionalRoles[shared.Treasurer] = IIGORoleConfig{}
+	clientConfig.MonitoringGraph[shared.Speaker] = shared.Judge
+	clientConfig.SanctionLadder[0].Threshold = 100
+	clientConfig.SanctionLadder[0].Consequences[0] = shared.IneligibleForOffice
+	clientConfig.ElectionVotingMethods[shared.Speaker] = shared.Approval
+	clientConfig.Constitution["rule_a"] = AmendmentProcedure{}
+	clientConfig.CollectiveSanctionRules[0] = "rule_c"
+
+	if want := makeConfig(); !reflect.DeepEqual(want, serverConfig) {
+		t.Errorf("client changed the server config: want '%v' got '%v'", want, serverConfig)
+	}
+}
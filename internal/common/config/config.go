@@ -3,7 +3,11 @@
 // Add default values etc. in <root>/params.go
 package config
 
-import "github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+import (
+	"sort"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
 
 // Config is the type for the game configuration.
 type Config struct {
@@ -32,6 +36,10 @@ type Config struct {
 	// MaxCriticalConsecutiveTurns is the maximum consecutive turns an island can be in the critical state.
 	MaxCriticalConsecutiveTurns uint
 
+	// MaxMessagesPerTurn is the maximum number of messages an island can send to other islands each turn.
+	// 0 disables messaging.
+	MaxMessagesPerTurn uint
+
 	// Wrapped foraging config
 	ForagingConfig ForagingConfig
 
@@ -40,6 +48,12 @@ type Config struct {
 
 	// Wrapped IIGO config
 	IIGOConfig IIGOConfig
+
+	// Wrapped IIFO config
+	IIFOConfig IIFOConfig
+
+	// Wrapped IITO config
+	IITOConfig IITOConfig
 }
 
 // DeerHuntConfig is a subset of foraging config
@@ -107,16 +121,109 @@ type IIGOConfig struct {
 	HistoryCacheDepth               uint
 	AssumedResourcesNoReport        shared.Resources
 	SanctionLength                  uint
+	// AnonymiseElectionBallots detaches recorded election ballots from their voters in the audit trail
+	AnonymiseElectionBallots bool
+	// Sanction appeals: fee paid by the appellant (refunded if the sanction is voided) and number of panel islands
+	AppealFee       shared.Resources
+	AppealPanelSize uint
 	// Legislative branch
 	SetVotingResultActionCost      shared.Resources
 	SetRuleToVoteActionCost        shared.Resources
 	AnnounceVotingResultActionCost shared.Resources
 	UpdateRulesActionCost          shared.Resources
 	AppointNextJudgeActionCost     shared.Resources
+	// LegislativeSessionBudget caps what a session may spend voting on agenda items and amendments,
+	// each of which costs SetRuleToVoteActionCost
+	LegislativeSessionBudget shared.Resources
+	// Recall petitions
+	RecallCoSignersRequired uint
+	// Term limits and candidate eligibility
+	MaxConsecutiveTerms           uint // 0 means no limit
+	TermCoolingOffPeriod          uint // turns a former holder must wait before standing for the same role again
+	CandidateMinimumResources     shared.Resources
+	CandidatesMustNotBeSanctioned bool
+	CandidatesMustNotBeCritical   bool
+	// Branch structure
+	AdditionalRoles map[shared.Role]IIGORoleConfig // roles in play on top of the President, Speaker and Judge
+	MonitoringGraph map[shared.Role]shared.Role    // monitoring role -> monitored role. nil means the default cycle
+	// SanctionLadder lists the sanction tiers from the mildest. nil means the default ladder
+	SanctionLadder []SanctionTierConfig
+	// ElectionVotingMethods fixes the voting method of the elections for the President, Speaker and Judge, held by
+	// the iigo_election_voting_method rules. A role left out lets the role running its election choose
+	ElectionVotingMethods map[shared.Role]shared.ElectionVotingMethod
+	// RuleVoteMajority is the share of the votes cast needed to pass an ordinary rule vote. 0.5 or less means a simple majority
+	RuleVoteMajority float64
+	// Constitution maps the entrenched rules to the procedure needed to change them. Votes on constitutional
+	// rules which do not follow their procedure leave them as they are
+	Constitution map[string]AmendmentProcedure
+	// CollectiveSanctionRules are evaluated against every island by the server at the end of the turn,
+	// once taxes are collected. Their output is charged to the island as a surcharge into the common pool
+	CollectiveSanctionRules []string
 
 	StartWithRulesInPlay bool
 }
 
+// IIGORoleConfig captures the config of an additional IIGO role
+type IIGORoleConfig struct {
+	AppointedBy       shared.Role      // role which runs the elections for this role
+	BudgetIncrement   shared.Resources // added to the role's budget every turn
+	ActionCost        shared.Resources // cost of the role's action
+	AppointActionCost shared.Resources // cost of running an election for this role
+}
+
+// DefaultMonitoringGraph returns the original accountability cycle:
+// the Speaker monitors the President, the President monitors the Judge and the Judge monitors the Speaker
+func DefaultMonitoringGraph() map[shared.Role]shared.Role {
+	return map[shared.Role]shared.Role{
+		shared.Speaker:   shared.President,
+		shared.President: shared.Judge,
+		shared.Judge:     shared.Speaker,
+	}
+}
+
+// GetMonitoringGraph returns the configured monitoring graph, or the default cycle if none is configured
+func (c IIGOConfig) GetMonitoringGraph() map[shared.Role]shared.Role {
+	if c.MonitoringGraph == nil {
+		return DefaultMonitoringGraph()
+	}
+	return c.MonitoringGraph
+}
+
+// GetRoles returns every IIGO role in play, the President, Speaker and Judge first
+func (c IIGOConfig) GetRoles() []shared.Role {
+	roles := []shared.Role{shared.President, shared.Speaker, shared.Judge}
+	additional := make([]shared.Role, 0, len(c.AdditionalRoles))
+	for role := range c.AdditionalRoles {
+		additional = append(additional, role)
+	}
+	sort.Slice(additional, func(i, j int) bool { return additional[i] < additional[j] })
+	return append(roles, additional...)
+}
+
+// IIFOConfig captures IIFO-specific config
+type IIFOConfig struct {
+	// PredictionReward is paid from the common pool to the most accurate forecaster of each turn
+	// in which disaster predictions are scored (0 disables the reward)
+	PredictionReward shared.Resources
+
+	// PredictionMarketLiquidity is the liquidity parameter of the prediction market maker: the higher it is, the less
+	// prices move with each trade. Opening a market takes Liquidity * ln 2 from the common pool (0 disables the market).
+	PredictionMarketLiquidity float64
+
+	// VerifyForageSharing makes the server check shared foraging information against the foraging history
+	// and attach the result to it
+	VerifyForageSharing bool
+
+	// ForageAuditCost is paid into the common pool by an island for each audit of the foraging information shared with it
+	ForageAuditCost shared.Resources
+}
+
+// IITOConfig captures IITO-specific config
+type IITOConfig struct {
+	// GiftNegotiationRounds is the number of counter-offer rounds in the gift session (0 disables negotiation)
+	GiftNegotiationRounds uint
+}
+
 // ForagingConfig captures foraging-specific config
 type ForagingConfig struct {
 	DeerHuntConfig DeerHuntConfig
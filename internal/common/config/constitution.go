@@ -0,0 +1,36 @@
+package config
+
+// AmendmentProcedure captures what it takes to change a constitutional rule
+type AmendmentProcedure struct {
+	Majority float64 // share of the votes cast that must approve the change. 1 means unanimity
+	Sessions uint    // consecutive legislative sessions that must pass the change. 0 means a single session
+}
+
+// stricter returns the strictest combination of both procedures
+func (p AmendmentProcedure) stricter(other AmendmentProcedure) AmendmentProcedure {
+	if other.Majority > p.Majority {
+		p.Majority = other.Majority
+	}
+	if other.Sessions > p.Sessions {
+		p.Sessions = other.Sessions
+	}
+	return p
+}
+
+// IsConstitutional returns whether the rule is entrenched in the constitution
+func (c IIGOConfig) IsConstitutional(ruleName string) bool {
+	_, ok := c.Constitution[ruleName]
+	return ok
+}
+
+// GetAmendmentProcedure returns the procedure a vote on the rules must follow. Ordinary rules are changed in a
+// single session by RuleVoteMajority, and a vote on several rules follows the strictest of their procedures.
+func (c IIGOConfig) GetAmendmentProcedure(ruleNames []string) AmendmentProcedure {
+	procedure := AmendmentProcedure{Majority: c.RuleVoteMajority, Sessions: 1}
+	for _, ruleName := range ruleNames {
+		if constitutional, ok := c.Constitution[ruleName]; ok {
+			procedure = procedure.stricter(constitutional)
+		}
+	}
+	return procedure
+}
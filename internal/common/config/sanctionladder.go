@@ -0,0 +1,66 @@
+package config
+
+import (
+	"strconv"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+// SanctionTierConfig captures the config of a tier of the sanction ladder
+type SanctionTierConfig struct {
+	Threshold       shared.IIGOSanctionsScore    // minimum sanction score for the tier, which the Judge may override
+	PenaltyRule     string                       // rule evaluated for the penalty. Empty means the penalty formula is used
+	PenaltyConstant shared.Resources             // constant part of the penalty (ConstSanctionAmount for the penalty rule)
+	PenaltyRate     float64                      // share of the island's reported resources paid, if there is no penalty rule
+	Duration        uint                         // turns the sanction lasts. 0 means SanctionLength
+	Consequences    []shared.SanctionConsequence // non-economic consequences while the sanction lasts
+}
+
+// Penalty evaluates the penalty formula of the tier for an island with the given resources
+func (t SanctionTierConfig) Penalty(resources shared.Resources, turnsLeft int) shared.Resources {
+	if turnsLeft <= 0 {
+		return 0
+	}
+	return t.PenaltyConstant + shared.Resources(t.PenaltyRate)*resources
+}
+
+// HasConsequence returns whether the tier carries consequence
+func (t SanctionTierConfig) HasConsequence(consequence shared.SanctionConsequence) bool {
+	for _, c := range t.Consequences {
+		if c == consequence {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultSanctionLadder returns the original five economic sanction tiers, each governed by its
+// iigo_economic_sanction rule
+func DefaultSanctionLadder() []SanctionTierConfig {
+	thresholds := []shared.IIGOSanctionsScore{1, 5, 10, 20, 30}
+	ladder := make([]SanctionTierConfig, len(thresholds))
+	for i, threshold := range thresholds {
+		ladder[i] = SanctionTierConfig{
+			Threshold:   threshold,
+			PenaltyRule: "iigo_economic_sanction_" + strconv.Itoa(i+1),
+		}
+	}
+	return ladder
+}
+
+// GetSanctionLadder returns the configured sanction ladder, or the default ladder if none is configured
+func (c IIGOConfig) GetSanctionLadder() []SanctionTierConfig {
+	if c.SanctionLadder == nil {
+		return DefaultSanctionLadder()
+	}
+	return c.SanctionLadder
+}
+
+// GetSanctionTier returns the config of a tier of the sanction ladder. ok is false if the tier is not on the ladder.
+func (c IIGOConfig) GetSanctionTier(tier shared.IIGOSanctionsTier) (tierConfig SanctionTierConfig, ok bool) {
+	ladder := c.GetSanctionLadder()
+	if tier < 0 || int(tier) >= len(ladder) {
+		return SanctionTierConfig{}, false
+	}
+	return ladder[tier], true
+}
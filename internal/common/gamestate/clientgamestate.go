@@ -30,12 +30,79 @@ type ClientGameState struct {
 	JudgeID     shared.ClientID
 	PresidentID shared.ClientID
 
+	// Islands holding the additional IIGO roles enabled in the config
+	IIGOAdditionalRoleIDs map[shared.Role]shared.ClientID
+
 	// IIGO roles budget (initialised in orchestration.go)
 	IIGORolesBudget map[shared.Role]shared.Resources
 
 	// IIGO turns in power (incremented and set by monitoring)
 	IIGOTurnsInPower map[shared.Role]uint
 
+	// IIGO Common Pool Reserve set by the Treasurer, which cannot be allocated this turn
+	IIGOCommonPoolReserve shared.Resources
+
+	// IIGO Auditor's decision on the President's allocations this turn: islands mapped to false are paid nothing
+	IIGOAllocationAudit map[shared.ClientID]bool
+
+	// IIGO Legislative agenda: items queued across turns until they are voted on
+	IIGOLegislativeAgenda []shared.AgendaItem
+
+	// IIGO Vote delegations: for each kind of vote, the island each island has delegated its vote to
+	IIGOVoteDelegations map[shared.VoteKind]map[shared.ClientID]shared.ClientID
+
+	// IIGO Non-economic sanction consequences each island is under and the number of turns, including
+	// this one, they remain in force
+	IIGOSanctionConsequences map[shared.ClientID]map[shared.SanctionConsequence]uint
+
+	// IIFO Disaster predictions of every island which have been scored
+	IIFOPredictions []shared.ScoredDisasterPrediction
+
+	// IIFO Rewards paid to the most accurate forecasters
+	IIFOPredictionRewards []shared.PredictionReward
+
+	// IIFO Prediction markets on disasters, with their current prices
+	IIFOPredictionMarkets []shared.PredictionMarket
+
+	// IIFO Prediction market shares held by the client
+	IIFOPredictionMarketHoldings []shared.PredictionMarketHolding
+
+	// IIFO Audits of shared foraging information paid for by the client
+	IIFOForageAudits []shared.ForageAudit
+
+	// IITO Coalitions formed over the game, with their members and treasuries
+	IITOCoalitions []shared.Coalition
+
+	// IITO Changes in the membership and treasuries of coalitions
+	IITOCoalitionEvents []shared.CoalitionEvent
+
+	// IITO Foraging expeditions funded by coalition treasuries
+	IITOCoalitionExpeditions []shared.CoalitionExpedition
+
+	// IITO Contracts the client is a party to, with their fulfilment so far
+	IITOContracts []shared.Contract
+
+	// IITO Number of contracts each island has broken over the game
+	IITOBrokenAgreements map[shared.ClientID]uint
+
+	// IITO Loans the client is the lender or the borrower of, with their repayment so far
+	IITOLoans []shared.Loan
+
+	// IITO Number of loans each island has defaulted on over the game
+	IITOLoanDefaults map[shared.ClientID]uint
+
+	// IITO Gifts the client is the giver or the recipient of, held in escrow or resolved
+	IITOEscrowedGifts []shared.EscrowedGift
+
+	// IITO Moves of this turn's gift negotiation the client made or received
+	IITOGiftNegotiation []shared.GiftNegotiationEntry
+
+	// IITO Market trades the client is the buyer or the seller of
+	IITOMarketTrades []shared.MarketTrade
+
+	// IITO Clearing price and volume of each asset on the market in every turn
+	IITOMarketClearings []shared.MarketClearing
+
 	// RuleInfo contains the global rules information for clients to access
 	RulesInfo RulesContext
 }
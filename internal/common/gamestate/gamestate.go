@@ -27,6 +27,9 @@ type GameState struct {
 	// Foraging History
 	ForagingHistory map[shared.ForageType][]foraging.ForagingReport
 
+	// Foraging return of each island in this turn's foraging session
+	ForagingReturns map[shared.ClientID]shared.Resources
+
 	// All global rules information
 	RulesInfo RulesContext
 
@@ -39,6 +42,18 @@ type GameState struct {
 	// IIGO turns in power (incremented and set by monitoring)
 	IIGOTurnsInPower map[shared.Role]uint
 
+	// IIGO tenure of each role, used to enforce term limits (updated by elections)
+	IIGOTenures map[shared.Role]IIGOTenure
+
+	// Islands holding the additional IIGO roles enabled in the config
+	IIGOAdditionalRoleIDs map[shared.Role]shared.ClientID
+
+	// IIGO Common Pool Reserve set by the Treasurer, which cannot be allocated this turn
+	IIGOCommonPoolReserve shared.Resources
+
+	// IIGO Allocation Audit by the Auditor: islands mapped to false have their allocation withheld
+	IIGOAllocationAudit map[shared.ClientID]bool
+
 	// IIGO Tax Amount Map
 	IIGOTaxAmount map[shared.ClientID]shared.Resources
 
@@ -51,6 +66,9 @@ type GameState struct {
 	// IIGO Sanction Amount Map
 	IIGOSanctionMap map[shared.ClientID]shared.Resources
 
+	// IIGO Collective Sanctions charged to islands at the end of this turn
+	IIGOCollectiveSanctions map[shared.ClientID]shared.Resources
+
 	// IIGO Sanction Cache is a record of all sanctions
 	IIGOSanctionCache map[int][]shared.Sanction
 
@@ -69,12 +87,107 @@ type GameState struct {
 	// IIGO Role Voting
 	IIGOElection []VotingInfo
 
+	// IIGO Election Recounts performed by the Judge this turn
+	IIGOElectionRecounts []ElectionRecount
+
+	// IIGO Recall petitions filed this turn and their outcome
+	IIGORecallPetitions []RecallRecord
+
+	// IIGO Sanction appeals heard this turn and their outcome
+	IIGOSanctionAppeals []AppealRecord
+
+	// IIGO Non-economic sanction consequences each island is under and the number of turns, including
+	// this one, they remain in force
+	IIGOSanctionConsequences map[shared.ClientID]map[shared.SanctionConsequence]uint
+
+	// IIGO Vote delegations: for each kind of vote, the island each island has delegated its vote to
+	IIGOVoteDelegations map[shared.VoteKind]map[shared.ClientID]shared.ClientID
+
+	// IIGO Legislative agenda: items queued across turns until they are voted on
+	IIGOLegislativeAgenda []shared.AgendaItem
+
+	// ID given to the next item queued on the legislative agenda
+	IIGONextAgendaItemID uint
+
+	// IIGO Motions voted on in this turn's legislative session
+	IIGOLegislativeSession []MotionRecord
+
+	// IIGO Ballot counted for each island in this turn's rule votes, by rule name. The server keeps them to
+	// resolve escrowed gifts, the islands only see the anonymous vote counts.
+	IIGORuleBallots map[string]map[shared.ClientID]shared.RuleVoteType
+
 	// IIGO Run Status
 	IIGORunStatus string
 
 	// IITO Transactions
 	IITOTransactions map[shared.ClientID]shared.GiftResponseDict
 
+	// IITO Contracts signed between islands, with their fulfilment so far
+	IITOContracts []shared.Contract
+
+	// ID given to the next contract proposed
+	IITONextContractID uint
+
+	// IITO Number of contracts each island has broken over the game
+	IITOBrokenAgreements map[shared.ClientID]uint
+
+	// IITO Loans made between islands, with their repayment so far
+	IITOLoans []shared.Loan
+
+	// ID given to the next loan offered
+	IITONextLoanID uint
+
+	// IITO Number of loans each island has defaulted on over the game
+	IITOLoanDefaults map[shared.ClientID]uint
+
+	// IITO Gifts held in escrow until their condition is met or they expire, and those resolved
+	IITOEscrowedGifts []shared.EscrowedGift
+
+	// ID given to the next escrowed gift
+	IITONextEscrowedGiftID uint
+
+	// Messages islands sent each other this turn
+	Messages []shared.MessageLogEntry
+
+	// IIFO Disaster predictions made over the game, scored once resolved
+	IIFOPredictions []shared.ScoredDisasterPrediction
+
+	// IIFO Rewards paid to the most accurate forecasters
+	IIFOPredictionRewards []shared.PredictionReward
+
+	// IIFO Prediction markets on disasters, with their current prices
+	IIFOPredictionMarkets []shared.PredictionMarket
+
+	// IIFO Shares held by islands in the prediction markets
+	IIFOPredictionMarketHoldings []shared.PredictionMarketHolding
+
+	// IIFO Audits of shared foraging information
+	IIFOForageAudits []shared.ForageAudit
+
+	// IITO Coalitions formed over the game, with their members and treasuries
+	IITOCoalitions []shared.Coalition
+
+	// IITO Changes in the membership and treasuries of coalitions
+	IITOCoalitionEvents []shared.CoalitionEvent
+
+	// IITO Foraging expeditions funded by coalition treasuries
+	IITOCoalitionExpeditions []shared.CoalitionExpedition
+
+	// IITO Transcript of this turn's gift negotiation
+	IITOGiftNegotiation []shared.GiftNegotiationEntry
+
+	// IITO Trades made on the market over the game
+	IITOMarketTrades []shared.MarketTrade
+
+	// IITO Clearing price and volume of each asset on the market in every turn
+	IITOMarketClearings []shared.MarketClearing
+
+	// Facts about each island's conduct verified by the server, readable by clients through the ServerReadHandle
+	ReputationLedger ReputationLedger
+
+	// Share of the common pool contributions each island announced over the game that it actually paid, from 0 to 1
+	ContributionHonestyScores map[shared.ClientID]float64
+
 	// Orchestration
 	SpeakerID   shared.ClientID
 	JudgeID     shared.ClientID
@@ -91,18 +204,50 @@ func (g GameState) Copy() GameState {
 	ret.Environment = g.Environment.Copy()
 	ret.DeerPopulation = g.DeerPopulation.Copy()
 	ret.ForagingHistory = copyForagingHistory(g.ForagingHistory)
+	ret.ForagingReturns = copyIIGOClientIDResourceMap(g.ForagingReturns)
 	ret.RulesInfo = copyRulesContext(g.RulesInfo)
 	ret.IIGOHistory = copyIIGOHistory(g.IIGOHistory)
 	ret.IIGORolesBudget = copyRolesBudget(g.IIGORolesBudget)
 	ret.IIGOTurnsInPower = copyTurnsInPower(g.IIGOTurnsInPower)
+	ret.IIGOTenures = copyIIGOTenures(g.IIGOTenures)
+	ret.IIGOAdditionalRoleIDs = copyAdditionalRoleIDs(g.IIGOAdditionalRoleIDs)
+	ret.IIGOAllocationAudit = copyAllocationAudit(g.IIGOAllocationAudit)
 	ret.IIGOTaxAmount = copyIIGOClientIDResourceMap(g.IIGOTaxAmount)
 	ret.IIGOAllocationMap = copyIIGOClientIDResourceMap(g.IIGOAllocationMap)
 	ret.IIGOSanctionMap = copyIIGOClientIDResourceMap(g.IIGOSanctionMap)
+	ret.IIGOCollectiveSanctions = copyIIGOClientIDResourceMap(g.IIGOCollectiveSanctions)
 	ret.IIGOHistoryCache = copyIIGOHistoryCache(g.IIGOHistoryCache)
 	ret.IIGOSanctionCache = copyIIGOSanctionCache(g.IIGOSanctionCache)
 	ret.IIGORoleMonitoringCache = copySingleIIGOEntry(g.IIGORoleMonitoringCache)
 	ret.IITOTransactions = copyIITOTransactions(g.IITOTransactions)
+	ret.IITOContracts = CopyContracts(g.IITOContracts)
+	ret.IITOBrokenAgreements = copyClientIDUintMap(g.IITOBrokenAgreements)
+	ret.IITOLoans = copyLoans(g.IITOLoans)
+	ret.IITOLoanDefaults = copyClientIDUintMap(g.IITOLoanDefaults)
+	ret.IITOEscrowedGifts = copyEscrowedGifts(g.IITOEscrowedGifts)
+	ret.IITOGiftNegotiation = copyGiftNegotiation(g.IITOGiftNegotiation)
+	ret.Messages = copyMessageLog(g.Messages)
+	ret.IIFOPredictions = copyScoredPredictions(g.IIFOPredictions)
+	ret.IIFOPredictionRewards = copyPredictionRewards(g.IIFOPredictionRewards)
+	ret.IIFOPredictionMarkets = copyPredictionMarkets(g.IIFOPredictionMarkets)
+	ret.IIFOPredictionMarketHoldings = copyPredictionMarketHoldings(g.IIFOPredictionMarketHoldings)
+	ret.IIFOForageAudits = copyForageAudits(g.IIFOForageAudits)
+	ret.IITOCoalitions = copyCoalitions(g.IITOCoalitions)
+	ret.IITOCoalitionEvents = copyCoalitionEvents(g.IITOCoalitionEvents)
+	ret.IITOCoalitionExpeditions = copyCoalitionExpeditions(g.IITOCoalitionExpeditions)
+	ret.IITOMarketTrades = copyMarketTrades(g.IITOMarketTrades)
+	ret.IITOMarketClearings = copyMarketClearings(g.IITOMarketClearings)
+	ret.ReputationLedger = g.ReputationLedger.Copy()
+	ret.ContributionHonestyScores = copyClientIDFloatMap(g.ContributionHonestyScores)
 	ret.IIGOElection = copyIIGOElection(g.IIGOElection)
+	ret.IIGOElectionRecounts = copyIIGOElectionRecounts(g.IIGOElectionRecounts)
+	ret.IIGORecallPetitions = copyIIGORecallPetitions(g.IIGORecallPetitions)
+	ret.IIGOSanctionAppeals = copyIIGOSanctionAppeals(g.IIGOSanctionAppeals)
+	ret.IIGOLegislativeAgenda = CopyLegislativeAgenda(g.IIGOLegislativeAgenda)
+	ret.IIGOVoteDelegations = CopyVoteDelegations(g.IIGOVoteDelegations)
+	ret.IIGOSanctionConsequences = copySanctionConsequences(g.IIGOSanctionConsequences)
+	ret.IIGOLegislativeSession = copyIIGOLegislativeSession(g.IIGOLegislativeSession)
+	ret.IIGORuleBallots = copyIIGORuleBallots(g.IIGORuleBallots)
 	return ret
 }
 
@@ -114,21 +259,54 @@ func (g *GameState) GetClientGameStateCopy(id shared.ClientID) ClientGameState {
 	}
 
 	return ClientGameState{
-		Season:             g.Season,
-		Turn:               g.Turn,
-		ClientInfo:         g.ClientInfos[id].Copy(),
-		ClientLifeStatuses: clientLifeStatuses,
-		CommonPool:         g.CommonPool,
-		Geography:          g.Environment.Geography,
-		SpeakerID:          g.SpeakerID,
-		JudgeID:            g.JudgeID,
-		PresidentID:        g.PresidentID,
-		IIGORolesBudget:    copyRolesBudget(g.IIGORolesBudget),
-		IIGOTurnsInPower:   copyTurnsInPower(g.IIGOTurnsInPower),
-		RulesInfo:          copyRulesContext(g.RulesInfo),
+		Season:                       g.Season,
+		Turn:                         g.Turn,
+		ClientInfo:                   g.ClientInfos[id].Copy(),
+		ClientLifeStatuses:           clientLifeStatuses,
+		CommonPool:                   g.CommonPool,
+		Geography:                    g.Environment.Geography,
+		SpeakerID:                    g.SpeakerID,
+		JudgeID:                      g.JudgeID,
+		PresidentID:                  g.PresidentID,
+		IIGOAdditionalRoleIDs:        copyAdditionalRoleIDs(g.IIGOAdditionalRoleIDs),
+		IIGORolesBudget:              copyRolesBudget(g.IIGORolesBudget),
+		IIGOTurnsInPower:             copyTurnsInPower(g.IIGOTurnsInPower),
+		IIGOCommonPoolReserve:        g.IIGOCommonPoolReserve,
+		IIGOAllocationAudit:          copyAllocationAudit(g.IIGOAllocationAudit),
+		IIGOLegislativeAgenda:        CopyLegislativeAgenda(g.IIGOLegislativeAgenda),
+		IIGOVoteDelegations:          CopyVoteDelegations(g.IIGOVoteDelegations),
+		IIGOSanctionConsequences:     copySanctionConsequences(g.IIGOSanctionConsequences),
+		IIFOPredictions:              getResolvedPredictions(g.IIFOPredictions),
+		IIFOPredictionRewards:        copyPredictionRewards(g.IIFOPredictionRewards),
+		IIFOPredictionMarkets:        copyPredictionMarkets(g.IIFOPredictionMarkets),
+		IIFOPredictionMarketHoldings: getClientPredictionMarketHoldings(g.IIFOPredictionMarketHoldings, id),
+		IIFOForageAudits:             getClientForageAudits(g.IIFOForageAudits, id),
+		IITOCoalitions:               copyCoalitions(g.IITOCoalitions),
+		IITOCoalitionEvents:          copyCoalitionEvents(g.IITOCoalitionEvents),
+		IITOCoalitionExpeditions:     copyCoalitionExpeditions(g.IITOCoalitionExpeditions),
+		IITOContracts:                getClientContracts(g.IITOContracts, id),
+		IITOBrokenAgreements:         copyClientIDUintMap(g.IITOBrokenAgreements),
+		IITOLoans:                    getClientLoans(g.IITOLoans, id),
+		IITOLoanDefaults:             copyClientIDUintMap(g.IITOLoanDefaults),
+		IITOEscrowedGifts:            getClientEscrowedGifts(g.IITOEscrowedGifts, id),
+		IITOGiftNegotiation:          getClientGiftNegotiation(g.IITOGiftNegotiation, id),
+		IITOMarketTrades:             getClientMarketTrades(g.IITOMarketTrades, id),
+		IITOMarketClearings:          copyMarketClearings(g.IITOMarketClearings),
+		RulesInfo:                    copyRulesContext(g.RulesInfo),
 	}
 }
 
+// getClientContracts returns a copy of the contracts the client is a party to
+func getClientContracts(contracts []shared.Contract, id shared.ClientID) []shared.Contract {
+	ret := []shared.Contract{}
+	for _, contract := range contracts {
+		if contract.Proposer == id || contract.Counterparty == id {
+			ret = append(ret, contract.Copy())
+		}
+	}
+	return ret
+}
+
 func copyClientInfos(m map[shared.ClientID]ClientInfo) map[shared.ClientID]ClientInfo {
 	ret := make(map[shared.ClientID]ClientInfo, len(m))
 	for k, v := range m {
@@ -153,6 +331,35 @@ func copyTurnsInPower(m map[shared.Role]uint) map[shared.Role]uint {
 	return ret
 }
 
+func copyAdditionalRoleIDs(m map[shared.Role]shared.ClientID) map[shared.Role]shared.ClientID {
+	ret := make(map[shared.Role]shared.ClientID, len(m))
+	for k, v := range m {
+		ret[k] = v
+	}
+	return ret
+}
+
+func copyAllocationAudit(m map[shared.ClientID]bool) map[shared.ClientID]bool {
+	ret := make(map[shared.ClientID]bool, len(m))
+	for k, v := range m {
+		ret[k] = v
+	}
+	return ret
+}
+
+func copyIIGOTenures(m map[shared.Role]IIGOTenure) map[shared.Role]IIGOTenure {
+	ret := make(map[shared.Role]IIGOTenure, len(m))
+	for role, tenure := range m {
+		leftOffice := make(map[shared.ClientID]uint, len(tenure.LeftOffice))
+		for k, v := range tenure.LeftOffice {
+			leftOffice[k] = v
+		}
+		tenure.LeftOffice = leftOffice
+		ret[role] = tenure
+	}
+	return ret
+}
+
 func copyIIGOHistory(iigoHistory map[uint][]shared.Accountability) map[uint][]shared.Accountability {
 	targetMap := make(map[uint][]shared.Accountability)
 	for key, value := range iigoHistory {
@@ -224,6 +431,264 @@ func copyIITOTransactions(iitoHistory map[shared.ClientID]shared.GiftResponseDic
 	return targetMap
 }
 
+// getClientLoans returns a copy of the loans the client is the lender or the borrower of
+func getClientLoans(loans []shared.Loan, id shared.ClientID) []shared.Loan {
+	ret := []shared.Loan{}
+	for _, loan := range loans {
+		if loan.Lender == id || loan.Borrower == id {
+			ret = append(ret, loan)
+		}
+	}
+	return ret
+}
+
+// CopyContracts returns a deep copy of the contracts
+func CopyContracts(input []shared.Contract) []shared.Contract {
+	if input == nil {
+		return nil
+	}
+	ret := make([]shared.Contract, len(input))
+	for i, contract := range input {
+		ret[i] = contract.Copy()
+	}
+	return ret
+}
+
+func copyLoans(input []shared.Loan) []shared.Loan {
+	if input == nil {
+		return nil
+	}
+	ret := make([]shared.Loan, len(input))
+	copy(ret, input)
+	return ret
+}
+
+func copyEscrowedGifts(input []shared.EscrowedGift) []shared.EscrowedGift {
+	if input == nil {
+		return nil
+	}
+	ret := make([]shared.EscrowedGift, len(input))
+	copy(ret, input)
+	return ret
+}
+
+func copyMessageLog(input []shared.MessageLogEntry) []shared.MessageLogEntry {
+	if input == nil {
+		return nil
+	}
+	ret := make([]shared.MessageLogEntry, len(input))
+	for i, entry := range input {
+		ret[i] = entry
+		ret[i].To = copyClientIDs(entry.To)
+		ret[i].Recipients = copyClientIDs(entry.Recipients)
+	}
+	return ret
+}
+
+func copyScoredPredictions(input []shared.ScoredDisasterPrediction) []shared.ScoredDisasterPrediction {
+	if input == nil {
+		return nil
+	}
+	ret := make([]shared.ScoredDisasterPrediction, len(input))
+	copy(ret, input)
+	return ret
+}
+
+// getResolvedPredictions returns a copy of the predictions which have been scored
+func getResolvedPredictions(predictions []shared.ScoredDisasterPrediction) []shared.ScoredDisasterPrediction {
+	ret := []shared.ScoredDisasterPrediction{}
+	for _, prediction := range predictions {
+		if prediction.Resolved {
+			ret = append(ret, prediction)
+		}
+	}
+	return ret
+}
+
+func copyPredictionRewards(input []shared.PredictionReward) []shared.PredictionReward {
+	if input == nil {
+		return nil
+	}
+	ret := make([]shared.PredictionReward, len(input))
+	copy(ret, input)
+	return ret
+}
+
+func copyPredictionMarkets(input []shared.PredictionMarket) []shared.PredictionMarket {
+	if input == nil {
+		return nil
+	}
+	ret := make([]shared.PredictionMarket, len(input))
+	copy(ret, input)
+	return ret
+}
+
+func copyPredictionMarketHoldings(input []shared.PredictionMarketHolding) []shared.PredictionMarketHolding {
+	if input == nil {
+		return nil
+	}
+	ret := make([]shared.PredictionMarketHolding, len(input))
+	copy(ret, input)
+	return ret
+}
+
+// getClientPredictionMarketHoldings returns a copy of the prediction market shares held by a client
+func getClientPredictionMarketHoldings(holdings []shared.PredictionMarketHolding, id shared.ClientID) []shared.PredictionMarketHolding {
+	ret := []shared.PredictionMarketHolding{}
+	for _, holding := range holdings {
+		if holding.Holder == id {
+			ret = append(ret, holding)
+		}
+	}
+	return ret
+}
+
+func copyForageAudits(input []shared.ForageAudit) []shared.ForageAudit {
+	if input == nil {
+		return nil
+	}
+	ret := make([]shared.ForageAudit, len(input))
+	copy(ret, input)
+	return ret
+}
+
+// getClientForageAudits returns a copy of the audits a client paid for
+func getClientForageAudits(audits []shared.ForageAudit, id shared.ClientID) []shared.ForageAudit {
+	ret := []shared.ForageAudit{}
+	for _, audit := range audits {
+		if audit.Auditor == id {
+			ret = append(ret, audit)
+		}
+	}
+	return ret
+}
+
+func copyCoalitions(input []shared.Coalition) []shared.Coalition {
+	if input == nil {
+		return nil
+	}
+	ret := make([]shared.Coalition, len(input))
+	for i, coalition := range input {
+		ret[i] = coalition
+		ret[i].Members = copyClientIDs(coalition.Members)
+	}
+	return ret
+}
+
+func copyCoalitionEvents(input []shared.CoalitionEvent) []shared.CoalitionEvent {
+	if input == nil {
+		return nil
+	}
+	ret := make([]shared.CoalitionEvent, len(input))
+	copy(ret, input)
+	return ret
+}
+
+func copyCoalitionExpeditions(input []shared.CoalitionExpedition) []shared.CoalitionExpedition {
+	if input == nil {
+		return nil
+	}
+	ret := make([]shared.CoalitionExpedition, len(input))
+	copy(ret, input)
+	return ret
+}
+
+func copyGiftNegotiation(input []shared.GiftNegotiationEntry) []shared.GiftNegotiationEntry {
+	if input == nil {
+		return nil
+	}
+	ret := make([]shared.GiftNegotiationEntry, len(input))
+	copy(ret, input)
+	return ret
+}
+
+// getClientGiftNegotiation returns a copy of the moves of the gift negotiation the client made or received
+func getClientGiftNegotiation(transcript []shared.GiftNegotiationEntry, id shared.ClientID) []shared.GiftNegotiationEntry {
+	ret := []shared.GiftNegotiationEntry{}
+	for _, entry := range transcript {
+		if entry.From == id || entry.To == id {
+			ret = append(ret, entry)
+		}
+	}
+	return ret
+}
+
+func copyMarketTrades(input []shared.MarketTrade) []shared.MarketTrade {
+	if input == nil {
+		return nil
+	}
+	ret := make([]shared.MarketTrade, len(input))
+	copy(ret, input)
+	return ret
+}
+
+func copyMarketClearings(input []shared.MarketClearing) []shared.MarketClearing {
+	if input == nil {
+		return nil
+	}
+	ret := make([]shared.MarketClearing, len(input))
+	copy(ret, input)
+	return ret
+}
+
+// getClientMarketTrades returns a copy of the market trades the client is the buyer or the seller of
+func getClientMarketTrades(trades []shared.MarketTrade, id shared.ClientID) []shared.MarketTrade {
+	ret := []shared.MarketTrade{}
+	for _, trade := range trades {
+		if trade.Buyer == id || trade.Seller == id {
+			ret = append(ret, trade)
+		}
+	}
+	return ret
+}
+
+// getClientEscrowedGifts returns a copy of the escrowed gifts the client is the giver or the recipient of
+func getClientEscrowedGifts(gifts []shared.EscrowedGift, id shared.ClientID) []shared.EscrowedGift {
+	ret := []shared.EscrowedGift{}
+	for _, gift := range gifts {
+		if gift.From == id || gift.To == id {
+			ret = append(ret, gift)
+		}
+	}
+	return ret
+}
+
+func copyIIGORuleBallots(input map[string]map[shared.ClientID]shared.RuleVoteType) map[string]map[shared.ClientID]shared.RuleVoteType {
+	if input == nil {
+		return nil
+	}
+	ret := make(map[string]map[shared.ClientID]shared.RuleVoteType, len(input))
+	for ruleName, ballots := range input {
+		ret[ruleName] = make(map[shared.ClientID]shared.RuleVoteType, len(ballots))
+		for voter, ballot := range ballots {
+			ret[ruleName][voter] = ballot
+		}
+	}
+	return ret
+}
+
+func copyClientIDUintMap(m map[shared.ClientID]uint) map[shared.ClientID]uint {
+	if m == nil {
+		return nil
+	}
+	ret := make(map[shared.ClientID]uint, len(m))
+	for k, v := range m {
+		ret[k] = v
+	}
+	return ret
+}
+
+func copyClientIDFloatMap(m map[shared.ClientID]float64) map[shared.ClientID]float64 {
+	if m == nil {
+		return nil
+	}
+	ret := make(map[shared.ClientID]float64, len(m))
+	for k, v := range m {
+		ret[k] = v
+	}
+	return ret
+}
+
 func copyForagingHistory(fHist map[shared.ForageType][]foraging.ForagingReport) map[shared.ForageType][]foraging.ForagingReport {
 	ret := make(map[shared.ForageType][]foraging.ForagingReport, len(fHist))
 	for k, v := range fHist { // iterate over different foraging types
@@ -237,6 +702,150 @@ func copyForagingHistory(fHist map[shared.ForageType][]foraging.ForagingReport)
 
 func copyIIGOElection(input []VotingInfo) []VotingInfo {
 	ret := make([]VotingInfo, len(input))
+	for i, info := range input {
+		ret[i] = info
+		ret[i].CandidateList = copyClientIDs(info.CandidateList)
+		ret[i].VoterList = copyClientIDs(info.VoterList)
+		if info.Delegates != nil {
+			ret[i].Delegates = make(map[shared.ClientID]shared.ClientID, len(info.Delegates))
+			for voter, caster := range info.Delegates {
+				ret[i].Delegates[voter] = caster
+			}
+		}
+		ret[i].Votes = copyBallots(info.Votes)
+		if info.RoundVotes != nil {
+			ret[i].RoundVotes = make([][][]shared.ClientID, len(info.RoundVotes))
+			for j, round := range info.RoundVotes {
+				ret[i].RoundVotes[j] = copyBallots(round)
+			}
+		}
+		ret[i].CountingTrace = copyStrings(info.CountingTrace)
+		ret[i].IneligibleCandidates = copyClientIDs(info.IneligibleCandidates)
+	}
+	return ret
+}
+
+func copyIIGOElectionRecounts(input []ElectionRecount) []ElectionRecount {
+	ret := make([]ElectionRecount, len(input))
+	for i, recount := range input {
+		ret[i] = recount
+		ret[i].CountingTrace = copyStrings(recount.CountingTrace)
+	}
+	return ret
+}
+
+func copyIIGORecallPetitions(input []RecallRecord) []RecallRecord {
+	ret := make([]RecallRecord, len(input))
+	for i, record := range input {
+		ret[i] = record
+		ret[i].Petition.CoSigners = copyClientIDs(record.Petition.CoSigners)
+	}
+	return ret
+}
+
+func copyIIGOSanctionAppeals(input []AppealRecord) []AppealRecord {
+	ret := make([]AppealRecord, len(input))
+	for i, record := range input {
+		ret[i] = record
+		ret[i].Appeal.RulesBroken = copyStrings(record.Appeal.RulesBroken)
+		ret[i].Appeal.RecordedVariables = copyVariableValuePairs(record.Appeal.RecordedVariables)
+		ret[i].Panel = copyClientIDs(record.Panel)
+		ret[i].RuleVerdicts = make(map[string]bool, len(record.RuleVerdicts))
+		for rule, verdict := range record.RuleVerdicts {
+			ret[i].RuleVerdicts[rule] = verdict
+		}
+		ret[i].Reasoning = copyStrings(record.Reasoning)
+	}
+	return ret
+}
+
+// CopyLegislativeAgenda returns a deep copy of the legislative agenda
+func CopyLegislativeAgenda(input []shared.AgendaItem) []shared.AgendaItem {
+	if input == nil {
+		return nil
+	}
+	ret := make([]shared.AgendaItem, len(input))
+	for i, item := range input {
+		ret[i] = item
+		ret[i].Rules = rules.CopyRuleMatrices(item.Rules)
+	}
+	return ret
+}
+
+// CopyVoteDelegations returns a deep copy of the vote delegation graphs
+func CopyVoteDelegations(input map[shared.VoteKind]map[shared.ClientID]shared.ClientID) map[shared.VoteKind]map[shared.ClientID]shared.ClientID {
+	if input == nil {
+		return nil
+	}
+	ret := make(map[shared.VoteKind]map[shared.ClientID]shared.ClientID, len(input))
+	for kind, graph := range input {
+		ret[kind] = make(map[shared.ClientID]shared.ClientID, len(graph))
+		for island, delegate := range graph {
+			ret[kind][island] = delegate
+		}
+	}
+	return ret
+}
+
+// copySanctionConsequences returns a deep copy of the sanction consequences islands are under
+func copySanctionConsequences(input map[shared.ClientID]map[shared.SanctionConsequence]uint) map[shared.ClientID]map[shared.SanctionConsequence]uint {
+	if input == nil {
+		return nil
+	}
+	ret := make(map[shared.ClientID]map[shared.SanctionConsequence]uint, len(input))
+	for island, consequences := range input {
+		ret[island] = make(map[shared.SanctionConsequence]uint, len(consequences))
+		for consequence, turns := range consequences {
+			ret[island][consequence] = turns
+		}
+	}
+	return ret
+}
+
+func copyIIGOLegislativeSession(input []MotionRecord) []MotionRecord {
+	ret := make([]MotionRecord, len(input))
+	for i, record := range input {
+		ret[i] = record
+		ret[i].RuleNames = copyStrings(record.RuleNames)
+		ret[i].Voters = copyClientIDs(record.Voters)
+	}
+	return ret
+}
+
+func copyVariableValuePairs(input []rules.VariableValuePair) []rules.VariableValuePair {
+	if input == nil {
+		return nil
+	}
+	ret := make([]rules.VariableValuePair, len(input))
+	copy(ret, input)
+	return ret
+}
+
+func copyBallots(input [][]shared.ClientID) [][]shared.ClientID {
+	if input == nil {
+		return nil
+	}
+	ret := make([][]shared.ClientID, len(input))
+	for i, ballot := range input {
+		ret[i] = copyClientIDs(ballot)
+	}
+	return ret
+}
+
+func copyClientIDs(input []shared.ClientID) []shared.ClientID {
+	if input == nil {
+		return nil
+	}
+	ret := make([]shared.ClientID, len(input))
+	copy(ret, input)
+	return ret
+}
+
+func copyStrings(input []string) []string {
+	if input == nil {
+		return nil
+	}
+	ret := make([]string, len(input))
 	copy(ret, input)
 	return ret
 }
@@ -255,12 +864,129 @@ type ClientInfo struct {
 	// REMEMBER TO EDIT `Copy` IF YOU ADD ANY REFERENCE TYPES (maps, slices, channels, functions etc.)
 }
 
-// VotingInfo contains all the information necessary to visualise voting
+// VotingInfo contains all the information necessary to visualise and audit voting
 type VotingInfo struct {
-	RoleToElect  shared.Role
-	VotingMethod shared.ElectionVotingMethod
-	VoterList    []shared.ClientID
-	Votes        [][]shared.ClientID
+	RoleToElect   shared.Role
+	VotingMethod  shared.ElectionVotingMethod
+	CandidateList []shared.ClientID
+	VoterList     []shared.ClientID
+	Votes         [][]shared.ClientID
+	// Delegates maps each voter whose vote was delegated to the island which cast it
+	Delegates map[shared.ClientID]shared.ClientID
+	// RoundVotes are the ballots cast in any further counting rounds (runoff methods only)
+	RoundVotes [][][]shared.ClientID
+	// Anonymised is true if the ballots can no longer be matched to the voters in VoterList
+	Anonymised    bool
+	CountingTrace []string
+	// IneligibleCandidates are the islands left off the ballot by term limits or eligibility criteria
+	IneligibleCandidates []shared.ClientID
+	// EligibilityWaived is true if no island was eligible so every candidate was allowed to stand
+	EligibilityWaived bool
+	// ElectedWinner is the winner of the count, AppointedWinner is who the appointing role actually chose
+	ElectedWinner   shared.ClientID
+	AppointedWinner shared.ClientID
+	AppointedBy     shared.ClientID
+}
+
+// IIGOTenure records the holder of an IIGO role and who held it before
+type IIGOTenure struct {
+	Holder shared.ClientID
+	// ConsecutiveTerms is the number of elections in a row won by Holder
+	ConsecutiveTerms uint
+	// LeftOffice maps each former holder to the turn it last left the role
+	LeftOffice map[shared.ClientID]uint
+}
+
+// RecallRecord is the outcome of a petition to recall an IIGO role holder
+type RecallRecord struct {
+	Petition shared.RecallPetition
+	// ElectionForced is true if the petition had enough co-signers to force an election
+	ElectionForced bool
+	// RunBy is the role whose branch ran the forced election
+	RunBy        shared.Role
+	ElectionHeld bool
+	Winner       shared.ClientID
+}
+
+// AppealRecord is the outcome of an island's appeal against the sanction imposed on it
+type AppealRecord struct {
+	Appeal  shared.SanctionAppeal
+	FeePaid shared.Resources
+	// Panel are the islands randomly selected to re-evaluate the rules
+	Panel []shared.ClientID
+	// RuleVerdicts maps each contested rule to true if the panel found it was broken
+	RuleVerdicts map[string]bool
+	FinalTier    shared.IIGOSanctionsTier
+	Outcome      shared.AppealOutcome
+	Reasoning    []string
+}
+
+// MotionRecord is the outcome of a vote held in a legislative session
+type MotionRecord struct {
+	ItemID uint
+	// AmendmentID is set if the motion was an amendment to the item rather than the item itself
+	AmendmentID   uint
+	IsAmendment   bool
+	RuleNames     []string
+	Voters        []shared.ClientID
+	VotesInFavour uint
+	VotesAgainst  uint
+	Passed        bool
+	// Constitutional items take effect once they have passed the required number of consecutive sessions
+	SessionsPassed   uint
+	SessionsRequired uint
+}
+
+// ElectionRecount is the result of the Judge recounting the recorded ballots of an election
+type ElectionRecount struct {
+	RoleToElect     shared.Role
+	RecountedWinner shared.ClientID
+	ElectedWinner   shared.ClientID
+	AppointedWinner shared.ClientID
+	AppointedBy     shared.ClientID
+	// TallyMatches is false if the recount disagrees with the originally counted winner
+	TallyMatches bool
+	// AppointmentOverridden is true if the appointing role did not appoint the true winner
+	AppointmentOverridden bool
+	CountingTrace         []string
+}
+
+// ReputationRecord holds the facts about an island's conduct in a turn, as observed by the server
+type ReputationRecord struct {
+	Turn uint
+	// GiftsPromised is the total of the island's gift offers accepted by their recipients,
+	// and GiftsGiven what it actually gave when the gifts were made
+	GiftsPromised shared.Resources
+	GiftsGiven    shared.Resources
+	// IntendedContribution is the common pool contribution the island announced to other islands, if
+	// ContributionAnnounced, and ActualContribution the tax it actually paid. ContributionGap is the
+	// intended minus the actual contribution, positive if the island paid less than it announced.
+	ContributionAnnounced bool
+	IntendedContribution  shared.Resources
+	ActualContribution    shared.Resources
+	ContributionGap       shared.Resources
+	// SanctionDue is the sanction the island was asked to pay, and SanctionPaid what it actually paid
+	SanctionDue  shared.Resources
+	SanctionPaid shared.Resources
+	// AgreementsBroken counts the contracts breached and market trades not delivered by the island
+	AgreementsBroken uint
+	LoanDefaults     uint
+}
+
+// ReputationLedger maps each island to its reputation records, one for every turn something was recorded
+type ReputationLedger map[shared.ClientID][]ReputationRecord
+
+// Copy returns a deep copy of the ReputationLedger.
+func (l ReputationLedger) Copy() ReputationLedger {
+	if l == nil {
+		return nil
+	}
+	ret := ReputationLedger{}
+	for id, records := range l {
+		ret[id] = make([]ReputationRecord, len(records))
+		copy(ret[id], records)
+	}
+	return ret
 }
 
 // Copy returns a deep copy of the ClientInfo.
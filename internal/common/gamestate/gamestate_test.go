@@ -56,6 +56,78 @@ func TestGetClientGameStateCopy(t *testing.T) {
 			shared.President: 3,
 			shared.Speaker:   4,
 		},
+		IIGOAdditionalRoleIDs: map[shared.Role]shared.ClientID{
+			shared.Treasurer: shared.Team2,
+		},
+		IIGOCommonPoolReserve: 5,
+		IIGOAllocationAudit:   map[shared.ClientID]bool{shared.Team1: true, shared.Team2: false},
+		IIGOLegislativeAgenda: []shared.AgendaItem{{ID: 1, TurnQueued: 2}},
+		IIGOVoteDelegations: map[shared.VoteKind]map[shared.ClientID]shared.ClientID{
+			shared.RuleVotes: {shared.Team1: shared.Team2},
+		},
+		IIGOSanctionConsequences: map[shared.ClientID]map[shared.SanctionConsequence]uint{
+			shared.Team3: {shared.LoseVotingRight: 2, shared.ExcludedFromGifts: 2},
+		},
+		IITOContracts: []shared.Contract{
+			{
+				ID:           1,
+				Proposer:     shared.Team1,
+				Counterparty: shared.Team2,
+				Obligations:  []shared.ContractObligation{{From: shared.Team1, Amount: 10, Turns: 5}},
+			},
+		},
+		IITOBrokenAgreements: map[shared.ClientID]uint{shared.Team2: 1},
+		IITOLoans: []shared.Loan{
+			{
+				LoanOffer: shared.LoanOffer{Borrower: shared.Team3, Principal: 20, InterestRate: 0.1, Instalments: 2},
+				Lender:    shared.Team2,
+			},
+		},
+		IITOLoanDefaults: map[shared.ClientID]uint{shared.Team3: 1},
+		IITOEscrowedGifts: []shared.EscrowedGift{
+			{
+				EscrowedGiftOffer: shared.EscrowedGiftOffer{To: shared.Team1, Amount: 30, Condition: shared.RecipientHitByDisaster, ExpiryTurns: 3},
+				From:              shared.Team3,
+			},
+		},
+		IITOGiftNegotiation: []shared.GiftNegotiationEntry{
+			{
+				From:                   shared.Team2,
+				To:                     shared.Team3,
+				GiftNegotiationMessage: shared.GiftNegotiationMessage{Kind: shared.GiftOpeningOffer, Amount: 10},
+			},
+		},
+		IITOMarketTrades: []shared.MarketTrade{
+			{Turn: 3, Asset: shared.IOU, Buyer: shared.Team1, Seller: shared.Team2, Quantity: 10, Price: 0.9},
+		},
+		IITOMarketClearings: []shared.MarketClearing{
+			{Turn: 3, Asset: shared.IOU, Price: 0.9, Volume: 10},
+		},
+		IIFOPredictions: []shared.ScoredDisasterPrediction{
+			{Predictor: shared.Team1, TurnMade: 1, Resolved: true, TurnResolved: 2, BrierScore: 0.25},
+			{Predictor: shared.Team2, TurnMade: 3},
+		},
+		IIFOPredictionRewards: []shared.PredictionReward{
+			{Turn: 2, Forecaster: shared.Team1, MeanBrierScore: 0.25, Amount: 5},
+		},
+		IIFOPredictionMarkets: []shared.PredictionMarket{
+			{Kind: shared.DisasterWithinTurns, Deadline: 5, Liquidity: 10, YesShares: 10, Price: 0.73},
+		},
+		IIFOPredictionMarketHoldings: []shared.PredictionMarketHolding{
+			{Holder: shared.Team2, YesShares: 10, Cost: 5.8},
+		},
+		IITOCoalitions: []shared.Coalition{
+			{Name: "Reef", Founder: shared.Team1, Members: []shared.ClientID{shared.Team1, shared.Team2}, Treasury: 10},
+		},
+		IITOCoalitionEvents: []shared.CoalitionEvent{
+			{Coalition: "Reef", Kind: shared.CoalitionJoined, ClientID: shared.Team2},
+		},
+		IITOCoalitionExpeditions: []shared.CoalitionExpedition{
+			{Coalition: "Reef", ForageType: shared.FishForageType, Input: 5, Return: 7},
+		},
+		IIFOForageAudits: []shared.ForageAudit{
+			{Turn: 3, Auditor: shared.Team3, Subject: shared.Team1, Verification: shared.ForageReportFalse, Cost: 5},
+		},
 		CommonPool: 20,
 		RulesInfo: RulesContext{
 			VariableMap:        map[rules.VariableFieldName]rules.VariableValuePair{},
@@ -70,20 +142,78 @@ func TestGetClientGameStateCopy(t *testing.T) {
 		shared.Team3: gameState.ClientInfos[shared.Team3].LifeStatus,
 	}
 
+	contracts := map[shared.ClientID][]shared.Contract{
+		shared.Team1: gameState.IITOContracts,
+		shared.Team2: gameState.IITOContracts,
+		shared.Team3: {},
+	}
+	escrowedGifts := map[shared.ClientID][]shared.EscrowedGift{
+		shared.Team1: gameState.IITOEscrowedGifts,
+		shared.Team2: {},
+		shared.Team3: gameState.IITOEscrowedGifts,
+	}
+	giftNegotiation := map[shared.ClientID][]shared.GiftNegotiationEntry{
+		shared.Team1: {},
+		shared.Team2: gameState.IITOGiftNegotiation,
+		shared.Team3: gameState.IITOGiftNegotiation,
+	}
+	marketTrades := map[shared.ClientID][]shared.MarketTrade{
+		shared.Team1: gameState.IITOMarketTrades,
+		shared.Team2: gameState.IITOMarketTrades,
+		shared.Team3: {},
+	}
+	predictionMarketHoldings := map[shared.ClientID][]shared.PredictionMarketHolding{
+		shared.Team1: {},
+		shared.Team2: gameState.IIFOPredictionMarketHoldings,
+		shared.Team3: {},
+	}
+	forageAudits := map[shared.ClientID][]shared.ForageAudit{
+		shared.Team1: {},
+		shared.Team2: {},
+		shared.Team3: gameState.IIFOForageAudits,
+	}
+	loans := map[shared.ClientID][]shared.Loan{
+		shared.Team1: {},
+		shared.Team2: gameState.IITOLoans,
+		shared.Team3: gameState.IITOLoans,
+	}
+
 	cases := []shared.ClientID{shared.Team1, shared.Team2, shared.Team3}
 
 	for _, tc := range cases {
 		t.Run(tc.String(), func(t *testing.T) {
 			expectClientGS := ClientGameState{
-				Season:             gameState.Season,
-				Turn:               gameState.Turn,
-				ClientInfo:         gameState.ClientInfos[tc],
-				ClientLifeStatuses: lifeStatuses,
-				CommonPool:         gameState.CommonPool,
-				Geography:          gameState.Environment.Geography,
-				IIGORolesBudget:    gameState.IIGORolesBudget,
-				IIGOTurnsInPower:   gameState.IIGOTurnsInPower,
-				RulesInfo:          gameState.RulesInfo,
+				Season:                       gameState.Season,
+				Turn:                         gameState.Turn,
+				ClientInfo:                   gameState.ClientInfos[tc],
+				ClientLifeStatuses:           lifeStatuses,
+				CommonPool:                   gameState.CommonPool,
+				Geography:                    gameState.Environment.Geography,
+				IIGOAdditionalRoleIDs:        gameState.IIGOAdditionalRoleIDs,
+				IIGORolesBudget:              gameState.IIGORolesBudget,
+				IIGOTurnsInPower:             gameState.IIGOTurnsInPower,
+				IIGOCommonPoolReserve:        gameState.IIGOCommonPoolReserve,
+				IIGOAllocationAudit:          gameState.IIGOAllocationAudit,
+				IIGOLegislativeAgenda:        gameState.IIGOLegislativeAgenda,
+				IIGOVoteDelegations:          gameState.IIGOVoteDelegations,
+				IIGOSanctionConsequences:     gameState.IIGOSanctionConsequences,
+				IIFOPredictions:              gameState.IIFOPredictions[:1],
+				IIFOPredictionRewards:        gameState.IIFOPredictionRewards,
+				IIFOPredictionMarkets:        gameState.IIFOPredictionMarkets,
+				IIFOPredictionMarketHoldings: predictionMarketHoldings[tc],
+				IIFOForageAudits:             forageAudits[tc],
+				IITOCoalitions:               gameState.IITOCoalitions,
+				IITOCoalitionEvents:          gameState.IITOCoalitionEvents,
+				IITOCoalitionExpeditions:     gameState.IITOCoalitionExpeditions,
+				IITOContracts:                contracts[tc],
+				IITOBrokenAgreements:         gameState.IITOBrokenAgreements,
+				IITOLoans:                    loans[tc],
+				IITOLoanDefaults:             gameState.IITOLoanDefaults,
+				IITOEscrowedGifts:            escrowedGifts[tc],
+				IITOGiftNegotiation:          giftNegotiation[tc],
+				IITOMarketTrades:             marketTrades[tc],
+				IITOMarketClearings:          gameState.IITOMarketClearings,
+				RulesInfo:                    gameState.RulesInfo,
 			}
 
 			gotClientGS := gameState.GetClientGameStateCopy(tc)
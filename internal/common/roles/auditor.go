@@ -0,0 +1,12 @@
+package roles
+
+import (
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+// Auditor is an interface that is implemented by baseAuditor but can also be
+// optionally implemented by individual islands.
+// Auditor is an additional IIGO role independently checking the President's allocations
+type Auditor interface {
+	AuditAllocations(requests map[shared.ClientID]shared.Resources, allocations map[shared.ClientID]shared.Resources) (map[shared.ClientID]bool, bool)
+}
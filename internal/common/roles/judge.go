@@ -16,4 +16,5 @@ type Judge interface {
 	GetSanctionThresholds() map[shared.IIGOSanctionsTier]shared.IIGOSanctionsScore
 	GetPardonedIslands(currentSanctions map[int][]shared.Sanction) map[int][]bool
 	HistoricalRetributionEnabled() bool
+	CallElectionRecount(electionsHeld []shared.Role) bool
 }
@@ -10,6 +10,7 @@ import (
 type Speaker interface {
 	PayJudge() shared.SpeakerReturnContent
 	DecideAgenda(rules.RuleMatrix) shared.SpeakerReturnContent
+	DecideLegislativeSession([]shared.AgendaItem, []shared.Amendment) shared.LegislativeSession
 	DecideVote(rules.RuleMatrix, []shared.ClientID) shared.SpeakerReturnContent
 	DecideAnnouncement(rules.RuleMatrix, bool) shared.SpeakerReturnContent
 	CallJudgeElection(shared.MonitorResult, int, []shared.ClientID) shared.ElectionSettings
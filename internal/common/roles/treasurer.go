@@ -0,0 +1,12 @@
+package roles
+
+import (
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+// Treasurer is an interface that is implemented by baseTreasurer but can also be
+// optionally implemented by individual islands.
+// Treasurer is an additional IIGO role managing the common pool
+type Treasurer interface {
+	SetCommonPoolReserve(commonPool shared.Resources) (shared.Resources, bool)
+}
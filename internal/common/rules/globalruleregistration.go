@@ -412,6 +412,28 @@ func registerRulesByMass(availableRules map[string]RuleMatrix) map[string]RuleMa
 			Mutable: false,
 			Linked:  false,
 		},
+		{
+			Name: "must_hold_recall_election",
+			ReqVar: []VariableFieldName{
+				RecallPetitionSucceeded,
+				RecallElectionHeld,
+			},
+			Values:  []float64{1, -1, 0},
+			Aux:     []float64{0},
+			Mutable: false,
+			Linked:  false,
+		},
+		{
+			Name: "auditor_must_audit_allocations",
+			ReqVar: []VariableFieldName{
+				AllocationAuditRequired,
+				AllocationAuditPerformed,
+			},
+			Values:  []float64{1, -1, 0},
+			Aux:     []float64{0},
+			Mutable: false,
+			Linked:  false,
+		},
 		{
 			Name: "must_appoint_elected_island",
 			ReqVar: []VariableFieldName{
@@ -422,6 +444,16 @@ func registerRulesByMass(availableRules map[string]RuleMatrix) map[string]RuleMa
 			Mutable: false,
 			Linked:  false,
 		},
+		{
+			Name: "must_appoint_eligible_island",
+			ReqVar: []VariableFieldName{
+				AppointmentEligible,
+			},
+			Values:  []float64{1, -1},
+			Aux:     []float64{0},
+			Mutable: false,
+			Linked:  false,
+		},
 		{
 			Name: "increment_budget_speaker",
 			ReqVar: []VariableFieldName{
@@ -476,8 +508,120 @@ func registerRulesByMass(availableRules map[string]RuleMatrix) map[string]RuleMa
 			LinkType:   ParentFailAutoRulePass,
 			LinkedRule: "check_allocation_rule",
 		},
+		{
+			// Collective sanction: when the common pool is below the disaster threshold,
+			// every island that paid less than its tax share is surcharged the shortfall
+			Name: "collective_free_rider_surcharge",
+			ReqVar: []VariableFieldName{
+				CommonPoolResources,
+				CommonPoolDisasterThreshold,
+				IslandTaxPaid,
+				IslandTaxShare,
+			},
+			Values:  []float64{-1, 1, 0, 0, 0, 0, 0, -1, 1, 0, 0, 0, -1, 1, 0},
+			Aux:     []float64{1, 1, 4},
+			Mutable: true,
+			Linked:  false,
+		},
+		{
+			Name: "iigo_term_length_president",
+			ReqVar: []VariableFieldName{
+				PresidentTermLength,
+			},
+			Values:  []float64{-1, 4},
+			Aux:     []float64{0},
+			Mutable: true,
+			Linked:  false,
+		},
+		{
+			Name: "iigo_term_length_speaker",
+			ReqVar: []VariableFieldName{
+				SpeakerTermLength,
+			},
+			Values:  []float64{-1, 4},
+			Aux:     []float64{0},
+			Mutable: true,
+			Linked:  false,
+		},
+		{
+			Name: "iigo_term_length_judge",
+			ReqVar: []VariableFieldName{
+				JudgeTermLength,
+			},
+			Values:  []float64{-1, 4},
+			Aux:     []float64{0},
+			Mutable: true,
+			Linked:  false,
+		},
+		{
+			Name: "iigo_rule_vote_majority",
+			ReqVar: []VariableFieldName{
+				RuleVoteMajority,
+			},
+			Values:  []float64{-1, 0.5},
+			Aux:     []float64{0},
+			Mutable: true,
+			Linked:  false,
+		},
+		{
+			// -1 lets the role running the election choose the voting method
+			Name: "iigo_election_voting_method_president",
+			ReqVar: []VariableFieldName{
+				ElectionVotingMethod,
+			},
+			Values:  []float64{-1, -1},
+			Aux:     []float64{0},
+			Mutable: true,
+			Linked:  false,
+		},
+		{
+			Name: "iigo_election_voting_method_speaker",
+			ReqVar: []VariableFieldName{
+				ElectionVotingMethod,
+			},
+			Values:  []float64{-1, -1},
+			Aux:     []float64{0},
+			Mutable: true,
+			Linked:  false,
+		},
+		{
+			Name: "iigo_election_voting_method_judge",
+			ReqVar: []VariableFieldName{
+				ElectionVotingMethod,
+			},
+			Values:  []float64{-1, -1},
+			Aux:     []float64{0},
+			Mutable: true,
+			Linked:  false,
+		},
+		{
+			// Islands must honour the IITO contracts they sign. The server reports the
+			// number of contracts an island broke in a turn to the Judge.
+			Name: "honour_iito_agreements",
+			ReqVar: []VariableFieldName{
+				NumberOfBrokenAgreements,
+			},
+			Values:  []float64{1, 0},
+			Aux:     []float64{0},
+			Mutable: false,
+			Linked:  false,
+		},
+		{
+			// Islands must repay the IITO loans they take. The server reports every default
+			// to the Judge, but only a default the lender petitions over breaks this rule.
+			Name: "repay_iito_loans",
+			ReqVar: []VariableFieldName{
+				NumberOfPetitionedLoanDefaults,
+			},
+			Values:  []float64{1, 0},
+			Aux:     []float64{0},
+			Mutable: false,
+			Linked:  false,
+		},
 	}
 
+	ruleSpecs = append(ruleSpecs, actionCostRuleSpecifications()...)
+
 	for _, rs := range ruleSpecs {
 		rowLength := len(rs.ReqVar) + 1
 		if len(rs.Values)%rowLength != 0 {
@@ -506,6 +650,50 @@ func registerRulesByMass(availableRules map[string]RuleMatrix) map[string]RuleMa
 	return availableRules
 }
 
+// IIGOActions are the IIGO actions whose cost is held by an iigo_action_cost rule
+var IIGOActions = []string{
+	"get_rule_for_speaker",
+	"broadcast_taxation",
+	"reply_allocation_requests",
+	"request_allocation_request",
+	"request_rule_proposal",
+	"appoint_next_speaker",
+	"inspect_history",
+	"historical_retribution",
+	"inspect_ballot",
+	"inspect_allocation",
+	"appoint_next_president",
+	"set_voting_result",
+	"set_rule_to_vote",
+	"announce_voting_result",
+	"update_rules",
+	"appoint_next_judge",
+}
+
+// ActionCostRuleName returns the name of the rule holding the cost of an IIGO action
+func ActionCostRuleName(action string) string {
+	return "iigo_action_cost_" + action
+}
+
+// actionCostRuleSpecifications returns the rules holding the cost of each IIGO action. Their constant
+// is set from the config when the game starts.
+func actionCostRuleSpecifications() []RawRuleSpecification {
+	specs := make([]RawRuleSpecification, 0, len(IIGOActions))
+	for _, action := range IIGOActions {
+		specs = append(specs, RawRuleSpecification{
+			Name: ActionCostRuleName(action),
+			ReqVar: []VariableFieldName{
+				IIGOActionCost,
+			},
+			Values:  []float64{-1, 0},
+			Aux:     []float64{0},
+			Mutable: true,
+			Linked:  false,
+		})
+	}
+	return specs
+}
+
 // CompileRuleCase allows an agent to quickly build a RuleMatrix using the RawRuleSpecification
 func CompileRuleCase(spec RawRuleSpecification) (RuleMatrix, bool) {
 	rowLength := len(spec.ReqVar) + 1
@@ -104,6 +104,18 @@ func CopyRulesMap(rulesMap map[string]RuleMatrix) map[string]RuleMatrix {
 	return targetMap
 }
 
+// CopyRuleMatrices returns a deep copy of a list of rules
+func CopyRuleMatrices(ruleMatrices []RuleMatrix) []RuleMatrix {
+	if ruleMatrices == nil {
+		return nil
+	}
+	ret := make([]RuleMatrix, len(ruleMatrices))
+	for i, ruleMatrix := range ruleMatrices {
+		ret[i] = copySingleRuleMatrix(ruleMatrix)
+	}
+	return ret
+}
+
 func copySingleRuleMatrix(inp RuleMatrix) RuleMatrix {
 	return RuleMatrix{
 		RuleName:          inp.RuleName,
@@ -100,6 +100,24 @@ const (
 	TermEnded
 	ElectionHeld
 	AppointmentMatchesVote
+	AppointmentEligible
+	RecallPetitionSucceeded
+	RecallElectionHeld
+	AllocationAuditRequired
+	AllocationAuditPerformed
+	CommonPoolResources
+	CommonPoolDisasterThreshold
+	IslandTaxPaid
+	IslandTaxShare
+	PresidentTermLength
+	SpeakerTermLength
+	JudgeTermLength
+	RuleVoteMajority
+	IIGOActionCost
+	NumberOfLoanDefaults
+	IntendedContributionGap
+	NumberOfPetitionedLoanDefaults
+	ElectionVotingMethod
 )
 
 func (v VariableFieldName) String() string {
@@ -162,6 +180,24 @@ func (v VariableFieldName) String() string {
 		"TermEnded",
 		"ElectionHeld",
 		"AppointmentMatchesVote",
+		"AppointmentEligible",
+		"RecallPetitionSucceeded",
+		"RecallElectionHeld",
+		"AllocationAuditRequired",
+		"AllocationAuditPerformed",
+		"CommonPoolResources",
+		"CommonPoolDisasterThreshold",
+		"IslandTaxPaid",
+		"IslandTaxShare",
+		"PresidentTermLength",
+		"SpeakerTermLength",
+		"JudgeTermLength",
+		"RuleVoteMajority",
+		"IIGOActionCost",
+		"NumberOfLoanDefaults",
+		"IntendedContributionGap",
+		"NumberOfPetitionedLoanDefaults",
+		"ElectionVotingMethod",
 	}
 	if v >= 0 && int(v) < len(strs) {
 		return strs[v]
@@ -242,6 +242,78 @@ var StaticVariables = [...]VariableValuePair{
 		VariableName: AppointmentMatchesVote,
 		Values:       []float64{0},
 	},
+	{
+		VariableName: AppointmentEligible,
+		Values:       []float64{1},
+	},
+	{
+		VariableName: RecallPetitionSucceeded,
+		Values:       []float64{0},
+	},
+	{
+		VariableName: RecallElectionHeld,
+		Values:       []float64{0},
+	},
+	{
+		VariableName: AllocationAuditRequired,
+		Values:       []float64{0},
+	},
+	{
+		VariableName: AllocationAuditPerformed,
+		Values:       []float64{0},
+	},
+	{
+		VariableName: CommonPoolResources,
+		Values:       []float64{0},
+	},
+	{
+		VariableName: CommonPoolDisasterThreshold,
+		Values:       []float64{0},
+	},
+	{
+		VariableName: IslandTaxPaid,
+		Values:       []float64{0},
+	},
+	{
+		VariableName: IslandTaxShare,
+		Values:       []float64{0},
+	},
+	{
+		VariableName: PresidentTermLength,
+		Values:       []float64{0},
+	},
+	{
+		VariableName: SpeakerTermLength,
+		Values:       []float64{0},
+	},
+	{
+		VariableName: JudgeTermLength,
+		Values:       []float64{0},
+	},
+	{
+		VariableName: RuleVoteMajority,
+		Values:       []float64{0},
+	},
+	{
+		VariableName: IIGOActionCost,
+		Values:       []float64{0},
+	},
+	{
+		VariableName: NumberOfLoanDefaults,
+		Values:       []float64{0},
+	},
+	{
+		VariableName: IntendedContributionGap,
+		Values:       []float64{0},
+	},
+	{
+		VariableName: NumberOfPetitionedLoanDefaults,
+		Values:       []float64{0},
+	},
+	{
+		VariableName: ElectionVotingMethod,
+		Values:       []float64{0},
+	},
 	{
 		VariableName: TaxDecisionMade,
 		Values:       []float64{1},
@@ -0,0 +1,202 @@
+package shared
+
+import (
+	"fmt"
+
+	"github.com/SOMAS2020/SOMAS2020/pkg/miscutils"
+)
+
+// CoalitionMembership provides enumerated rules for joining a coalition
+type CoalitionMembership int
+
+const (
+	// OpenMembership coalitions can be joined by any island paying the entry fee
+	OpenMembership CoalitionMembership = iota
+	// ApprovedMembership coalitions can only be joined with the approval of a majority of their members
+	ApprovedMembership
+)
+
+func (c CoalitionMembership) String() string {
+	strs := [...]string{
+		"OpenMembership",
+		"ApprovedMembership",
+	}
+	if c >= 0 && int(c) < len(strs) {
+		return strs[c]
+	}
+	return fmt.Sprintf("UNKNOWN CoalitionMembership '%v'", int(c))
+}
+
+// GoString implements GoStringer
+func (c CoalitionMembership) GoString() string {
+	return c.String()
+}
+
+// MarshalText implements TextMarshaler
+func (c CoalitionMembership) MarshalText() ([]byte, error) {
+	return miscutils.MarshalTextForString(c.String())
+}
+
+// MarshalJSON implements RawMessage
+func (c CoalitionMembership) MarshalJSON() ([]byte, error) {
+	return miscutils.MarshalJSONForString(c.String())
+}
+
+// CoalitionRules are set by the founder of a coalition and bind its members
+type CoalitionRules struct {
+	Membership CoalitionMembership
+	// EntryFee is paid into the treasury by each island joining the coalition, including its founder
+	EntryFee Resources
+	// Dues are paid into the treasury by each member every turn. Members failing to pay are expelled.
+	Dues Resources
+	// ForageShare of the treasury is spent every turn on a coalition foraging expedition of ForageType,
+	// whose return goes back to the treasury
+	ForageType  ForageType
+	ForageShare float64
+	// MutualAid is paid from the treasury to each critical member every turn, as long as the treasury can afford it
+	MutualAid Resources
+}
+
+// CoalitionActionKind provides enumerated actions islands take on coalitions
+type CoalitionActionKind int
+
+const (
+	// FormCoalition founds a coalition with the given name and rules
+	FormCoalition CoalitionActionKind = iota
+	// JoinCoalition applies to join a coalition
+	JoinCoalition
+	// LeaveCoalition leaves the island's coalition
+	LeaveCoalition
+	// ContributeToCoalition pays an amount into the treasury of the island's coalition
+	ContributeToCoalition
+)
+
+func (c CoalitionActionKind) String() string {
+	strs := [...]string{
+		"FormCoalition",
+		"JoinCoalition",
+		"LeaveCoalition",
+		"ContributeToCoalition",
+	}
+	if c >= 0 && int(c) < len(strs) {
+		return strs[c]
+	}
+	return fmt.Sprintf("UNKNOWN CoalitionActionKind '%v'", int(c))
+}
+
+// GoString implements GoStringer
+func (c CoalitionActionKind) GoString() string {
+	return c.String()
+}
+
+// MarshalText implements TextMarshaler
+func (c CoalitionActionKind) MarshalText() ([]byte, error) {
+	return miscutils.MarshalTextForString(c.String())
+}
+
+// MarshalJSON implements RawMessage
+func (c CoalitionActionKind) MarshalJSON() ([]byte, error) {
+	return miscutils.MarshalJSONForString(c.String())
+}
+
+// CoalitionAction is an action an island takes on a coalition. Coalition names the coalition formed or joined,
+// Rules are the rules of a coalition formed and Amount is the contribution to the treasury.
+type CoalitionAction struct {
+	Kind      CoalitionActionKind
+	Coalition string
+	Rules     CoalitionRules
+	Amount    Resources
+}
+
+// Coalition is a named group of islands with a shared treasury. An island is a member of at most one coalition.
+type Coalition struct {
+	Name       string
+	Founder    ClientID
+	Rules      CoalitionRules
+	Members    []ClientID
+	Treasury   Resources
+	FormedTurn uint
+	// Set by the server once the last member leaves
+	Dissolved     bool
+	DissolvedTurn uint
+}
+
+// CoalitionEventKind provides enumerated events recorded for coalitions
+type CoalitionEventKind int
+
+const (
+	// CoalitionFormed is recorded when an island founds a coalition
+	CoalitionFormed CoalitionEventKind = iota
+	// CoalitionJoined is recorded when an island joins a coalition
+	CoalitionJoined
+	// CoalitionJoinRejected is recorded when the members of a coalition reject an island applying to join it
+	CoalitionJoinRejected
+	// CoalitionLeft is recorded when an island leaves a coalition, or dies
+	CoalitionLeft
+	// CoalitionExpelled is recorded when a member is expelled for failing to pay its dues
+	CoalitionExpelled
+	// CoalitionDissolved is recorded when the last member leaves and the treasury goes to the common pool
+	CoalitionDissolved
+	// CoalitionEntryFeePaid is recorded when an island pays the entry fee of a coalition
+	CoalitionEntryFeePaid
+	// CoalitionDuesPaid is recorded when a member pays its dues
+	CoalitionDuesPaid
+	// CoalitionContribution is recorded when a member contributes to the treasury
+	CoalitionContribution
+	// CoalitionAidPaid is recorded when the treasury pays mutual aid to a critical member
+	CoalitionAidPaid
+)
+
+func (c CoalitionEventKind) String() string {
+	strs := [...]string{
+		"CoalitionFormed",
+		"CoalitionJoined",
+		"CoalitionJoinRejected",
+		"CoalitionLeft",
+		"CoalitionExpelled",
+		"CoalitionDissolved",
+		"CoalitionEntryFeePaid",
+		"CoalitionDuesPaid",
+		"CoalitionContribution",
+		"CoalitionAidPaid",
+	}
+	if c >= 0 && int(c) < len(strs) {
+		return strs[c]
+	}
+	return fmt.Sprintf("UNKNOWN CoalitionEventKind '%v'", int(c))
+}
+
+// GoString implements GoStringer
+func (c CoalitionEventKind) GoString() string {
+	return c.String()
+}
+
+// MarshalText implements TextMarshaler
+func (c CoalitionEventKind) MarshalText() ([]byte, error) {
+	return miscutils.MarshalTextForString(c.String())
+}
+
+// MarshalJSON implements RawMessage
+func (c CoalitionEventKind) MarshalJSON() ([]byte, error) {
+	return miscutils.MarshalJSONForString(c.String())
+}
+
+// CoalitionEvent records a change in the membership or treasury of a coalition.
+// ClientID is the island concerned, or the founder for events of the whole coalition, and Amount the resources moved, if any.
+type CoalitionEvent struct {
+	Turn      uint
+	Coalition string
+	Kind      CoalitionEventKind
+	ClientID  ClientID
+	Amount    Resources
+}
+
+// CoalitionExpedition is a foraging expedition funded by the treasury of a coalition
+type CoalitionExpedition struct {
+	Turn         uint
+	Coalition    string
+	ForageType   ForageType
+	Input        Resources
+	NumberCaught uint
+	Return       Resources
+}
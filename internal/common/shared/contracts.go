@@ -0,0 +1,100 @@
+package shared
+
+import (
+	"fmt"
+
+	"github.com/SOMAS2020/SOMAS2020/pkg/miscutils"
+)
+
+// ContractStatus provides enumerated states of a signed IITO contract
+type ContractStatus int
+
+const (
+	ContractActive ContractStatus = iota
+	ContractFulfilled
+	ContractBreached
+	// ContractVoid contracts ended because one of the parties died
+	ContractVoid
+)
+
+func (c ContractStatus) String() string {
+	strs := [...]string{
+		"ContractActive",
+		"ContractFulfilled",
+		"ContractBreached",
+		"ContractVoid",
+	}
+	if c >= 0 && int(c) < len(strs) {
+		return strs[c]
+	}
+	return fmt.Sprintf("UNKNOWN ContractStatus '%v'", int(c))
+}
+
+// GoString implements GoStringer
+func (c ContractStatus) GoString() string {
+	return c.String()
+}
+
+// MarshalText implements TextMarshaler
+func (c ContractStatus) MarshalText() ([]byte, error) {
+	return miscutils.MarshalTextForString(c.String())
+}
+
+// MarshalJSON implements RawMessage
+func (c ContractStatus) MarshalJSON() ([]byte, error) {
+	return miscutils.MarshalJSONForString(c.String())
+}
+
+// ContractObligation is a payment one party of a contract commits to make to the other.
+// The payment is due every turn for Turns turns, starting from the turn the contract is signed or,
+// if AfterDisaster is set, from the turn following the next disaster.
+type ContractObligation struct {
+	From          ClientID
+	Amount        Resources
+	Turns         uint
+	AfterDisaster bool
+	// PaymentsMade is the number of payments made in full so far (set by the server)
+	PaymentsMade uint
+}
+
+// Contract is a binding agreement between two islands, proposed by one and signed by the other.
+// For example, "I give 10 per turn for 5 turns in exchange for 15 after the next disaster" is a contract
+// with the obligations {From: proposer, Amount: 10, Turns: 5} and {From: counterparty, Amount: 15, Turns: 1, AfterDisaster: true}.
+type Contract struct {
+	ID           uint
+	Proposer     ClientID
+	Counterparty ClientID
+	Obligations  []ContractObligation
+	// Set by the server once the contract is signed
+	SignedTurn   uint
+	SignedSeason uint
+	Status       ContractStatus
+	// BrokenBy is the island which failed to make a payment due, if the contract was breached
+	BrokenBy ClientID
+}
+
+// OtherParty returns the party of the contract which is not id
+func (c Contract) OtherParty(id ClientID) ClientID {
+	if id == c.Proposer {
+		return c.Counterparty
+	}
+	return c.Proposer
+}
+
+// IsDue returns whether a payment of the obligation is due in the given season
+func (o ContractObligation) IsDue(signedSeason uint, season uint) bool {
+	if o.PaymentsMade >= o.Turns {
+		return false
+	}
+	return !o.AfterDisaster || season > signedSeason
+}
+
+// Copy returns a deep copy of the contract
+func (c Contract) Copy() Contract {
+	ret := c
+	if c.Obligations != nil {
+		ret.Obligations = make([]ContractObligation, len(c.Obligations))
+		copy(ret.Obligations, c.Obligations)
+	}
+	return ret
+}
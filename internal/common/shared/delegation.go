@@ -0,0 +1,38 @@
+package shared
+
+import (
+	"fmt"
+
+	"github.com/SOMAS2020/SOMAS2020/pkg/miscutils"
+)
+
+// VoteKind provides enumerated values for the kinds of votes an island can delegate
+type VoteKind int
+
+const (
+	RuleVotes VoteKind = iota
+	ElectionVotes
+)
+
+func (v VoteKind) String() string {
+	strs := [...]string{"RuleVotes", "ElectionVotes"}
+	if v >= 0 && int(v) < len(strs) {
+		return strs[v]
+	}
+	return fmt.Sprintf("UNKNOWN VoteKind '%v'", int(v))
+}
+
+// GoString implements GoStringer
+func (v VoteKind) GoString() string {
+	return v.String()
+}
+
+// MarshalText implements TextMarshaler
+func (v VoteKind) MarshalText() ([]byte, error) {
+	return miscutils.MarshalTextForString(v.String())
+}
+
+// MarshalJSON implements RawMessage
+func (v VoteKind) MarshalJSON() ([]byte, error) {
+	return miscutils.MarshalJSONForString(v.String())
+}
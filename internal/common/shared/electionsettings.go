@@ -2,8 +2,10 @@ package shared
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/SOMAS2020/SOMAS2020/pkg/miscutils"
+	"github.com/pkg/errors"
 )
 
 // ElectionVotingMethod provides enumerated type for selection of voting system to be used
@@ -51,3 +53,40 @@ func (e ElectionVotingMethod) MarshalText() ([]byte, error) {
 func (e ElectionVotingMethod) MarshalJSON() ([]byte, error) {
 	return miscutils.MarshalJSONForString(e.String())
 }
+
+// ParseElectionVotingMethod gets the ElectionVotingMethod named s
+func ParseElectionVotingMethod(s string) (ElectionVotingMethod, error) {
+	for e := BordaCount; e <= Approval; e++ {
+		if strings.EqualFold(e.String(), strings.TrimSpace(s)) {
+			return e, nil
+		}
+	}
+	return BordaCount, errors.Errorf("Unknown ElectionVotingMethod specified: '%v'.", s)
+}
+
+// ParseElectionVotingMethods parses a comma separated list of "Role:Method" pairs
+func ParseElectionVotingMethods(s string) (map[Role]ElectionVotingMethod, error) {
+	methods := map[Role]ElectionVotingMethod{}
+	if strings.TrimSpace(s) == "" {
+		return methods, nil
+	}
+	for _, entry := range strings.Split(s, ",") {
+		pair := strings.Split(entry, ":")
+		if len(pair) != 2 {
+			return nil, errors.Errorf("Invalid election voting method: '%v'. Expected 'Role:Method'.", entry)
+		}
+		role, err := ParseRole(pair[0])
+		if err != nil {
+			return nil, err
+		}
+		method, err := ParseElectionVotingMethod(pair[1])
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := methods[role]; ok {
+			return nil, errors.Errorf("Role %v has more than one voting method.", role)
+		}
+		methods[role] = method
+	}
+	return methods, nil
+}
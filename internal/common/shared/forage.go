@@ -86,6 +86,8 @@ type ForageShareInfo struct {
 	ShareTo []ClientID
 	// SharedFrom is used to show where the information came from
 	SharedFrom ClientID
+	// Verification is set by the server if it checks shared information against the foraging history
+	Verification ForageReportVerification
 }
 
 // ForagingOfferDict is a map of client -> foraging decisions, their resource obtained, and which
@@ -95,3 +97,52 @@ type ForagingOfferDict = map[ClientID]ForageShareInfo
 // ForagingReceiptDict is a map of client -> array of information of other clients
 // foraging decisions, their resource obtained, and which clients to sent this information
 type ForagingReceiptDict = map[ClientID][]ForageShareInfo
+
+// ForageReportVerification provides enumerated results of the server checking shared foraging information
+type ForageReportVerification int
+
+const (
+	// ForageReportUnverified information has not been checked
+	ForageReportUnverified ForageReportVerification = iota
+	// ForageReportTruthful information matches the island's most recent foraging decision and its return
+	ForageReportTruthful
+	// ForageReportFalse information does not match the island's most recent foraging decision or its return
+	ForageReportFalse
+)
+
+func (v ForageReportVerification) String() string {
+	strs := [...]string{
+		"ForageReportUnverified",
+		"ForageReportTruthful",
+		"ForageReportFalse",
+	}
+	if v >= 0 && int(v) < len(strs) {
+		return strs[v]
+	}
+	return fmt.Sprintf("UNKNOWN ForageReportVerification '%v'", int(v))
+}
+
+// GoString implements GoStringer
+func (v ForageReportVerification) GoString() string {
+	return v.String()
+}
+
+// MarshalText implements TextMarshaler
+func (v ForageReportVerification) MarshalText() ([]byte, error) {
+	return miscutils.MarshalTextForString(v.String())
+}
+
+// MarshalJSON implements RawMessage
+func (v ForageReportVerification) MarshalJSON() ([]byte, error) {
+	return miscutils.MarshalJSONForString(v.String())
+}
+
+// ForageAudit is the result of an audit of the foraging information one island shared with another,
+// paid for by the auditor
+type ForageAudit struct {
+	Turn         uint
+	Auditor      ClientID
+	Subject      ClientID
+	Verification ForageReportVerification
+	Cost         Resources
+}
@@ -1,5 +1,11 @@
 package shared
 
+import (
+	"fmt"
+
+	"github.com/SOMAS2020/SOMAS2020/pkg/miscutils"
+)
+
 // GiftRequest contains the details of a gift request from an island to another
 type GiftRequest Resources
 
@@ -34,3 +40,158 @@ type GiftResponse struct {
 
 // GiftResponseDict represents an island's responses to all the other island's gift offers.
 type GiftResponseDict map[ClientID]GiftResponse
+
+// EscrowCondition provides enumerated game events an escrowed gift can be released on
+type EscrowCondition int
+
+const (
+	// RecipientHitByDisaster releases the gift when a disaster hits the recipient
+	RecipientHitByDisaster EscrowCondition = iota
+	// RecipientCritical releases the gift when the recipient is in critical state
+	RecipientCritical
+	// RecipientVotedOnRule releases the gift when the recipient casts the given vote on the given rule
+	RecipientVotedOnRule
+)
+
+func (e EscrowCondition) String() string {
+	strs := [...]string{
+		"RecipientHitByDisaster",
+		"RecipientCritical",
+		"RecipientVotedOnRule",
+	}
+	if e >= 0 && int(e) < len(strs) {
+		return strs[e]
+	}
+	return fmt.Sprintf("UNKNOWN EscrowCondition '%v'", int(e))
+}
+
+// GoString implements GoStringer
+func (e EscrowCondition) GoString() string {
+	return e.String()
+}
+
+// MarshalText implements TextMarshaler
+func (e EscrowCondition) MarshalText() ([]byte, error) {
+	return miscutils.MarshalTextForString(e.String())
+}
+
+// MarshalJSON implements RawMessage
+func (e EscrowCondition) MarshalJSON() ([]byte, error) {
+	return miscutils.MarshalJSONForString(e.String())
+}
+
+// EscrowStatus provides enumerated states of an escrowed gift
+type EscrowStatus int
+
+const (
+	EscrowHeld EscrowStatus = iota
+	EscrowReleased
+	EscrowReturned
+)
+
+func (e EscrowStatus) String() string {
+	strs := [...]string{
+		"EscrowHeld",
+		"EscrowReleased",
+		"EscrowReturned",
+	}
+	if e >= 0 && int(e) < len(strs) {
+		return strs[e]
+	}
+	return fmt.Sprintf("UNKNOWN EscrowStatus '%v'", int(e))
+}
+
+// GoString implements GoStringer
+func (e EscrowStatus) GoString() string {
+	return e.String()
+}
+
+// MarshalText implements TextMarshaler
+func (e EscrowStatus) MarshalText() ([]byte, error) {
+	return miscutils.MarshalTextForString(e.String())
+}
+
+// MarshalJSON implements RawMessage
+func (e EscrowStatus) MarshalJSON() ([]byte, error) {
+	return miscutils.MarshalJSONForString(e.String())
+}
+
+// EscrowedGiftOffer is a gift the server holds in escrow and releases to the recipient only if the condition
+// becomes true within ExpiryTurns turns, including the one it is made in. Otherwise it is returned to the giver.
+type EscrowedGiftOffer struct {
+	To          ClientID
+	Amount      Resources
+	Condition   EscrowCondition
+	ExpiryTurns uint
+	// RuleName and Vote are the vote the recipient must cast for the RecipientVotedOnRule condition
+	RuleName string
+	Vote     RuleVoteType
+}
+
+// EscrowedGift is an escrowed gift offer whose amount was taken from the giver
+type EscrowedGift struct {
+	EscrowedGiftOffer
+	ID            uint
+	From          ClientID
+	TurnDeposited uint
+	Status        EscrowStatus
+	TurnResolved  uint
+}
+
+// GiftNegotiationMoveKind provides enumerated moves of the gift negotiation
+type GiftNegotiationMoveKind int
+
+const (
+	// GiftOpeningOffer is the gift offer made in the gift session, recorded by the server
+	GiftOpeningOffer GiftNegotiationMoveKind = iota
+	// GiftCounterOffer is the amount the recipient asks for instead of the standing offer
+	GiftCounterOffer
+	// GiftOfferRevised replaces the standing offer with a new amount
+	GiftOfferRevised
+	// GiftOfferWithdrawn removes the standing offer
+	GiftOfferWithdrawn
+)
+
+func (g GiftNegotiationMoveKind) String() string {
+	strs := [...]string{
+		"GiftOpeningOffer",
+		"GiftCounterOffer",
+		"GiftOfferRevised",
+		"GiftOfferWithdrawn",
+	}
+	if g >= 0 && int(g) < len(strs) {
+		return strs[g]
+	}
+	return fmt.Sprintf("UNKNOWN GiftNegotiationMoveKind '%v'", int(g))
+}
+
+// GoString implements GoStringer
+func (g GiftNegotiationMoveKind) GoString() string {
+	return g.String()
+}
+
+// MarshalText implements TextMarshaler
+func (g GiftNegotiationMoveKind) MarshalText() ([]byte, error) {
+	return miscutils.MarshalTextForString(g.String())
+}
+
+// MarshalJSON implements RawMessage
+func (g GiftNegotiationMoveKind) MarshalJSON() ([]byte, error) {
+	return miscutils.MarshalJSONForString(g.String())
+}
+
+// GiftNegotiationMessage is a move of an island in the gift negotiation, with an optional free-text message.
+// Amount is ignored for GiftOfferWithdrawn moves.
+type GiftNegotiationMessage struct {
+	Kind    GiftNegotiationMoveKind
+	Amount  Resources
+	Message string
+}
+
+// GiftNegotiationEntry is a move of the gift negotiation transcript. Opening offers are recorded in round 0.
+type GiftNegotiationEntry struct {
+	Round uint
+	From  ClientID
+	To    ClientID
+	GiftNegotiationMessage
+}
@@ -2,21 +2,27 @@ package shared
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/SOMAS2020/SOMAS2020/pkg/miscutils"
+	"github.com/pkg/errors"
 )
 
 // Role provides enumerated type for IIGO roles (President, Speaker and Judge)
+// Treasurer and Auditor are additional roles which are only in play if enabled in the config
 type Role int
 
 const (
 	President Role = iota
 	Speaker
 	Judge
+	Treasurer
+	Auditor
+	roleEnd
 )
 
 func (r Role) String() string {
-	strs := [...]string{"President", "Speaker", "Judge"}
+	strs := [...]string{"President", "Speaker", "Judge", "Treasurer", "Auditor"}
 	if r >= 0 && int(r) < len(strs) {
 		return strs[r]
 	}
@@ -38,6 +44,47 @@ func (r Role) MarshalJSON() ([]byte, error) {
 	return miscutils.MarshalJSONForString(r.String())
 }
 
+// ParseRole gets the Role named s
+func ParseRole(s string) (Role, error) {
+	for r := President; r < roleEnd; r++ {
+		if strings.EqualFold(r.String(), strings.TrimSpace(s)) {
+			return r, nil
+		}
+	}
+	return President, errors.Errorf("Unknown Role specified: '%v'.", s)
+}
+
+// ParseMonitoringGraph parses a comma separated list of "Monitor:Monitored" role pairs
+// Each role can monitor at most one other role
+func ParseMonitoringGraph(s string) (map[Role]Role, error) {
+	graph := map[Role]Role{}
+	if strings.TrimSpace(s) == "" {
+		return graph, nil
+	}
+	for _, edge := range strings.Split(s, ",") {
+		pair := strings.Split(edge, ":")
+		if len(pair) != 2 {
+			return nil, errors.Errorf("Invalid monitoring graph edge: '%v'. Expected 'Monitor:Monitored'.", edge)
+		}
+		monitor, err := ParseRole(pair[0])
+		if err != nil {
+			return nil, err
+		}
+		monitored, err := ParseRole(pair[1])
+		if err != nil {
+			return nil, err
+		}
+		if monitor == monitored {
+			return nil, errors.Errorf("Role %v cannot monitor itself.", monitor)
+		}
+		if _, ok := graph[monitor]; ok {
+			return nil, errors.Errorf("Role %v monitors more than one role.", monitor)
+		}
+		graph[monitor] = monitored
+	}
+	return graph, nil
+}
+
 // RuleVoteType provides enumerated values for Approving, Rejecting or Abstaining from a vote.
 type RuleVoteType int
 
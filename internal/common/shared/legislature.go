@@ -0,0 +1,38 @@
+package shared
+
+import "github.com/SOMAS2020/SOMAS2020/internal/common/rules"
+
+// AgendaItem is a motion queued on the legislative agenda until it is voted on.
+// An item holding several rules is a bundle: its rules are voted on together and pass or fail together.
+type AgendaItem struct {
+	ID         uint
+	Rules      []rules.RuleMatrix
+	TurnQueued uint
+	// AmendmentsAdopted is the number of amendments to the item passed by the islands
+	AmendmentsAdopted uint
+	// SessionsPassed is the number of consecutive sessions that passed the item so far. An item changing
+	// constitutional rules stays on the agenda until it has passed as many sessions as their amendment procedure requires
+	SessionsPassed uint
+	TurnLastPassed uint
+}
+
+// Amendment is a proposed change to the matrix of a rule queued on the agenda.
+// The amended rule must keep the name of the rule it replaces.
+type Amendment struct {
+	ID         uint
+	ItemID     uint
+	Proposer   ClientID
+	RuleMatrix rules.RuleMatrix
+}
+
+// LegislativeSession is the Speaker's plan for the items voted on in a legislative session
+type LegislativeSession struct {
+	// Bundles are groups of agenda items merged into a single item before any vote.
+	// The merged item keeps the ID of the first item of the group.
+	Bundles [][]uint
+	// Order is the sequence in which agenda items are voted on. Items left out stay on the agenda.
+	Order []uint
+	// Amendments are put to a vote just before the item they amend
+	Amendments  []uint
+	ActionTaken bool
+}
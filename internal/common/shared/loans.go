@@ -0,0 +1,84 @@
+package shared
+
+import (
+	"fmt"
+
+	"github.com/SOMAS2020/SOMAS2020/pkg/miscutils"
+)
+
+// LoanStatus provides enumerated states of an IITO loan
+type LoanStatus int
+
+const (
+	LoanActive LoanStatus = iota
+	LoanRepaid
+	LoanDefaulted
+	// LoanWrittenOff loans ended because the lender died
+	LoanWrittenOff
+)
+
+func (l LoanStatus) String() string {
+	strs := [...]string{
+		"LoanActive",
+		"LoanRepaid",
+		"LoanDefaulted",
+		"LoanWrittenOff",
+	}
+	if l >= 0 && int(l) < len(strs) {
+		return strs[l]
+	}
+	return fmt.Sprintf("UNKNOWN LoanStatus '%v'", int(l))
+}
+
+// GoString implements GoStringer
+func (l LoanStatus) GoString() string {
+	return l.String()
+}
+
+// MarshalText implements TextMarshaler
+func (l LoanStatus) MarshalText() ([]byte, error) {
+	return miscutils.MarshalTextForString(l.String())
+}
+
+// MarshalJSON implements RawMessage
+func (l LoanStatus) MarshalJSON() ([]byte, error) {
+	return miscutils.MarshalJSONForString(l.String())
+}
+
+// LoanOffer is an offer from an island to lend resources to another.
+// The borrower repays the principal and the interest in equal instalments, one per turn from the turn after
+// the loan is made.
+type LoanOffer struct {
+	Borrower  ClientID
+	Principal Resources
+	// InterestRate is the interest charged on the principal over the whole loan, e.g. 0.1 for 10%
+	InterestRate float64
+	Instalments  uint
+}
+
+// Loan is a loan offer accepted by the borrower. The principal is lent as soon as the offer is accepted.
+type Loan struct {
+	LoanOffer
+	ID              uint
+	Lender          ClientID
+	TurnGranted     uint
+	InstalmentsPaid uint
+	Status          LoanStatus
+	// Petitioned is true if the lender petitioned the Judge over the borrower's default
+	Petitioned bool
+}
+
+// AmountOwed returns the principal and the interest the borrower has to repay over the whole loan
+func (l LoanOffer) AmountOwed() Resources {
+	return l.Principal * Resources(1+l.InterestRate)
+}
+
+// Instalment returns the amount repaid every turn
+func (l LoanOffer) Instalment() Resources {
+	return l.AmountOwed() / Resources(l.Instalments)
+}
+
+// Outstanding returns the amount the borrower still has to repay
+func (l Loan) Outstanding() Resources {
+	return l.Instalment() * Resources(l.Instalments-l.InstalmentsPaid)
+}
@@ -0,0 +1,113 @@
+package shared
+
+import (
+	"fmt"
+
+	"github.com/SOMAS2020/SOMAS2020/pkg/miscutils"
+)
+
+// MarketAsset provides enumerated assets traded on the IITO market
+type MarketAsset int
+
+const (
+	// ForagingShare units entitle the buyer to 1% of the seller's foraging return in the turn of the trade
+	ForagingShare MarketAsset = iota
+	// IOU units entitle the buyer to 1 resource from the seller at the end of the turn of the trade
+	IOU
+)
+
+// MarketAssets lists the assets traded on the IITO market
+var MarketAssets = [...]MarketAsset{ForagingShare, IOU}
+
+func (m MarketAsset) String() string {
+	strs := [...]string{
+		"ForagingShare",
+		"IOU",
+	}
+	if m >= 0 && int(m) < len(strs) {
+		return strs[m]
+	}
+	return fmt.Sprintf("UNKNOWN MarketAsset '%v'", int(m))
+}
+
+// GoString implements GoStringer
+func (m MarketAsset) GoString() string {
+	return m.String()
+}
+
+// MarshalText implements TextMarshaler
+func (m MarketAsset) MarshalText() ([]byte, error) {
+	return miscutils.MarshalTextForString(m.String())
+}
+
+// MarshalJSON implements RawMessage
+func (m MarketAsset) MarshalJSON() ([]byte, error) {
+	return miscutils.MarshalJSONForString(m.String())
+}
+
+// MarketSide provides enumerated sides of an order on the IITO market
+type MarketSide int
+
+const (
+	// Bid orders buy an asset
+	Bid MarketSide = iota
+	// Ask orders sell an asset
+	Ask
+)
+
+func (m MarketSide) String() string {
+	strs := [...]string{
+		"Bid",
+		"Ask",
+	}
+	if m >= 0 && int(m) < len(strs) {
+		return strs[m]
+	}
+	return fmt.Sprintf("UNKNOWN MarketSide '%v'", int(m))
+}
+
+// GoString implements GoStringer
+func (m MarketSide) GoString() string {
+	return m.String()
+}
+
+// MarshalText implements TextMarshaler
+func (m MarketSide) MarshalText() ([]byte, error) {
+	return miscutils.MarshalTextForString(m.String())
+}
+
+// MarshalJSON implements RawMessage
+func (m MarketSide) MarshalJSON() ([]byte, error) {
+	return miscutils.MarshalJSONForString(m.String())
+}
+
+// MarketOrder is an order posted to the IITO market's order book.
+// Price is the highest price per unit a bid pays, or the lowest price per unit an ask accepts.
+type MarketOrder struct {
+	Asset    MarketAsset
+	Side     MarketSide
+	Quantity float64
+	Price    Resources
+}
+
+// MarketTrade is a quantity of an asset sold by one island to another when the market clears.
+// The buyer pays Quantity * Price to the seller straight away, and the seller delivers the asset at the end of the turn.
+type MarketTrade struct {
+	Turn     uint
+	Asset    MarketAsset
+	Buyer    ClientID
+	Seller   ClientID
+	Quantity float64
+	Price    Resources
+	// Set by the server once the asset is delivered
+	Settled   bool
+	Delivered Resources
+}
+
+// MarketClearing is the result of clearing the order book of an asset in a turn
+type MarketClearing struct {
+	Turn   uint
+	Asset  MarketAsset
+	Price  Resources
+	Volume float64
+}
@@ -0,0 +1,66 @@
+package shared
+
+import (
+	"fmt"
+
+	"github.com/SOMAS2020/SOMAS2020/pkg/miscutils"
+)
+
+// MessageKind provides enumerated kinds of messages islands send each other
+type MessageKind int
+
+const (
+	// TextMessage is free text
+	TextMessage MessageKind = iota
+	// ProposalMessage proposes a deal, such as a gift or an alliance, possibly worth Amount
+	ProposalMessage
+	// ThreatMessage threatens the recipients, possibly with a loss of Amount
+	ThreatMessage
+	// PromiseMessage promises the recipients something, possibly worth Amount
+	PromiseMessage
+)
+
+func (m MessageKind) String() string {
+	strs := [...]string{
+		"TextMessage",
+		"ProposalMessage",
+		"ThreatMessage",
+		"PromiseMessage",
+	}
+	if m >= 0 && int(m) < len(strs) {
+		return strs[m]
+	}
+	return fmt.Sprintf("UNKNOWN MessageKind '%v'", int(m))
+}
+
+// GoString implements GoStringer
+func (m MessageKind) GoString() string {
+	return m.String()
+}
+
+// MarshalText implements TextMarshaler
+func (m MessageKind) MarshalText() ([]byte, error) {
+	return miscutils.MarshalTextForString(m.String())
+}
+
+// MarshalJSON implements RawMessage
+func (m MessageKind) MarshalJSON() ([]byte, error) {
+	return miscutils.MarshalJSONForString(m.String())
+}
+
+// Message is a message an island sends to one island, a group of islands, or every other island if Recipients is empty.
+// Nothing binds the sender to what it says.
+type Message struct {
+	Kind       MessageKind
+	Recipients []ClientID
+	Text       string
+	Amount     Resources
+}
+
+// MessageLogEntry is a message delivered by the server. To lists the islands it was delivered to.
+type MessageLogEntry struct {
+	Turn uint
+	From ClientID
+	To   []ClientID
+	Message
+}
@@ -0,0 +1,91 @@
+package shared
+
+import (
+	"fmt"
+
+	"github.com/SOMAS2020/SOMAS2020/pkg/miscutils"
+)
+
+// DisasterContractKind provides enumerated events traded on the IIFO prediction market
+type DisasterContractKind int
+
+const (
+	// DisasterWithinTurns contracts pay out if a disaster happens within the given number of turns,
+	// counting the turn they are bought in
+	DisasterWithinTurns DisasterContractKind = iota
+	// MagnitudeAbove contracts pay out if the next disaster has a magnitude above the given threshold
+	MagnitudeAbove
+)
+
+func (d DisasterContractKind) String() string {
+	strs := [...]string{
+		"DisasterWithinTurns",
+		"MagnitudeAbove",
+	}
+	if d >= 0 && int(d) < len(strs) {
+		return strs[d]
+	}
+	return fmt.Sprintf("UNKNOWN DisasterContractKind '%v'", int(d))
+}
+
+// GoString implements GoStringer
+func (d DisasterContractKind) GoString() string {
+	return d.String()
+}
+
+// MarshalText implements TextMarshaler
+func (d DisasterContractKind) MarshalText() ([]byte, error) {
+	return miscutils.MarshalTextForString(d.String())
+}
+
+// MarshalJSON implements RawMessage
+func (d DisasterContractKind) MarshalJSON() ([]byte, error) {
+	return miscutils.MarshalJSONForString(d.String())
+}
+
+// DisasterContract is the event an island bets on: a disaster within Turns turns, or a next disaster
+// with a magnitude above Magnitude
+type DisasterContract struct {
+	Kind      DisasterContractKind
+	Turns     uint
+	Magnitude Magnitude
+}
+
+// PredictionMarketOrder buys Shares of a contract from the market maker. A Yes share pays 1 resource if the
+// event happens and a No share pays 1 resource if it does not.
+type PredictionMarketOrder struct {
+	Contract DisasterContract
+	Yes      bool
+	Shares   float64
+}
+
+// PredictionMarket is a market on a disaster event, priced by a logarithmic market scoring rule (LMSR) market maker.
+// Deadline is the last turn a disaster counts for a DisasterWithinTurns market. The price of a Yes share is the
+// probability of the event implied by the shares sold, and Escrow holds the resources paying out the winning shares.
+type PredictionMarket struct {
+	ID         uint
+	Kind       DisasterContractKind
+	Deadline   uint
+	Magnitude  Magnitude
+	OpenedTurn uint
+	Liquidity  float64
+	YesShares  float64
+	NoShares   float64
+	Price      float64
+	Escrow     Resources
+	// Set by the server once the market is settled
+	Resolved     bool
+	Outcome      bool
+	TurnResolved uint
+}
+
+// PredictionMarketHolding is the shares of a prediction market held by an island,
+// with the resources it paid for them and the payout of its winning shares
+type PredictionMarketHolding struct {
+	MarketID  uint
+	Holder    ClientID
+	YesShares float64
+	NoShares  float64
+	Cost      Resources
+	Payout    Resources
+}
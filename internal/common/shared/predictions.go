@@ -32,3 +32,31 @@ type DisasterPredictionInfoDict = map[ClientID]DisasterPredictionInfo
 
 // ReceivedDisasterPredictionsDict is a dictionary of ReceivedDisasterPredictionInfo
 type ReceivedDisasterPredictionsDict = map[ClientID]ReceivedDisasterPredictionInfo
+
+// ScoredDisasterPrediction is a disaster prediction made by an island, scored by the server once the next
+// disaster happens or the predicted turn (the turn it was made in plus TimeLeft) passes without one
+type ScoredDisasterPrediction struct {
+	Predictor    ClientID
+	Prediction   DisasterPrediction
+	TurnMade     uint
+	Resolved     bool
+	TurnResolved uint
+	// DisasterHappened is true if the prediction was resolved by a disaster
+	DisasterHappened bool
+	// BrierScore is the squared difference between the confidence, as a probability, and the outcome: 1 if the
+	// disaster happened in the predicted turn, 0 otherwise. It is a proper scoring rule, lower is better.
+	BrierScore float64
+	// MagnitudeError is the absolute error of the predicted magnitude and LocationError the distance from the
+	// predicted location to the disaster, if DisasterHappened
+	MagnitudeError float64
+	LocationError  float64
+}
+
+// PredictionReward is the reward paid from the common pool to the most accurate forecaster of a turn:
+// the island with the lowest mean Brier score over its predictions resolved in the turn
+type PredictionReward struct {
+	Turn           uint
+	Forecaster     ClientID
+	MeanBrierScore float64
+	Amount         Resources
+}
@@ -0,0 +1,10 @@
+package shared
+
+// RecallPetition is a petition filed by an island to recall the current holder of an IIGO role.
+// If enough islands co-sign the petition, an election for the role is forced.
+type RecallPetition struct {
+	Role       Role
+	RoleHolder ClientID
+	Petitioner ClientID
+	CoSigners  []ClientID
+}
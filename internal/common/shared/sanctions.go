@@ -5,6 +5,7 @@ import (
 
 	"github.com/SOMAS2020/SOMAS2020/internal/common/rules"
 	"github.com/SOMAS2020/SOMAS2020/pkg/miscutils"
+	"github.com/pkg/errors"
 )
 
 // IIGOSanctionsScore provides typed integer score for each island
@@ -13,27 +14,23 @@ type IIGOSanctionsScore int
 // IIGOSanctionsTier provides typed integer tiers for sanctions
 type IIGOSanctionsTier int
 
-// Provides enumerated tiers for IIGO sanctions
+// Provides enumerated tiers for IIGO sanctions. The sanction ladder in the IIGO config sets how many
+// tiers are in play, further tiers follow on from SanctionTier5.
 const (
-	SanctionTier1 IIGOSanctionsTier = iota
+	NoSanction IIGOSanctionsTier = iota - 1
+	SanctionTier1
 	SanctionTier2
 	SanctionTier3
 	SanctionTier4
 	SanctionTier5
-	NoSanction
 )
 
 func (i IIGOSanctionsTier) String() string {
-	strs := [...]string{
-		"SanctionTier1",
-		"SanctionTier2",
-		"SanctionTier3",
-		"SanctionTier4",
-		"SanctionTier5",
-		"NoSanction",
+	if i == NoSanction {
+		return "NoSanction"
 	}
-	if i >= 0 && int(i) < len(strs) {
-		return strs[i]
+	if i >= 0 {
+		return fmt.Sprintf("SanctionTier%v", int(i)+1)
 	}
 	return fmt.Sprintf("UNKNOWN IIGOSanctionsTier '%v'", int(i))
 }
@@ -53,6 +50,56 @@ func (i IIGOSanctionsTier) MarshalJSON() ([]byte, error) {
 	return miscutils.MarshalJSONForString(i.String())
 }
 
+// SanctionConsequence provides enumerated non-economic consequences a sanction tier can carry:
+// losing the right to vote in rule votes and elections, being barred from IIGO roles, receiving
+// no common pool allocation and being left out of IITO gift sessions
+type SanctionConsequence int
+
+const (
+	LoseVotingRight SanctionConsequence = iota
+	IneligibleForOffice
+	ExcludedFromAllocation
+	ExcludedFromGifts
+)
+
+func (c SanctionConsequence) String() string {
+	strs := [...]string{
+		"LoseVotingRight",
+		"IneligibleForOffice",
+		"ExcludedFromAllocation",
+		"ExcludedFromGifts",
+	}
+	if c >= 0 && int(c) < len(strs) {
+		return strs[c]
+	}
+	return fmt.Sprintf("UNKNOWN SanctionConsequence '%v'", int(c))
+}
+
+// GoString implements GoStringer
+func (c SanctionConsequence) GoString() string {
+	return c.String()
+}
+
+// MarshalText implements TextMarshaler
+func (c SanctionConsequence) MarshalText() ([]byte, error) {
+	return miscutils.MarshalTextForString(c.String())
+}
+
+// MarshalJSON implements RawMessage
+func (c SanctionConsequence) MarshalJSON() ([]byte, error) {
+	return miscutils.MarshalJSONForString(c.String())
+}
+
+// ParseSanctionConsequence returns the sanction consequence with the given name
+func ParseSanctionConsequence(s string) (SanctionConsequence, error) {
+	for c := LoseVotingRight; c <= ExcludedFromGifts; c++ {
+		if c.String() == s {
+			return c, nil
+		}
+	}
+	return 0, errors.Errorf("Unknown sanction consequence: '%v'", s)
+}
+
 // EvaluationReturn is a data-structure allowing clients to return which rules they've evaluated and the results
 type EvaluationReturn struct {
 	Rules       []rules.RuleMatrix
@@ -65,3 +112,54 @@ type Sanction struct {
 	SanctionTier IIGOSanctionsTier
 	TurnsLeft    int
 }
+
+// InForce tells if a sanction still applies. The turns left on a sanction are counted down as it is levied,
+// so it stays in force until the turns left go below zero.
+func (s Sanction) InForce() bool {
+	return s.SanctionTier != NoSanction && s.TurnsLeft >= 0
+}
+
+// SanctionAppeal is an island contesting the sanction imposed on it this turn.
+// RecordedVariables are the variables recorded for the island in the turn the Judge inspected.
+type SanctionAppeal struct {
+	Appellant         ClientID
+	SanctionTier      IIGOSanctionsTier
+	RulesBroken       []string
+	RecordedVariables []rules.VariableValuePair
+}
+
+// AppealOutcome provides enumerated outcomes of a sanction appeal
+type AppealOutcome int
+
+const (
+	AppealUpheld AppealOutcome = iota
+	AppealReduced
+	AppealVoided
+)
+
+func (a AppealOutcome) String() string {
+	strs := [...]string{
+		"AppealUpheld",
+		"AppealReduced",
+		"AppealVoided",
+	}
+	if a >= 0 && int(a) < len(strs) {
+		return strs[a]
+	}
+	return fmt.Sprintf("UNKNOWN AppealOutcome '%v'", int(a))
+}
+
+// GoString implements GoStringer
+func (a AppealOutcome) GoString() string {
+	return a.String()
+}
+
+// MarshalText implements TextMarshaler
+func (a AppealOutcome) MarshalText() ([]byte, error) {
+	return miscutils.MarshalTextForString(a.String())
+}
+
+// MarshalJSON implements RawMessage
+func (a AppealOutcome) MarshalJSON() ([]byte, error) {
+	return miscutils.MarshalJSONForString(a.String())
+}
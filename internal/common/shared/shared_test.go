@@ -35,3 +35,105 @@ func TestSortClientByID(t *testing.T) {
 		t.Errorf("want '%v' got '%v'", want, clients)
 	}
 }
+
+func TestParseMonitoringGraph(t *testing.T) {
+	cases := []struct {
+		name    string
+		input   string
+		want    map[Role]Role
+		wantErr bool
+	}{
+		{
+			name:  "default cycle",
+			input: "Speaker:President,President:Judge,Judge:Speaker",
+			want:  map[Role]Role{Speaker: President, President: Judge, Judge: Speaker},
+		},
+		{
+			name:  "additional roles, case and spaces ignored",
+			input: "treasurer: Auditor, Auditor:Treasurer",
+			want:  map[Role]Role{Treasurer: Auditor, Auditor: Treasurer},
+		},
+		{
+			name:  "empty graph",
+			input: "",
+			want:  map[Role]Role{},
+		},
+		{
+			name:    "unknown role",
+			input:   "Speaker:King",
+			wantErr: true,
+		},
+		{
+			name:    "self monitoring",
+			input:   "Judge:Judge",
+			wantErr: true,
+		},
+		{
+			name:    "role monitoring two roles",
+			input:   "Judge:Speaker,Judge:President",
+			wantErr: true,
+		},
+		{
+			name:    "malformed edge",
+			input:   "Judge",
+			wantErr: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseMonitoringGraph(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("want error %v got '%v'", tc.wantErr, err)
+			}
+			if !tc.wantErr && !reflect.DeepEqual(tc.want, got) {
+				t.Errorf("want '%v' got '%v'", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestParseElectionVotingMethods(t *testing.T) {
+	cases := []struct {
+		name    string
+		input   string
+		want    map[Role]ElectionVotingMethod
+		wantErr bool
+	}{
+		{
+			name:  "methods for some roles",
+			input: "President:Runoff, judge:instantrunoff",
+			want:  map[Role]ElectionVotingMethod{President: Runoff, Judge: InstantRunoff},
+		},
+		{
+			name:  "no methods",
+			input: "",
+			want:  map[Role]ElectionVotingMethod{},
+		},
+		{
+			name:    "unknown method",
+			input:   "Speaker:Lottery",
+			wantErr: true,
+		},
+		{
+			name:    "role with two methods",
+			input:   "Speaker:Runoff,Speaker:Approval",
+			wantErr: true,
+		},
+		{
+			name:    "malformed entry",
+			input:   "Speaker",
+			wantErr: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseElectionVotingMethods(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("want error %v got '%v'", tc.wantErr, err)
+			}
+			if !tc.wantErr && !reflect.DeepEqual(tc.want, got) {
+				t.Errorf("want '%v' got '%v'", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package voting
+
+import "github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+
+// ResolveDelegates returns, for every voter, the island casting its vote. A vote follows the chain of
+// delegations through the other voters and is cast by the last voter on the chain, so an island delegating
+// to an island which is not voting casts its own vote. If the chain loops, the voter casts its own vote.
+func ResolveDelegates(delegations map[shared.ClientID]shared.ClientID, voters []shared.ClientID) map[shared.ClientID]shared.ClientID {
+	voting := map[shared.ClientID]bool{}
+	for _, voter := range voters {
+		voting[voter] = true
+	}
+	casters := map[shared.ClientID]shared.ClientID{}
+	for _, voter := range voters {
+		caster := voter
+		visited := map[shared.ClientID]bool{voter: true}
+		for {
+			delegate, ok := delegations[caster]
+			if !ok || !voting[delegate] {
+				break
+			}
+			if visited[delegate] {
+				caster = voter
+				break
+			}
+			visited[delegate] = true
+			caster = delegate
+		}
+		casters[voter] = caster
+	}
+	return casters
+}
+
+// delegatedVoters returns the voters whose vote is cast by another island
+func delegatedVoters(casters map[shared.ClientID]shared.ClientID) map[shared.ClientID]shared.ClientID {
+	delegated := map[shared.ClientID]shared.ClientID{}
+	for voter, caster := range casters {
+		if voter != caster {
+			delegated[voter] = caster
+		}
+	}
+	return delegated
+}
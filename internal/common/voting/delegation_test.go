@@ -0,0 +1,115 @@
+package voting
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/baseclient"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+type mockElectionVoter struct {
+	*baseclient.BaseClient
+	ranking []shared.ClientID
+	asked   int
+}
+
+func (c *mockElectionVoter) VoteForElection(roleToElect shared.Role, candidateList []shared.ClientID) []shared.ClientID {
+	c.asked++
+	return c.ranking
+}
+
+func TestResolveDelegates(t *testing.T) {
+	voters := []shared.ClientID{shared.Team1, shared.Team2, shared.Team3, shared.Team4}
+	cases := []struct {
+		name        string
+		delegations map[shared.ClientID]shared.ClientID
+		expected    map[shared.ClientID]shared.ClientID
+	}{
+		{
+			name:        "No delegations",
+			delegations: map[shared.ClientID]shared.ClientID{},
+			expected: map[shared.ClientID]shared.ClientID{
+				shared.Team1: shared.Team1, shared.Team2: shared.Team2, shared.Team3: shared.Team3, shared.Team4: shared.Team4,
+			},
+		},
+		{
+			name: "Transitive delegation",
+			delegations: map[shared.ClientID]shared.ClientID{
+				shared.Team1: shared.Team2,
+				shared.Team2: shared.Team3,
+			},
+			expected: map[shared.ClientID]shared.ClientID{
+				shared.Team1: shared.Team3, shared.Team2: shared.Team3, shared.Team3: shared.Team3, shared.Team4: shared.Team4,
+			},
+		},
+		{
+			name: "Cycle falls back to own vote",
+			delegations: map[shared.ClientID]shared.ClientID{
+				shared.Team1: shared.Team2,
+				shared.Team2: shared.Team3,
+				shared.Team3: shared.Team2,
+				shared.Team4: shared.Team3,
+			},
+			expected: map[shared.ClientID]shared.ClientID{
+				shared.Team1: shared.Team1, shared.Team2: shared.Team2, shared.Team3: shared.Team3, shared.Team4: shared.Team4,
+			},
+		},
+		{
+			name: "Chain stops at the last voting island",
+			delegations: map[shared.ClientID]shared.ClientID{
+				shared.Team1: shared.Team2,
+				shared.Team2: shared.Team5,
+				shared.Team3: shared.Team6,
+			},
+			expected: map[shared.ClientID]shared.ClientID{
+				shared.Team1: shared.Team2, shared.Team2: shared.Team2, shared.Team3: shared.Team3, shared.Team4: shared.Team4,
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ResolveDelegates(tc.delegations, voters)
+			if !reflect.DeepEqual(tc.expected, got) {
+				t.Errorf("Expected %v got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestElectionDelegation(t *testing.T) {
+	voters := []shared.ClientID{shared.Team1, shared.Team2, shared.Team3}
+	clients := map[shared.ClientID]*mockElectionVoter{
+		shared.Team1: {BaseClient: baseclient.NewClient(shared.Team1), ranking: []shared.ClientID{shared.Team1, shared.Team2, shared.Team3}},
+		shared.Team2: {BaseClient: baseclient.NewClient(shared.Team2), ranking: []shared.ClientID{shared.Team2, shared.Team1, shared.Team3}},
+		shared.Team3: {BaseClient: baseclient.NewClient(shared.Team3), ranking: []shared.ClientID{shared.Team3, shared.Team2, shared.Team1}},
+	}
+	clientMap := map[shared.ClientID]baseclient.Client{}
+	for id, client := range clients {
+		clientMap[id] = client
+	}
+
+	election := Election{Logger: func(format string, a ...interface{}) {}}
+	election.ProposeElection(shared.President, shared.BordaCount)
+	election.SetDelegations(map[shared.ClientID]shared.ClientID{shared.Team1: shared.Team2, shared.Team3: shared.Team2})
+	election.OpenBallot(voters, copyCandidateList(voters))
+	election.Vote(clientMap)
+	winner := election.CloseBallot(clientMap)
+
+	if winner != shared.Team2 {
+		t.Errorf("Expected the delegate's choice Team2 to win got %v", winner)
+	}
+	info := election.GetVotingInfo()
+	for _, ballot := range info.Votes {
+		if !reflect.DeepEqual(ballot, clients[shared.Team2].ranking) {
+			t.Errorf("Expected every ballot to be the delegate's got %v", info.Votes)
+		}
+	}
+	expectedDelegates := map[shared.ClientID]shared.ClientID{shared.Team1: shared.Team2, shared.Team3: shared.Team2}
+	if !reflect.DeepEqual(expectedDelegates, info.Delegates) {
+		t.Errorf("Expected delegates %v got %v", expectedDelegates, info.Delegates)
+	}
+	if clients[shared.Team1].asked != 0 || clients[shared.Team3].asked != 0 || clients[shared.Team2].asked != 1 {
+		t.Errorf("Expected only the delegate to be asked to vote")
+	}
+}
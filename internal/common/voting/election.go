@@ -6,6 +6,7 @@ import (
 	"sort"
 
 	"github.com/SOMAS2020/SOMAS2020/internal/common/baseclient"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/gamestate"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
 )
@@ -16,7 +17,19 @@ type Election struct {
 	candidateList []shared.ClientID
 	voterList     []shared.ClientID
 	votes         [][]shared.ClientID
-	Logger        shared.Logger
+	// delegations map islands to the island they delegated their election votes to
+	delegations   map[shared.ClientID]shared.ClientID
+	delegates     map[shared.ClientID]shared.ClientID
+	roundVotes    [][][]shared.ClientID
+	countingTrace []string
+	electedWinner shared.ClientID
+	recount       bool
+	// term limits and eligibility criteria applied by OpenBallot (see SetEligibility)
+	gameState            *gamestate.GameState
+	gameConf             *config.IIGOConfig
+	ineligibleCandidates []shared.ClientID
+	eligibilityWaived    bool
+	Logger               shared.Logger
 }
 
 // Logf is the Election logger
@@ -24,29 +37,57 @@ func (e *Election) Logf(format string, a ...interface{}) {
 	e.Logger("[ELECTION]: %v", fmt.Sprintf(format, a...))
 }
 
+// tracef records a step of the vote counting so that it can be audited later
+func (e *Election) tracef(format string, a ...interface{}) {
+	e.countingTrace = append(e.countingTrace, fmt.Sprintf(format, a...))
+}
+
 // ProposeMotion sets the role to be voted on
 func (e *Election) ProposeElection(role shared.Role, method shared.ElectionVotingMethod) {
 	e.roleToElect = role
 	e.votingMethod = method
 }
 
-// OpenBallot sets the islands eligible to vote.
+// OpenBallot sets the islands eligible to vote and the islands eligible to stand as candidates.
 func (e *Election) OpenBallot(clientIDs []shared.ClientID, allIslands []shared.ClientID) {
-	e.voterList = clientIDs
+	e.voterList = e.entitledVoters(clientIDs)
 	//Get candidate list in sorted order.
 	sort.SliceStable(allIslands, func(i, j int) bool {
 		return int(allIslands[i]) < int(allIslands[j])
 	})
-	e.candidateList = allIslands
+	e.candidateList = e.eligibleCandidates(allIslands)
 }
 
-// Vote gets votes from eligible islands.
+// SetDelegations sets the islands each island has delegated its election votes to
+func (e *Election) SetDelegations(delegations map[shared.ClientID]shared.ClientID) {
+	e.delegations = delegations
+}
+
+// Vote gets votes from eligible islands. An island which delegated its vote gets the ballot of its delegate.
 func (e *Election) Vote(clientMap map[shared.ClientID]baseclient.Client) {
+	e.votes = append(e.votes, e.gatherBallots(clientMap, e.candidateList)...)
+	e.Logf("Votes: %v", e.votes)
+}
+
+// gatherBallots asks each island casting a vote to rank candidateList, once for itself and every
+// voter delegating to it, and returns the ballots in the order of voterList
+func (e *Election) gatherBallots(clientMap map[shared.ClientID]baseclient.Client, candidateList []shared.ClientID) [][]shared.ClientID {
+	casters := ResolveDelegates(e.delegations, e.voterList)
+	e.delegates = delegatedVoters(casters)
+	cast := map[shared.ClientID][]shared.ClientID{}
+	var ret [][]shared.ClientID
 	for i := 0; i < len(e.voterList); i++ {
-		copyOfCandidateList := copyCandidateList(e.candidateList)
-		e.votes = append(e.votes, clientMap[e.voterList[i]].VoteForElection(e.roleToElect, copyOfCandidateList))
+		caster := casters[e.voterList[i]]
+		ballot, ok := cast[caster]
+		if ok {
+			ballot = copyCandidateList(ballot)
+		} else {
+			ballot = clientMap[caster].VoteForElection(e.roleToElect, copyCandidateList(candidateList))
+			cast[caster] = ballot
+		}
+		ret = append(ret, ballot)
 	}
-	e.Logf("Votes: %v", e.votes)
+	return ret
 }
 
 func copyCandidateList(list []shared.ClientID) []shared.ClientID {
@@ -57,6 +98,14 @@ func copyCandidateList(list []shared.ClientID) []shared.ClientID {
 	return ret
 }
 
+func copyBallots(ballots [][]shared.ClientID) [][]shared.ClientID {
+	ret := make([][]shared.ClientID, len(ballots))
+	for index, ballot := range ballots {
+		ret[index] = copyCandidateList(ballot)
+	}
+	return ret
+}
+
 // CloseBallot counts the votes received and returns the result.
 func (e *Election) CloseBallot(clientMap map[shared.ClientID]baseclient.Client) shared.ClientID {
 
@@ -72,9 +121,46 @@ func (e *Election) CloseBallot(clientMap map[shared.ClientID]baseclient.Client)
 	case shared.Approval:
 		result = e.approvalResult()
 	}
+	e.electedWinner = result
+	e.tracef("Elected winner: %v", result)
 	return result
 }
 
+// nextRoundVotes gets the ballots for a further counting round restricted to candidateList.
+// During a recount the ballots recorded for that round are replayed instead of asking the voters again.
+func (e *Election) nextRoundVotes(clientMap map[shared.ClientID]baseclient.Client, candidateList []shared.ClientID) [][]shared.ClientID {
+	if e.recount {
+		if len(e.roundVotes) == 0 {
+			return [][]shared.ClientID{}
+		}
+		ret := e.roundVotes[0]
+		e.roundVotes = e.roundVotes[1:]
+		return ret
+	}
+	ret := e.gatherBallots(clientMap, candidateList)
+	e.roundVotes = append(e.roundVotes, ret)
+	return ret
+}
+
+// Recount re-tallies the ballots recorded in an election audit trail and returns the true winner
+// along with the counting trace of the recount. No voter is asked to vote again.
+func Recount(info gamestate.VotingInfo, logger shared.Logger) (shared.ClientID, []string) {
+	e := Election{
+		roleToElect:   info.RoleToElect,
+		votingMethod:  info.VotingMethod,
+		candidateList: copyCandidateList(info.CandidateList),
+		voterList:     copyCandidateList(info.VoterList),
+		votes:         copyBallots(info.Votes),
+		recount:       true,
+		Logger:        logger,
+	}
+	for _, round := range info.RoundVotes {
+		e.roundVotes = append(e.roundVotes, copyBallots(round))
+	}
+	winner := e.CloseBallot(nil)
+	return winner, e.countingTrace
+}
+
 //func (e *Election) completePreferenceMap()
 
 func scoreCalculator(totalVotes [][]shared.ClientID, candidateList []shared.ClientID) ([]float64, []float64, float64) {
@@ -222,6 +308,7 @@ func (e *Election) bordaCountResult() shared.ClientID {
 	// Implement Borda count winner selection method
 	candidatesNumber := len(e.candidateList)
 	finalScore, variance, _ := scoreCalculator(e.votes, e.candidateList)
+	e.tracef("Borda count scores for %v: %v", e.candidateList, finalScore)
 
 	var maxScore float64 = 0
 	var winnerIndex int
@@ -246,9 +333,9 @@ func (e *Election) runOffResult(clientMap map[shared.ClientID]baseclient.Client)
 	//Round one
 	scoreList, variance, totalScore := scoreCalculator(e.votes, e.candidateList)
 	rOneCandidateList := e.candidateList
-	voterNumber := len(e.voterList)
 
 	halfTotalScore := 0.5 * totalScore
+	e.tracef("Runoff round 1 scores for %v: %v", rOneCandidateList, scoreList)
 
 	maxScore, maxScoreIndex := findMaxScore(scoreList, variance)
 
@@ -264,17 +351,18 @@ func (e *Election) runOffResult(clientMap map[shared.ClientID]baseclient.Client)
 
 		rTwoCandidateList := []shared.ClientID{rOneCandidateList[maxScoreIndex], rOneCandidateList[competitorIndex]}
 
-		var rTwoVotes [][]shared.ClientID
-		for i := 0; i < voterNumber; i++ {
-			rTwoVotes = append(rTwoVotes, clientMap[e.voterList[i]].VoteForElection(e.roleToElect, rTwoCandidateList))
-		}
-		for i := 0; i < voterNumber; i++ {
+		rTwoVotes := e.nextRoundVotes(clientMap, rTwoCandidateList)
+		for i := 0; i < len(rTwoVotes); i++ {
+			if len(rTwoVotes[i]) == 0 {
+				continue
+			}
 			if rTwoVotes[i][0] == rOneCandidateList[maxScoreIndex] {
 				remainNumber++
 			} else if rTwoVotes[i][0] == rOneCandidateList[competitorIndex] {
 				changeNumber++
 			}
 		}
+		e.tracef("Runoff round 2: %v votes for %v, %v votes for %v", remainNumber, rOneCandidateList[maxScoreIndex], changeNumber, rOneCandidateList[competitorIndex])
 		if changeNumber > remainNumber {
 			winner = rOneCandidateList[competitorIndex]
 		} else {
@@ -287,12 +375,13 @@ func (e *Election) runOffResult(clientMap map[shared.ClientID]baseclient.Client)
 func (e *Election) instantRunoffResult(clientMap map[shared.ClientID]baseclient.Client) shared.ClientID {
 	var winner shared.ClientID
 	candidateNumber := len(e.candidateList)
-	candidateList := e.candidateList
+	candidateList := copyCandidateList(e.candidateList)
 	totalVotes := e.votes
 	var halfTotalScore float64 = 0
 
-	for {
+	for round := 1; ; round++ {
 		scoreList, variance, totalScore := scoreCalculator(totalVotes, candidateList)
+		e.tracef("Instant runoff round %v scores for %v: %v", round, candidateList, scoreList)
 
 		halfTotalScore = 0.5 * totalScore
 
@@ -305,6 +394,7 @@ func (e *Election) instantRunoffResult(clientMap map[shared.ClientID]baseclient.
 		}
 
 		_, minScoreIndex := findMinScore(scoreList, variance)
+		e.tracef("Instant runoff round %v eliminates %v", round, candidateList[minScoreIndex])
 
 		//Eliminate the least popular candidate
 		if minScoreIndex == 0 {
@@ -317,10 +407,7 @@ func (e *Election) instantRunoffResult(clientMap map[shared.ClientID]baseclient.
 		candidateNumber--
 
 		//New round voting status update
-		for i := 0; i < len(e.voterList); i++ {
-			totalVotes = append(totalVotes, clientMap[e.voterList[i]].VoteForElection(e.roleToElect, candidateList))
-		}
-		totalVotes = totalVotes[(len(totalVotes) - len(e.voterList)):]
+		totalVotes = e.nextRoundVotes(clientMap, candidateList)
 
 	}
 	return winner
@@ -340,6 +427,7 @@ func (e *Election) approvalResult() shared.ClientID {
 			}
 		}
 	}
+	e.tracef("Approval scores for %v: %v", candidateList, scoreList)
 	maxScore := 0
 	maxScoreIndex := 0
 	for i := 0; i < len(candidateList); i++ {
@@ -380,9 +468,42 @@ func (e *Election) majorityResult() shared.ClientID {
 // GetVotingInfo get a neccesery information to visualise in the form on gamestate.VotingInfo
 func (e *Election) GetVotingInfo() gamestate.VotingInfo {
 	return gamestate.VotingInfo{
-		RoleToElect:  e.roleToElect,
-		VotingMethod: e.votingMethod,
-		VoterList:    e.voterList,
-		Votes:        e.votes,
+		RoleToElect:   e.roleToElect,
+		VotingMethod:  e.votingMethod,
+		CandidateList: e.candidateList,
+		VoterList:     e.voterList,
+		Votes:         e.votes,
+		Delegates:     e.delegates,
+		RoundVotes:    e.roundVotes,
+		CountingTrace: e.countingTrace,
+		ElectedWinner: e.electedWinner,
+		IneligibleCandidates: e.ineligibleCandidates,
+		EligibilityWaived:    e.eligibilityWaived,
 	}
 }
+
+// AnonymiseVotingInfo detaches the recorded ballots from the voters that cast them.
+// Ballots within each counting round are put in a canonical order, which keeps every tally unchanged.
+func AnonymiseVotingInfo(info gamestate.VotingInfo) gamestate.VotingInfo {
+	ret := info
+	ret.Votes = sortBallots(copyBallots(info.Votes))
+	ret.RoundVotes = make([][][]shared.ClientID, len(info.RoundVotes))
+	for index, round := range info.RoundVotes {
+		ret.RoundVotes[index] = sortBallots(copyBallots(round))
+	}
+	ret.Anonymised = true
+	return ret
+}
+
+func sortBallots(ballots [][]shared.ClientID) [][]shared.ClientID {
+	sort.SliceStable(ballots, func(i, j int) bool {
+		a, b := ballots[i], ballots[j]
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return len(a) < len(b)
+	})
+	return ballots
+}
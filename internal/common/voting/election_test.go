@@ -235,3 +235,75 @@ func TestOpenBallot(t *testing.T) {
 		})
 	}
 }
+
+type mockVoter struct {
+	baseclient.BaseClient
+}
+
+// VoteForElection ranks the candidates in the order they are offered
+func (c *mockVoter) VoteForElection(roleToElect shared.Role, candidateList []shared.ClientID) []shared.ClientID {
+	return candidateList
+}
+
+func TestRecount(t *testing.T) {
+	ballots := [][]shared.ClientID{{shared.Team3, shared.Team2, shared.Team1, shared.Team6, shared.Team5, shared.Team4},
+		{shared.Team4, shared.Team6, shared.Team5, shared.Team2, shared.Team3, shared.Team1},
+		{shared.Team3, shared.Team6, shared.Team1, shared.Team4, shared.Team5, shared.Team2},
+		{shared.Team2, shared.Team5, shared.Team6, shared.Team4, shared.Team3, shared.Team1},
+		{shared.Team6, shared.Team4, shared.Team1, shared.Team5, shared.Team2, shared.Team3},
+		{shared.Team5, shared.Team2, shared.Team3, shared.Team6, shared.Team1, shared.Team4}}
+	cases := []struct {
+		name         string
+		votingMethod shared.ElectionVotingMethod
+		anonymise    bool
+	}{
+		{
+			name:         "borda_count_recount",
+			votingMethod: shared.BordaCount,
+		},
+		{
+			name:         "approval_recount",
+			votingMethod: shared.Approval,
+		},
+		{
+			name:         "runoff_recount",
+			votingMethod: shared.Runoff,
+		},
+		{
+			name:         "anonymised_runoff_recount",
+			votingMethod: shared.Runoff,
+			anonymise:    true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			clientMap := map[shared.ClientID]baseclient.Client{}
+			for _, clientID := range shared.TeamIDs {
+				clientMap[clientID] = &mockVoter{}
+			}
+			ele := Election{
+				roleToElect:   shared.President,
+				votingMethod:  tc.votingMethod,
+				candidateList: copyCandidateList(shared.TeamIDs[:]),
+				voterList:     copyCandidateList(shared.TeamIDs[:]),
+				votes:         copyBallots(ballots),
+				Logger:        func(format string, a ...interface{}) {},
+			}
+			winner := ele.CloseBallot(clientMap)
+			info := ele.GetVotingInfo()
+			if tc.anonymise {
+				info = AnonymiseVotingInfo(info)
+			}
+			if info.ElectedWinner != winner {
+				t.Errorf("Expected recorded winner to be %v got %v", winner, info.ElectedWinner)
+			}
+			recountedWinner, trace := Recount(info, ele.Logger)
+			if recountedWinner != winner {
+				t.Errorf("Expected recount winner to be %v got %v", winner, recountedWinner)
+			}
+			if !reflect.DeepEqual(trace, info.CountingTrace) {
+				t.Errorf("Expected recount trace to be %v got %v", info.CountingTrace, trace)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package voting
+
+import (
+	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/gamestate"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+// SetEligibility makes OpenBallot leave off the candidate list any island barred from the role
+// by the term limits and eligibility criteria in gameConf, and leave off the voter list any island
+// whose sanction took away its right to vote.
+func (e *Election) SetEligibility(gameState *gamestate.GameState, gameConf *config.IIGOConfig) {
+	e.gameState = gameState
+	e.gameConf = gameConf
+}
+
+// eligibleCandidates filters the candidates of the election. If no island is eligible the
+// full list is kept so that the role can still be filled.
+func (e *Election) eligibleCandidates(candidates []shared.ClientID) []shared.ClientID {
+	e.ineligibleCandidates = []shared.ClientID{}
+	e.eligibilityWaived = false
+	if e.gameState == nil || e.gameConf == nil {
+		return candidates
+	}
+	eligible := []shared.ClientID{}
+	for _, island := range candidates {
+		if IsEligible(island, e.roleToElect, e.gameState, e.gameConf) {
+			eligible = append(eligible, island)
+		} else {
+			e.ineligibleCandidates = append(e.ineligibleCandidates, island)
+		}
+	}
+	if len(eligible) == 0 {
+		e.Logf("No island is eligible for %v, all candidates are allowed to stand", e.roleToElect)
+		e.ineligibleCandidates = []shared.ClientID{}
+		e.eligibilityWaived = true
+		return candidates
+	}
+	if len(e.ineligibleCandidates) > 0 {
+		e.Logf("Ineligible candidates for %v: %v", e.roleToElect, e.ineligibleCandidates)
+	}
+	return eligible
+}
+
+// entitledVoters leaves off the voter list any island which lost its right to vote through a sanction.
+// If no island is entitled to vote the full list is kept so that the role can still be filled.
+func (e *Election) entitledVoters(voters []shared.ClientID) []shared.ClientID {
+	if e.gameState == nil || e.gameConf == nil {
+		return voters
+	}
+	entitled := WithoutSanctionConsequence(voters, shared.LoseVotingRight, SanctionConsequencesInForce(e.gameState, e.gameConf))
+	if len(entitled) == 0 {
+		e.Logf("No island is entitled to vote for %v, all voters are allowed to vote", e.roleToElect)
+		return voters
+	}
+	if len(entitled) < len(voters) {
+		e.Logf("Islands voting for %v: %v, others have lost their right to vote", e.roleToElect, entitled)
+	}
+	return entitled
+}
+
+// IsEligible returns whether island may hold role according to the term limits and eligibility
+// criteria in gameConf. Term limits are checked against the tenure recorded in gameState.
+func IsEligible(island shared.ClientID, role shared.Role, gameState *gamestate.GameState, gameConf *config.IIGOConfig) bool {
+	tenure, ok := gameState.IIGOTenures[role]
+	if ok && tenure.ConsecutiveTerms > 0 {
+		if tenure.Holder == island {
+			if gameConf.MaxConsecutiveTerms > 0 && tenure.ConsecutiveTerms >= gameConf.MaxConsecutiveTerms {
+				return false
+			}
+		} else if leftOffice, served := tenure.LeftOffice[island]; served && gameState.Turn < leftOffice+gameConf.TermCoolingOffPeriod {
+			return false
+		}
+	}
+
+	clientInfo := gameState.ClientInfos[island]
+	if clientInfo.Resources < gameConf.CandidateMinimumResources {
+		return false
+	}
+	if gameConf.CandidatesMustNotBeCritical && clientInfo.LifeStatus == shared.Critical {
+		return false
+	}
+	if gameConf.CandidatesMustNotBeSanctioned && isSanctioned(island, gameState.IIGOSanctionCache) {
+		return false
+	}
+	return !HasSanctionConsequence(island, shared.IneligibleForOffice, gameState, gameConf)
+}
+
+// HasSanctionConsequence returns whether island is serving a sanction whose tier of the sanction ladder
+// carries consequence
+func HasSanctionConsequence(island shared.ClientID, consequence shared.SanctionConsequence, gameState *gamestate.GameState, gameConf *config.IIGOConfig) bool {
+	_, inForce := SanctionConsequencesInForce(gameState, gameConf)[island][consequence]
+	return inForce
+}
+
+// SanctionConsequencesInForce returns the consequences each island is under and the number of turns, including
+// the current one, they remain in force. Consequences apply as long as their sanction is in force.
+func SanctionConsequencesInForce(gameState *gamestate.GameState, gameConf *config.IIGOConfig) map[shared.ClientID]map[shared.SanctionConsequence]uint {
+	inForce := map[shared.ClientID]map[shared.SanctionConsequence]uint{}
+	for _, sanctions := range gameState.IIGOSanctionCache {
+		for _, sanction := range sanctions {
+			tierConfig, ok := gameConf.GetSanctionTier(sanction.SanctionTier)
+			if !ok || !sanction.InForce() {
+				continue
+			}
+			for _, consequence := range tierConfig.Consequences {
+				if _, ok := inForce[sanction.ClientID]; !ok {
+					inForce[sanction.ClientID] = map[shared.SanctionConsequence]uint{}
+				}
+				if turns := uint(sanction.TurnsLeft + 1); turns > inForce[sanction.ClientID][consequence] {
+					inForce[sanction.ClientID][consequence] = turns
+				}
+			}
+		}
+	}
+	return inForce
+}
+
+// WithoutSanctionConsequence returns the islands which are not under consequence in consequences,
+// as returned by SanctionConsequencesInForce
+func WithoutSanctionConsequence(islands []shared.ClientID, consequence shared.SanctionConsequence, consequences map[shared.ClientID]map[shared.SanctionConsequence]uint) []shared.ClientID {
+	ret := []shared.ClientID{}
+	for _, island := range islands {
+		if _, ok := consequences[island][consequence]; !ok {
+			ret = append(ret, island)
+		}
+	}
+	return ret
+}
+
+func isSanctioned(island shared.ClientID, sanctionCache map[int][]shared.Sanction) bool {
+	for _, sanctions := range sanctionCache {
+		for _, sanction := range sanctions {
+			if sanction.ClientID == island && sanction.InForce() {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,271 @@
+package voting
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/gamestate"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+func TestIsEligible(t *testing.T) {
+	fakeGameState := &gamestate.GameState{
+		Turn: 10,
+		ClientInfos: map[shared.ClientID]gamestate.ClientInfo{
+			shared.Team1: {Resources: 100, LifeStatus: shared.Alive},
+			shared.Team2: {Resources: 100, LifeStatus: shared.Alive},
+			shared.Team3: {Resources: 5, LifeStatus: shared.Critical},
+			shared.Team4: {Resources: 100, LifeStatus: shared.Alive},
+			shared.Team5: {Resources: 100, LifeStatus: shared.Alive},
+			shared.Team6: {Resources: 100, LifeStatus: shared.Alive},
+		},
+		IIGOTenures: map[shared.Role]gamestate.IIGOTenure{
+			shared.President: {
+				Holder:           shared.Team1,
+				ConsecutiveTerms: 2,
+				LeftOffice:       map[shared.ClientID]uint{shared.Team2: 8},
+			},
+		},
+		IIGOSanctionCache: map[int][]shared.Sanction{
+			0: {{ClientID: shared.Team4, SanctionTier: shared.SanctionTier2, TurnsLeft: 2}},
+			1: {
+				{ClientID: shared.Team5, SanctionTier: shared.SanctionTier1, TurnsLeft: 0},
+				{ClientID: shared.Team6, SanctionTier: shared.SanctionTier1, TurnsLeft: -1},
+			},
+		},
+	}
+	cases := []struct {
+		name     string
+		island   shared.ClientID
+		gameConf config.IIGOConfig
+		expected bool
+	}{
+		{
+			name:     "No criteria",
+			island:   shared.Team3,
+			gameConf: config.IIGOConfig{},
+			expected: true,
+		},
+		{
+			name:     "Term limit reached",
+			island:   shared.Team1,
+			gameConf: config.IIGOConfig{MaxConsecutiveTerms: 2},
+			expected: false,
+		},
+		{
+			name:     "Term limit not reached",
+			island:   shared.Team1,
+			gameConf: config.IIGOConfig{MaxConsecutiveTerms: 3},
+			expected: true,
+		},
+		{
+			name:     "Cooling off",
+			island:   shared.Team2,
+			gameConf: config.IIGOConfig{TermCoolingOffPeriod: 3},
+			expected: false,
+		},
+		{
+			name:     "Cooling off over",
+			island:   shared.Team2,
+			gameConf: config.IIGOConfig{TermCoolingOffPeriod: 2},
+			expected: true,
+		},
+		{
+			name:     "Below minimum resources",
+			island:   shared.Team3,
+			gameConf: config.IIGOConfig{CandidateMinimumResources: 10},
+			expected: false,
+		},
+		{
+			name:     "Critical",
+			island:   shared.Team3,
+			gameConf: config.IIGOConfig{CandidatesMustNotBeCritical: true},
+			expected: false,
+		},
+		{
+			name:     "Sanctioned",
+			island:   shared.Team4,
+			gameConf: config.IIGOConfig{CandidatesMustNotBeSanctioned: true},
+			expected: false,
+		},
+		{
+			name:     "Sanctioned on the last turn of the sanction",
+			island:   shared.Team5,
+			gameConf: config.IIGOConfig{CandidatesMustNotBeSanctioned: true},
+			expected: false,
+		},
+		{
+			name:     "Sanction served",
+			island:   shared.Team6,
+			gameConf: config.IIGOConfig{CandidatesMustNotBeSanctioned: true},
+			expected: true,
+		},
+		{
+			name:   "Sanction tier bars office",
+			island: shared.Team4,
+			gameConf: config.IIGOConfig{SanctionLadder: []config.SanctionTierConfig{
+				{Threshold: 1},
+				{Threshold: 5, Consequences: []shared.SanctionConsequence{shared.IneligibleForOffice}},
+			}},
+			expected: false,
+		},
+		{
+			name:   "Sanction tier carries other consequences",
+			island: shared.Team4,
+			gameConf: config.IIGOConfig{SanctionLadder: []config.SanctionTierConfig{
+				{Threshold: 1},
+				{Threshold: 5, Consequences: []shared.SanctionConsequence{shared.LoseVotingRight}},
+			}},
+			expected: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := IsEligible(tc.island, shared.President, fakeGameState, &tc.gameConf)
+			if res != tc.expected {
+				t.Errorf("Expected eligibility of %v to be %v got %v", tc.island, tc.expected, res)
+			}
+		})
+	}
+}
+
+func TestOpenBallotEligibility(t *testing.T) {
+	fakeGameState := &gamestate.GameState{
+		ClientInfos: map[shared.ClientID]gamestate.ClientInfo{
+			shared.Team1: {Resources: 100},
+			shared.Team2: {Resources: 5},
+			shared.Team3: {Resources: 50},
+		},
+	}
+	cases := []struct {
+		name               string
+		minimumResources   shared.Resources
+		expectedCandidates []shared.ClientID
+		expectedIneligible []shared.ClientID
+		expectedWaived     bool
+	}{
+		{
+			name:               "Ineligible islands left off the ballot",
+			minimumResources:   10,
+			expectedCandidates: []shared.ClientID{shared.Team1, shared.Team3},
+			expectedIneligible: []shared.ClientID{shared.Team2},
+		},
+		{
+			name:               "Criteria waived when nobody is eligible",
+			minimumResources:   1000,
+			expectedCandidates: []shared.ClientID{shared.Team1, shared.Team2, shared.Team3},
+			expectedIneligible: []shared.ClientID{},
+			expectedWaived:     true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			election := Election{Logger: func(format string, a ...interface{}) {}}
+			election.ProposeElection(shared.Judge, shared.BordaCount)
+			election.SetEligibility(fakeGameState, &config.IIGOConfig{CandidateMinimumResources: tc.minimumResources})
+			election.OpenBallot([]shared.ClientID{shared.Team1}, []shared.ClientID{shared.Team3, shared.Team2, shared.Team1})
+			info := election.GetVotingInfo()
+			if !reflect.DeepEqual(info.CandidateList, tc.expectedCandidates) {
+				t.Errorf("Expected candidates %v got %v", tc.expectedCandidates, info.CandidateList)
+			}
+			if !reflect.DeepEqual(info.IneligibleCandidates, tc.expectedIneligible) {
+				t.Errorf("Expected ineligible candidates %v got %v", tc.expectedIneligible, info.IneligibleCandidates)
+			}
+			if info.EligibilityWaived != tc.expectedWaived {
+				t.Errorf("Expected eligibility waived %v got %v", tc.expectedWaived, info.EligibilityWaived)
+			}
+		})
+	}
+}
+
+func TestOpenBallotVotingRight(t *testing.T) {
+	ladder := []config.SanctionTierConfig{
+		{Threshold: 1},
+		{Threshold: 5, Consequences: []shared.SanctionConsequence{shared.LoseVotingRight}},
+	}
+	cases := []struct {
+		name           string
+		sanctions      []shared.Sanction
+		expectedVoters []shared.ClientID
+	}{
+		{
+			name: "Islands which lost their right to vote are left off the voter list",
+			sanctions: []shared.Sanction{
+				{ClientID: shared.Team1, SanctionTier: shared.SanctionTier1, TurnsLeft: 2},
+				{ClientID: shared.Team2, SanctionTier: shared.SanctionTier2, TurnsLeft: 2},
+			},
+			expectedVoters: []shared.ClientID{shared.Team1, shared.Team3},
+		},
+		{
+			name: "Expired sanctions do not take away the right to vote",
+			sanctions: []shared.Sanction{
+				{ClientID: shared.Team2, SanctionTier: shared.SanctionTier2, TurnsLeft: -1},
+			},
+			expectedVoters: []shared.ClientID{shared.Team1, shared.Team2, shared.Team3},
+		},
+		{
+			name: "Voter list kept when nobody is entitled to vote",
+			sanctions: []shared.Sanction{
+				{ClientID: shared.Team1, SanctionTier: shared.SanctionTier2, TurnsLeft: 1},
+				{ClientID: shared.Team2, SanctionTier: shared.SanctionTier2, TurnsLeft: 1},
+				{ClientID: shared.Team3, SanctionTier: shared.SanctionTier2, TurnsLeft: 1},
+			},
+			expectedVoters: []shared.ClientID{shared.Team1, shared.Team2, shared.Team3},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeGameState := &gamestate.GameState{
+				IIGOSanctionCache: map[int][]shared.Sanction{0: tc.sanctions},
+			}
+			election := Election{Logger: func(format string, a ...interface{}) {}}
+			election.ProposeElection(shared.Judge, shared.BordaCount)
+			election.SetEligibility(fakeGameState, &config.IIGOConfig{SanctionLadder: ladder})
+			islands := []shared.ClientID{shared.Team1, shared.Team2, shared.Team3}
+			election.OpenBallot(islands, copyCandidateList(islands))
+			info := election.GetVotingInfo()
+			if !reflect.DeepEqual(info.VoterList, tc.expectedVoters) {
+				t.Errorf("Expected voters %v got %v", tc.expectedVoters, info.VoterList)
+			}
+		})
+	}
+}
+
+func TestSanctionConsequencesInForce(t *testing.T) {
+	gameConf := &config.IIGOConfig{SanctionLadder: []config.SanctionTierConfig{
+		{Threshold: 1},
+		{Threshold: 5, Consequences: []shared.SanctionConsequence{shared.LoseVotingRight}},
+		{Threshold: 10, Consequences: []shared.SanctionConsequence{shared.LoseVotingRight, shared.ExcludedFromGifts}},
+	}}
+	fakeGameState := &gamestate.GameState{
+		IIGOSanctionCache: map[int][]shared.Sanction{
+			0: {
+				{ClientID: shared.Team1, SanctionTier: shared.SanctionTier1, TurnsLeft: 4},
+				{ClientID: shared.Team2, SanctionTier: shared.SanctionTier2, TurnsLeft: 0},
+				{ClientID: shared.Team4, SanctionTier: shared.NoSanction, TurnsLeft: 4},
+			},
+			1: {
+				{ClientID: shared.Team2, SanctionTier: shared.SanctionTier3, TurnsLeft: 1},
+				{ClientID: shared.Team3, SanctionTier: shared.SanctionTier2, TurnsLeft: -1},
+			},
+		},
+	}
+	expected := map[shared.ClientID]map[shared.SanctionConsequence]uint{
+		shared.Team2: {shared.LoseVotingRight: 2, shared.ExcludedFromGifts: 2},
+	}
+	res := SanctionConsequencesInForce(fakeGameState, gameConf)
+	if !reflect.DeepEqual(res, expected) {
+		t.Errorf("Expected consequences %v got %v", expected, res)
+	}
+	if !HasSanctionConsequence(shared.Team2, shared.ExcludedFromGifts, fakeGameState, gameConf) {
+		t.Errorf("Expected %v to be excluded from gifts", shared.Team2)
+	}
+	if HasSanctionConsequence(shared.Team3, shared.LoseVotingRight, fakeGameState, gameConf) {
+		t.Errorf("Expected the expired sanction of %v to have no consequence", shared.Team3)
+	}
+	islands := []shared.ClientID{shared.Team1, shared.Team2, shared.Team3}
+	if got, want := WithoutSanctionConsequence(islands, shared.ExcludedFromGifts, res), []shared.ClientID{shared.Team1, shared.Team3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected islands without the consequence %v got %v", want, got)
+	}
+}
@@ -11,7 +11,11 @@ import (
 type RuleVote struct {
 	//Checked by RuleVote
 	ruleToVote rules.RuleMatrix
-	voterList  []shared.ClientID
+	// bundledRules are voted on together with ruleToVote
+	bundledRules []rules.RuleMatrix
+	voterList    []shared.ClientID
+	// delegations map islands to the island they delegated their rule votes to
+	delegations map[shared.ClientID]shared.ClientID
 	//Held by RuleVote
 	ballots []shared.RuleVoteType
 	Logger  shared.Logger
@@ -32,23 +36,65 @@ func (v *RuleVote) SetRule(ruleMatrix rules.RuleMatrix) {
 	v.ruleToVote = ruleMatrix
 }
 
+// SetBundle sets several rules to be voted on together. An island approves the bundle
+// only if it approves every rule in it, and rejects it if it rejects any of them.
+func (v *RuleVote) SetBundle(ruleMatrices []rules.RuleMatrix) {
+	if len(ruleMatrices) == 0 {
+		return
+	}
+	v.ruleToVote = ruleMatrices[0]
+	v.bundledRules = ruleMatrices[1:]
+}
+
 // SetVotingIslands is called by baseSpeaker to set the islands eligible to vote.
 func (v *RuleVote) SetVotingIslands(clientIDs []shared.ClientID) {
 	//TODO: intersection of islands alive and islands chosen to vote
 	v.voterList = clientIDs
 }
 
+// SetDelegations sets the islands each island has delegated its rule votes to
+func (v *RuleVote) SetDelegations(delegations map[shared.ClientID]shared.ClientID) {
+	v.delegations = delegations
+}
+
 // GatherBallots is called by baseSpeaker to get votes from clients.
 func (v *RuleVote) GatherBallots(clientMap map[shared.ClientID]baseclient.Client) {
 	//Gather N ballots from islands
 	if v.ruleToVote.RuleName != "" && len(v.ruleToVote.RuleName) > 0 {
+		casters := ResolveDelegates(v.delegations, v.voterList)
+		cast := map[shared.ClientID]shared.RuleVoteType{}
 		for i := 0; i < len(v.voterList); i++ {
-			v.ballots = append(v.ballots, clientMap[v.voterList[i]].VoteForRule(v.ruleToVote))
+			caster := casters[v.voterList[i]]
+			ballot, ok := cast[caster]
+			if !ok {
+				client := clientMap[caster]
+				ballot = client.VoteForRule(v.ruleToVote)
+				for _, ruleMatrix := range v.bundledRules {
+					ballot = combineBundleBallots(ballot, client.VoteForRule(ruleMatrix))
+				}
+				cast[caster] = ballot
+			}
+			v.ballots = append(v.ballots, ballot)
+		}
+		if delegated := delegatedVoters(casters); len(delegated) > 0 {
+			v.Logf("Delegated votes: %v", delegated)
 		}
 	}
 	v.Logf("Votes: %v", v.ballots)
 }
 
+// combineBundleBallots combines an island's votes on two rules of a bundle: a rejection of
+// either rule rejects the bundle, otherwise an abstention on either rule abstains from it
+func combineBundleBallots(a, b shared.RuleVoteType) shared.RuleVoteType {
+	if a == shared.Reject || b == shared.Reject {
+		return shared.Reject
+	}
+	if a == shared.Abstain || b == shared.Abstain {
+		return shared.Abstain
+	}
+	return shared.Approve
+}
+
 //GetBallotBox is called by baseSpeaker and
 //returns the BallotBox with n votesInFavour and N-n votesAgainst
 func (v *RuleVote) GetBallotBox() BallotBox {
@@ -65,8 +111,29 @@ func (v *RuleVote) GetBallotBox() BallotBox {
 	return outcome
 }
 
+// GetVoterBallots returns the ballot counted for each voter, which is the ballot of its delegate if it delegated
+// its vote. It is kept by the server, the islands only see the anonymous BallotBox.
+func (v *RuleVote) GetVoterBallots() map[shared.ClientID]shared.RuleVoteType {
+	voterBallots := make(map[shared.ClientID]shared.RuleVoteType, len(v.ballots))
+	for i, ballot := range v.ballots {
+		voterBallots[v.voterList[i]] = ballot
+	}
+	return voterBallots
+}
+
 //CountVotesMajority is called by baseSpeaker and
 //returns the majority result of the BallotBox
 func (b *BallotBox) CountVotesMajority() bool {
 	return b.VotesInFavour > b.VotesAgainst
 }
+
+// CountVotesSupermajority returns true if at least the given share of the votes cast are in favour.
+// A share of one means unanimity and a share of one half or less falls back to a simple majority.
+func (b *BallotBox) CountVotesSupermajority(share float64) bool {
+	if share <= 0.5 {
+		return b.CountVotesMajority()
+	}
+	votesCast := b.VotesInFavour + b.VotesAgainst
+	// the tolerance keeps shares such as 2/3 from failing on rounding
+	return b.VotesInFavour > 0 && float64(b.VotesInFavour) >= share*float64(votesCast)-1e-9
+}
@@ -0,0 +1,179 @@
+package voting
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/baseclient"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/rules"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+type mockRuleVoter struct {
+	*baseclient.BaseClient
+	votes map[string]shared.RuleVoteType
+}
+
+func (c *mockRuleVoter) VoteForRule(ruleMatrix rules.RuleMatrix) shared.RuleVoteType {
+	return c.votes[ruleMatrix.RuleName]
+}
+
+func TestGatherBallots(t *testing.T) {
+	cases := []struct {
+		name        string
+		rules       []rules.RuleMatrix
+		votes       map[shared.ClientID]map[string]shared.RuleVoteType
+		delegations map[shared.ClientID]shared.ClientID
+		expected    BallotBox
+		// expectedBallots are the ballots counted for each voter
+		expectedBallots map[shared.ClientID]shared.RuleVoteType
+	}{
+		{
+			name:  "Single rule",
+			rules: []rules.RuleMatrix{{RuleName: "rule_a"}},
+			votes: map[shared.ClientID]map[string]shared.RuleVoteType{
+				shared.Team1: {"rule_a": shared.Approve},
+				shared.Team2: {"rule_a": shared.Reject},
+				shared.Team3: {"rule_a": shared.Approve},
+			},
+			expected: BallotBox{VotesInFavour: 2, VotesAgainst: 1},
+			expectedBallots: map[shared.ClientID]shared.RuleVoteType{
+				shared.Team1: shared.Approve,
+				shared.Team2: shared.Reject,
+				shared.Team3: shared.Approve,
+			},
+		},
+		{
+			name:  "Bundle approved only if every rule is approved",
+			rules: []rules.RuleMatrix{{RuleName: "rule_a"}, {RuleName: "rule_b"}},
+			votes: map[shared.ClientID]map[string]shared.RuleVoteType{
+				shared.Team1: {"rule_a": shared.Approve, "rule_b": shared.Approve},
+				shared.Team2: {"rule_a": shared.Approve, "rule_b": shared.Reject},
+				shared.Team3: {"rule_a": shared.Abstain, "rule_b": shared.Approve},
+			},
+			expected: BallotBox{VotesInFavour: 1, VotesAgainst: 1},
+			expectedBallots: map[shared.ClientID]shared.RuleVoteType{
+				shared.Team1: shared.Approve,
+				shared.Team2: shared.Reject,
+				shared.Team3: shared.Abstain,
+			},
+		},
+		{
+			name:  "Rejection dominates abstention",
+			rules: []rules.RuleMatrix{{RuleName: "rule_a"}, {RuleName: "rule_b"}, {RuleName: "rule_c"}},
+			votes: map[shared.ClientID]map[string]shared.RuleVoteType{
+				shared.Team1: {"rule_a": shared.Abstain, "rule_b": shared.Reject, "rule_c": shared.Approve},
+				shared.Team2: {"rule_a": shared.Approve, "rule_b": shared.Approve, "rule_c": shared.Approve},
+				shared.Team3: {"rule_a": shared.Approve, "rule_b": shared.Approve, "rule_c": shared.Approve},
+			},
+			expected: BallotBox{VotesInFavour: 2, VotesAgainst: 1},
+			expectedBallots: map[shared.ClientID]shared.RuleVoteType{
+				shared.Team1: shared.Reject,
+				shared.Team2: shared.Approve,
+				shared.Team3: shared.Approve,
+			},
+		},
+		{
+			name:  "Delegated votes cast by the delegate",
+			rules: []rules.RuleMatrix{{RuleName: "rule_a"}},
+			votes: map[shared.ClientID]map[string]shared.RuleVoteType{
+				shared.Team1: {"rule_a": shared.Approve},
+				shared.Team2: {"rule_a": shared.Approve},
+				shared.Team3: {"rule_a": shared.Reject},
+			},
+			delegations: map[shared.ClientID]shared.ClientID{
+				shared.Team1: shared.Team2,
+				shared.Team2: shared.Team3,
+			},
+			expected: BallotBox{VotesInFavour: 0, VotesAgainst: 3},
+			expectedBallots: map[shared.ClientID]shared.RuleVoteType{
+				shared.Team1: shared.Reject,
+				shared.Team2: shared.Reject,
+				shared.Team3: shared.Reject,
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			clientMap := map[shared.ClientID]baseclient.Client{}
+			voters := []shared.ClientID{}
+			for _, clientID := range []shared.ClientID{shared.Team1, shared.Team2, shared.Team3} {
+				clientMap[clientID] = &mockRuleVoter{BaseClient: baseclient.NewClient(clientID), votes: tc.votes[clientID]}
+				voters = append(voters, clientID)
+			}
+			ruleVote := RuleVote{Logger: func(format string, a ...interface{}) {}}
+			ruleVote.SetBundle(tc.rules)
+			ruleVote.SetVotingIslands(voters)
+			ruleVote.SetDelegations(tc.delegations)
+			ruleVote.GatherBallots(clientMap)
+			got := ruleVote.GetBallotBox()
+			if !reflect.DeepEqual(tc.expected, got) {
+				t.Errorf("Expected %v got %v", tc.expected, got)
+			}
+			gotBallots := ruleVote.GetVoterBallots()
+			if !reflect.DeepEqual(tc.expectedBallots, gotBallots) {
+				t.Errorf("Expected ballots %v got %v", tc.expectedBallots, gotBallots)
+			}
+		})
+	}
+}
+
+func TestCountVotesSupermajority(t *testing.T) {
+	cases := []struct {
+		name     string
+		ballots  BallotBox
+		share    float64
+		expected bool
+	}{
+		{
+			name:     "Simple majority",
+			ballots:  BallotBox{VotesInFavour: 3, VotesAgainst: 2},
+			share:    0.5,
+			expected: true,
+		},
+		{
+			name:     "Tie fails a simple majority",
+			ballots:  BallotBox{VotesInFavour: 2, VotesAgainst: 2},
+			share:    0,
+			expected: false,
+		},
+		{
+			name:     "Two thirds reached",
+			ballots:  BallotBox{VotesInFavour: 2, VotesAgainst: 1},
+			share:    2.0 / 3.0,
+			expected: true,
+		},
+		{
+			name:     "Two thirds missed",
+			ballots:  BallotBox{VotesInFavour: 3, VotesAgainst: 2},
+			share:    2.0 / 3.0,
+			expected: false,
+		},
+		{
+			name:     "Unanimity",
+			ballots:  BallotBox{VotesInFavour: 6, VotesAgainst: 0},
+			share:    1,
+			expected: true,
+		},
+		{
+			name:     "Unanimity broken by one vote against",
+			ballots:  BallotBox{VotesInFavour: 5, VotesAgainst: 1},
+			share:    1,
+			expected: false,
+		},
+		{
+			name:     "No votes in favour",
+			ballots:  BallotBox{},
+			share:    1,
+			expected: false,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.ballots.CountVotesSupermajority(tc.share)
+			if got != tc.expected {
+				t.Errorf("Expected %v got %v", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,291 @@
+package server
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/foraging"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+	"github.com/pkg/errors"
+)
+
+// runCoalitionSession collects the actions islands take on coalitions, in the order of the islands' IDs. Dead islands
+// leave their coalition first, then islands leave, form, join and contribute to coalitions. Members finally pay their
+// dues, and are expelled if they cannot.
+func (s *SOMASServer) runCoalitionSession() {
+	s.logf("start runCoalitionSession")
+	defer s.logf("finish runCoalitionSession")
+
+	for _, clientID := range shared.TeamIDs {
+		if coalition := s.coalitionOf(clientID); coalition != nil && s.gameState.ClientInfos[clientID].LifeStatus == shared.Dead {
+			s.removeCoalitionMember(coalition, clientID, shared.CoalitionLeft)
+		}
+	}
+
+	actions := map[shared.ClientID][]shared.CoalitionAction{}
+	for _, clientID := range shared.TeamIDs {
+		clientInfo, ok := s.gameState.ClientInfos[clientID]
+		if !ok || clientInfo.LifeStatus == shared.Dead {
+			continue
+		}
+		actions[clientID] = s.clientMap[clientID].GetCoalitionActions()
+	}
+
+	for _, clientID := range shared.TeamIDs {
+		for _, action := range actions[clientID] {
+			if action.Kind != shared.LeaveCoalition {
+				continue
+			}
+			if coalition := s.coalitionOf(clientID); coalition != nil {
+				s.removeCoalitionMember(coalition, clientID, shared.CoalitionLeft)
+			}
+		}
+	}
+
+	for _, clientID := range shared.TeamIDs {
+		for _, action := range actions[clientID] {
+			switch action.Kind {
+			case shared.FormCoalition:
+				s.formCoalition(clientID, action.Coalition, action.Rules)
+			case shared.JoinCoalition:
+				s.joinCoalition(clientID, action.Coalition)
+			case shared.ContributeToCoalition:
+				coalition := s.coalitionOf(clientID)
+				if coalition == nil || !(action.Amount > 0) || math.IsInf(float64(action.Amount), 1) {
+					s.logf("[IITO]: %v made an invalid coalition contribution: %v", clientID, action)
+					continue
+				}
+				s.payIntoTreasury(coalition, clientID, action.Amount, shared.CoalitionContribution)
+			}
+		}
+	}
+
+	for i := range s.gameState.IITOCoalitions {
+		coalition := &s.gameState.IITOCoalitions[i]
+		if coalition.Dissolved || coalition.Rules.Dues == 0 {
+			continue
+		}
+		for _, clientID := range append([]shared.ClientID{}, coalition.Members...) {
+			if !s.payIntoTreasury(coalition, clientID, coalition.Rules.Dues, shared.CoalitionDuesPaid) {
+				s.removeCoalitionMember(coalition, clientID, shared.CoalitionExpelled)
+			}
+		}
+	}
+}
+
+// formCoalition founds a coalition if its name is not taken by an active coalition, its rules are valid, and the
+// founder is not in a coalition and can pay the entry fee
+func (s *SOMASServer) formCoalition(clientID shared.ClientID, name string, rules shared.CoalitionRules) {
+	if name == "" || s.findCoalition(name) != nil || s.coalitionOf(clientID) != nil || !validCoalitionRules(rules) {
+		s.logf("[IITO]: %v cannot form coalition '%v' with rules %v", clientID, name, rules)
+		return
+	}
+	if rules.EntryFee > s.gameState.ClientInfos[clientID].Resources {
+		s.logf("[IITO]: %v cannot afford the entry fee of coalition '%v'", clientID, name)
+		return
+	}
+
+	s.gameState.IITOCoalitions = append(s.gameState.IITOCoalitions, shared.Coalition{
+		Name:       name,
+		Founder:    clientID,
+		Rules:      rules,
+		Members:    []shared.ClientID{clientID},
+		FormedTurn: s.gameState.Turn,
+	})
+	coalition := &s.gameState.IITOCoalitions[len(s.gameState.IITOCoalitions)-1]
+	s.recordCoalitionEvent(coalition, shared.CoalitionFormed, clientID, 0)
+	if rules.EntryFee > 0 {
+		s.payIntoTreasury(coalition, clientID, rules.EntryFee, shared.CoalitionEntryFeePaid)
+	}
+}
+
+// joinCoalition adds an island which is not in a coalition to an active coalition, if it can pay the entry fee and,
+// for coalitions with ApprovedMembership, a majority of the members approve
+func (s *SOMASServer) joinCoalition(clientID shared.ClientID, name string) {
+	coalition := s.findCoalition(name)
+	if coalition == nil || s.coalitionOf(clientID) != nil {
+		s.logf("[IITO]: %v cannot join coalition '%v'", clientID, name)
+		return
+	}
+	if coalition.Rules.EntryFee > s.gameState.ClientInfos[clientID].Resources {
+		s.logf("[IITO]: %v cannot afford the entry fee of coalition '%v'", clientID, name)
+		return
+	}
+	if coalition.Rules.Membership == shared.ApprovedMembership {
+		approvals := 0
+		for _, member := range coalition.Members {
+			if s.clientMap[member].ApproveCoalitionMember(name, clientID) {
+				approvals++
+			}
+		}
+		if 2*approvals <= len(coalition.Members) {
+			s.recordCoalitionEvent(coalition, shared.CoalitionJoinRejected, clientID, 0)
+			return
+		}
+	}
+
+	coalition.Members = append(coalition.Members, clientID)
+	s.recordCoalitionEvent(coalition, shared.CoalitionJoined, clientID, 0)
+	if coalition.Rules.EntryFee > 0 {
+		s.payIntoTreasury(coalition, clientID, coalition.Rules.EntryFee, shared.CoalitionEntryFeePaid)
+	}
+}
+
+// validCoalitionRules checks that amounts are non-negative and finite, and the forage share is a fraction
+func validCoalitionRules(rules shared.CoalitionRules) bool {
+	for _, amount := range []shared.Resources{rules.EntryFee, rules.Dues, rules.MutualAid} {
+		if !(amount >= 0) || math.IsInf(float64(amount), 1) {
+			return false
+		}
+	}
+	return (rules.Membership == shared.OpenMembership || rules.Membership == shared.ApprovedMembership) &&
+		shared.IsValidForageType(rules.ForageType) &&
+		rules.ForageShare >= 0 && rules.ForageShare <= 1
+}
+
+// payIntoTreasury takes an amount from an island into the treasury of its coalition.
+// It returns false if the island cannot afford it.
+func (s *SOMASServer) payIntoTreasury(coalition *shared.Coalition, clientID shared.ClientID, amount shared.Resources, kind shared.CoalitionEventKind) bool {
+	transactionMsg := fmt.Sprintf("[IITO]: %v paid %v into the treasury of coalition '%v'", clientID, amount, coalition.Name)
+	if err := s.takeResources(clientID, amount, "TAKE: "+transactionMsg); err != nil {
+		s.logf("[IITO]: %v cannot pay %v into the treasury of coalition '%v': %v", clientID, amount, coalition.Name, err)
+		return false
+	}
+	coalition.Treasury += amount
+	s.recordCoalitionEvent(coalition, kind, clientID, amount)
+	return true
+}
+
+// removeCoalitionMember removes a member from its coalition. A coalition losing its last member is dissolved,
+// and its treasury goes to the common pool.
+func (s *SOMASServer) removeCoalitionMember(coalition *shared.Coalition, clientID shared.ClientID, kind shared.CoalitionEventKind) {
+	members := []shared.ClientID{}
+	for _, member := range coalition.Members {
+		if member != clientID {
+			members = append(members, member)
+		}
+	}
+	coalition.Members = members
+	s.recordCoalitionEvent(coalition, kind, clientID, 0)
+
+	if len(coalition.Members) == 0 {
+		s.gameState.CommonPool += coalition.Treasury
+		s.recordCoalitionEvent(coalition, shared.CoalitionDissolved, coalition.Founder, coalition.Treasury)
+		coalition.Treasury = 0
+		coalition.Dissolved = true
+		coalition.DissolvedTurn = s.gameState.Turn
+	}
+}
+
+// findCoalition returns the active coalition with a name, or nil if there is none
+func (s *SOMASServer) findCoalition(name string) *shared.Coalition {
+	for i := range s.gameState.IITOCoalitions {
+		if coalition := &s.gameState.IITOCoalitions[i]; !coalition.Dissolved && coalition.Name == name {
+			return coalition
+		}
+	}
+	return nil
+}
+
+// coalitionOf returns the active coalition an island is a member of, or nil if there is none
+func (s *SOMASServer) coalitionOf(clientID shared.ClientID) *shared.Coalition {
+	for i := range s.gameState.IITOCoalitions {
+		if coalition := &s.gameState.IITOCoalitions[i]; !coalition.Dissolved && clientArrayContains(coalition.Members, clientID) {
+			return coalition
+		}
+	}
+	return nil
+}
+
+func (s *SOMASServer) recordCoalitionEvent(coalition *shared.Coalition, kind shared.CoalitionEventKind, clientID shared.ClientID, amount shared.Resources) {
+	s.logf("[IITO]: Coalition '%v': %v %v %v", coalition.Name, kind, clientID, amount)
+	s.gameState.IITOCoalitionEvents = append(s.gameState.IITOCoalitionEvents, shared.CoalitionEvent{
+		Turn:      s.gameState.Turn,
+		Coalition: coalition.Name,
+		Kind:      kind,
+		ClientID:  clientID,
+		Amount:    amount,
+	})
+}
+
+// runCoalitionEndOfTurn runs the foraging expedition of each coalition, funded by its ForageShare of the treasury
+// and returning to the treasury, then pays mutual aid from the treasury to its critical members
+func (s *SOMASServer) runCoalitionEndOfTurn() error {
+	s.logf("start runCoalitionEndOfTurn")
+	defer s.logf("finish runCoalitionEndOfTurn")
+
+	for i := range s.gameState.IITOCoalitions {
+		coalition := &s.gameState.IITOCoalitions[i]
+		if coalition.Dissolved {
+			continue
+		}
+		if err := s.runCoalitionExpedition(coalition); err != nil {
+			return errors.Errorf("Failed to run the foraging expedition of coalition '%v': %v", coalition.Name, err)
+		}
+
+		aid := coalition.Rules.MutualAid
+		if aid == 0 {
+			continue
+		}
+		for _, clientID := range coalition.Members {
+			if s.gameState.ClientInfos[clientID].LifeStatus != shared.Critical {
+				continue
+			}
+			if aid > coalition.Treasury {
+				s.logf("[IITO]: Coalition '%v' cannot afford mutual aid for %v", coalition.Name, clientID)
+				continue
+			}
+			if err := s.giveResources(clientID, aid, fmt.Sprintf("[IITO]: mutual aid from coalition '%v'", coalition.Name)); err != nil {
+				s.logf("Ignoring failure to give resources in runCoalitionEndOfTurn: %v", err)
+				continue
+			}
+			coalition.Treasury -= aid
+			s.recordCoalitionEvent(coalition, shared.CoalitionAidPaid, clientID, aid)
+		}
+	}
+	return nil
+}
+
+// runCoalitionExpedition forages with the coalition's ForageShare of its treasury, contributed in equal parts on
+// behalf of its members. The expedition is not part of the foraging history of the islands.
+func (s *SOMASServer) runCoalitionExpedition(coalition *shared.Coalition) error {
+	input := coalition.Treasury * shared.Resources(coalition.Rules.ForageShare)
+	if !(input > 0) || len(coalition.Members) == 0 {
+		return nil
+	}
+	contributions := map[shared.ClientID]shared.Resources{}
+	for _, clientID := range coalition.Members {
+		contributions[clientID] = input / shared.Resources(len(coalition.Members))
+	}
+
+	var report foraging.ForagingReport
+	switch coalition.Rules.ForageType {
+	case shared.DeerForageType:
+		dhConf := s.gameConfig.ForagingConfig.DeerHuntConfig
+		hunt, err := foraging.CreateDeerHunt(contributions, dhConf, s.logf)
+		if err != nil {
+			return err
+		}
+		report = hunt.Hunt(dhConf, s.availableDeer())
+		s.deerHunts = append(s.deerHunts, report.NumberCaught)
+	case shared.FishForageType:
+		fConf := s.gameConfig.ForagingConfig.FishingConfig
+		expedition, err := foraging.CreateFishingExpedition(contributions, fConf, s.logf)
+		if err != nil {
+			return err
+		}
+		report = expedition.Fish(fConf)
+	}
+
+	coalition.Treasury += report.TotalUtility - input
+	s.logf("[IITO]: Coalition '%v' foraged %v with %v and got %v", coalition.Name, coalition.Rules.ForageType, input, report.TotalUtility)
+	s.gameState.IITOCoalitionExpeditions = append(s.gameState.IITOCoalitionExpeditions, shared.CoalitionExpedition{
+		Turn:         s.gameState.Turn,
+		Coalition:    coalition.Name,
+		ForageType:   coalition.Rules.ForageType,
+		Input:        input,
+		NumberCaught: report.NumberCaught,
+		Return:       report.TotalUtility,
+	})
+	return nil
+}
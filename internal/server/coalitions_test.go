@@ -0,0 +1,227 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/baseclient"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/foraging"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/gamestate"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+type mockClientCoalition struct {
+	baseclient.Client
+	actions []shared.CoalitionAction
+	approve bool
+}
+
+func (c *mockClientCoalition) GetCoalitionActions() []shared.CoalitionAction {
+	return c.actions
+}
+
+func (c *mockClientCoalition) ApproveCoalitionMember(coalition string, applicant shared.ClientID) bool {
+	return c.approve
+}
+
+func TestRunCoalitionSession(t *testing.T) {
+	clientMap := map[shared.ClientID]baseclient.Client{
+		shared.Team1: &mockClientCoalition{
+			actions: []shared.CoalitionAction{
+				{Kind: shared.ContributeToCoalition, Amount: 8},
+			},
+		},
+		shared.Team2: &mockClientCoalition{
+			actions: []shared.CoalitionAction{
+				// Leaving happens before joining, and only Team 3 of Lagoon's two members approves
+				{Kind: shared.JoinCoalition, Coalition: "Lagoon"},
+				{Kind: shared.LeaveCoalition},
+			},
+		},
+		shared.Team3: &mockClientCoalition{approve: true},
+		shared.Team4: &mockClientCoalition{approve: false},
+		shared.Team5: &mockClientCoalition{},
+		shared.Team6: &mockClientCoalition{
+			actions: []shared.CoalitionAction{
+				// Reef is taken
+				{Kind: shared.FormCoalition, Coalition: "Reef"},
+				// Atoll is dissolved when its dead member leaves, so its name is free
+				{Kind: shared.FormCoalition, Coalition: "Atoll", Rules: shared.CoalitionRules{EntryFee: 4, ForageShare: 0.5}},
+				{Kind: shared.ContributeToCoalition, Amount: -1},
+			},
+		},
+	}
+
+	s := &SOMASServer{
+		gameState: gamestate.GameState{
+			Turn: 2,
+			ClientInfos: map[shared.ClientID]gamestate.ClientInfo{
+				shared.Team1: {LifeStatus: shared.Alive, Resources: 100},
+				shared.Team2: {LifeStatus: shared.Alive, Resources: 100},
+				shared.Team3: {LifeStatus: shared.Alive, Resources: 100},
+				shared.Team4: {LifeStatus: shared.Critical, Resources: 1},
+				shared.Team5: {LifeStatus: shared.Dead},
+				shared.Team6: {LifeStatus: shared.Alive, Resources: 100},
+			},
+			IITOCoalitions: []shared.Coalition{
+				{Name: "Reef", Founder: shared.Team1, Rules: shared.CoalitionRules{Dues: 2}, Members: []shared.ClientID{shared.Team1, shared.Team2}, Treasury: 10},
+				{Name: "Lagoon", Founder: shared.Team3, Rules: shared.CoalitionRules{Membership: shared.ApprovedMembership, Dues: 3}, Members: []shared.ClientID{shared.Team3, shared.Team4}},
+				{Name: "Atoll", Founder: shared.Team5, Members: []shared.ClientID{shared.Team5}, Treasury: 7},
+			},
+		},
+		clientMap: clientMap,
+	}
+
+	s.runCoalitionSession()
+
+	wantCoalitions := []shared.Coalition{
+		{Name: "Reef", Founder: shared.Team1, Rules: shared.CoalitionRules{Dues: 2}, Members: []shared.ClientID{shared.Team1}, Treasury: 20},
+		// Team 4 cannot pay its dues
+		{Name: "Lagoon", Founder: shared.Team3, Rules: shared.CoalitionRules{Membership: shared.ApprovedMembership, Dues: 3}, Members: []shared.ClientID{shared.Team3}, Treasury: 3},
+		{Name: "Atoll", Founder: shared.Team5, Members: []shared.ClientID{}, Dissolved: true, DissolvedTurn: 2},
+		{Name: "Atoll", Founder: shared.Team6, Rules: shared.CoalitionRules{EntryFee: 4, ForageShare: 0.5}, Members: []shared.ClientID{shared.Team6}, Treasury: 4, FormedTurn: 2},
+	}
+	if !reflect.DeepEqual(wantCoalitions, s.gameState.IITOCoalitions) {
+		t.Errorf("want coalitions '%v' got '%v'", wantCoalitions, s.gameState.IITOCoalitions)
+	}
+
+	wantEvents := []shared.CoalitionEvent{
+		{Turn: 2, Coalition: "Atoll", Kind: shared.CoalitionLeft, ClientID: shared.Team5},
+		{Turn: 2, Coalition: "Atoll", Kind: shared.CoalitionDissolved, ClientID: shared.Team5, Amount: 7},
+		{Turn: 2, Coalition: "Reef", Kind: shared.CoalitionLeft, ClientID: shared.Team2},
+		{Turn: 2, Coalition: "Reef", Kind: shared.CoalitionContribution, ClientID: shared.Team1, Amount: 8},
+		{Turn: 2, Coalition: "Lagoon", Kind: shared.CoalitionJoinRejected, ClientID: shared.Team2},
+		{Turn: 2, Coalition: "Atoll", Kind: shared.CoalitionFormed, ClientID: shared.Team6},
+		{Turn: 2, Coalition: "Atoll", Kind: shared.CoalitionEntryFeePaid, ClientID: shared.Team6, Amount: 4},
+		{Turn: 2, Coalition: "Reef", Kind: shared.CoalitionDuesPaid, ClientID: shared.Team1, Amount: 2},
+		{Turn: 2, Coalition: "Lagoon", Kind: shared.CoalitionDuesPaid, ClientID: shared.Team3, Amount: 3},
+		{Turn: 2, Coalition: "Lagoon", Kind: shared.CoalitionExpelled, ClientID: shared.Team4},
+	}
+	if !reflect.DeepEqual(wantEvents, s.gameState.IITOCoalitionEvents) {
+		t.Errorf("want events '%v' got '%v'", wantEvents, s.gameState.IITOCoalitionEvents)
+	}
+
+	wantResources := map[shared.ClientID]shared.Resources{
+		shared.Team1: 90,
+		shared.Team2: 100,
+		shared.Team3: 97,
+		shared.Team4: 1,
+		shared.Team6: 96,
+	}
+	for clientID, want := range wantResources {
+		if got := s.gameState.ClientInfos[clientID].Resources; got != want {
+			t.Errorf("%v: want resources %v got %v", clientID, want, got)
+		}
+	}
+	if s.gameState.CommonPool != 7 {
+		t.Errorf("want common pool 7 got %v", s.gameState.CommonPool)
+	}
+}
+
+func TestRunCoalitionEndOfTurn(t *testing.T) {
+	s := &SOMASServer{
+		gameState: gamestate.GameState{
+			Turn: 2,
+			ClientInfos: map[shared.ClientID]gamestate.ClientInfo{
+				shared.Team1: {LifeStatus: shared.Alive},
+				shared.Team2: {LifeStatus: shared.Critical},
+				shared.Team3: {LifeStatus: shared.Critical},
+			},
+			IITOCoalitions: []shared.Coalition{
+				{
+					Name:     "Reef",
+					Rules:    shared.CoalitionRules{ForageType: shared.FishForageType, ForageShare: 0.5, MutualAid: 3},
+					Members:  []shared.ClientID{shared.Team1, shared.Team2},
+					Treasury: 20,
+				},
+				// Lagoon cannot afford its mutual aid
+				{Name: "Lagoon", Rules: shared.CoalitionRules{MutualAid: 5}, Members: []shared.ClientID{shared.Team3}, Treasury: 4},
+				{Name: "Atoll", Rules: shared.CoalitionRules{ForageShare: 1, MutualAid: 5}, Treasury: 10, Dissolved: true},
+			},
+		},
+	}
+
+	if err := s.runCoalitionEndOfTurn(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expeditions := s.gameState.IITOCoalitionExpeditions
+	if len(expeditions) != 1 {
+		t.Fatalf("want 1 expedition got '%v'", expeditions)
+	}
+	expedition := expeditions[0]
+	if expedition.Turn != 2 || expedition.Coalition != "Reef" || expedition.ForageType != shared.FishForageType || expedition.Input != 10 {
+		t.Errorf("unexpected expedition '%v'", expedition)
+	}
+
+	wantTreasuries := []shared.Resources{20 - 10 + expedition.Return - 3, 4, 10}
+	for i, want := range wantTreasuries {
+		if got := s.gameState.IITOCoalitions[i].Treasury; got != want {
+			t.Errorf("%v: want treasury %v got %v", s.gameState.IITOCoalitions[i].Name, want, got)
+		}
+	}
+	wantEvents := []shared.CoalitionEvent{
+		{Turn: 2, Coalition: "Reef", Kind: shared.CoalitionAidPaid, ClientID: shared.Team2, Amount: 3},
+	}
+	if !reflect.DeepEqual(wantEvents, s.gameState.IITOCoalitionEvents) {
+		t.Errorf("want events '%v' got '%v'", wantEvents, s.gameState.IITOCoalitionEvents)
+	}
+	if got := s.gameState.ClientInfos[shared.Team2].Resources; got != 3 {
+		t.Errorf("want Team2 resources 3 got %v", got)
+	}
+}
+
+func TestCoalitionDeerHuntSharesPopulationStep(t *testing.T) {
+	dhConf := config.DeerHuntConfig{
+		MaxDeerPerHunt:        4,
+		IncrementalInputDecay: 0.8,
+		BernoulliProb:         0.95,
+		ExponentialRate:       1,
+		InputScaler:           12,
+		OutputScaler:          18,
+		DistributionStrategy:  shared.InputProportionalSplit,
+		ThetaCritical:         0.8,
+		ThetaMax:              0.95,
+		MaxDeerPopulation:     12,
+		DeerGrowthCoefficient: 0.4,
+	}
+	s := &SOMASServer{
+		gameConfig: config.Config{ForagingConfig: config.ForagingConfig{DeerHuntConfig: dhConf}},
+		gameState: gamestate.GameState{
+			ClientInfos: map[shared.ClientID]gamestate.ClientInfo{
+				shared.Team1: {LifeStatus: shared.Alive},
+			},
+			DeerPopulation: foraging.CreateDeerPopulationModel(dhConf, func(format string, a ...interface{}) {}),
+			IITOCoalitions: []shared.Coalition{
+				{Name: "Reef", Rules: shared.CoalitionRules{ForageType: shared.DeerForageType, ForageShare: 1}, Members: []shared.ClientID{shared.Team1}, Treasury: 100},
+				{Name: "Lagoon", Rules: shared.CoalitionRules{ForageType: shared.DeerForageType, ForageShare: 1}, Members: []shared.ClientID{shared.Team1}, Treasury: 100},
+			},
+		},
+		// The islands' deer hunt already caught 2 deer this turn
+		deerHunts: []uint{2},
+	}
+
+	if err := s.runCoalitionEndOfTurn(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.gameState.DeerPopulation.T != 0 || s.gameState.DeerPopulation.Population != 12 {
+		t.Errorf("deer population updated before the end of the turn: %v at %v", s.gameState.DeerPopulation.Population, s.gameState.DeerPopulation.T)
+	}
+	caught := uint(2)
+	for _, expedition := range s.gameState.IITOCoalitionExpeditions {
+		caught += expedition.NumberCaught
+	}
+	if caught > 12 {
+		t.Errorf("hunts caught %v deer out of 12", caught)
+	}
+
+	want := foraging.CreateDeerPopulationModel(dhConf, func(format string, a ...interface{}) {}).Simulate([]int{int(caught)})
+	s.updateDeerPopulation()
+	if s.gameState.DeerPopulation.T != want.T || s.gameState.DeerPopulation.Population != want.Population {
+		t.Errorf("want deer population %v at %v got %v at %v", want.Population, want.T, s.gameState.DeerPopulation.Population, s.gameState.DeerPopulation.T)
+	}
+	if s.deerHunts != nil {
+		t.Errorf("want deer hunts reset got %v", s.deerHunts)
+	}
+}
@@ -11,6 +11,7 @@ func (s *SOMASServer) runForage() error {
 	s.logf("start runForage")
 	defer s.logf("finish runForage")
 
+	s.gameState.ForagingReturns = map[shared.ClientID]shared.Resources{}
 	foragingParticipants, err := s.getForagingDecisions()
 	if err != nil {
 		return errors.Errorf("Something went wrong getting the foraging decision:%v", err)
@@ -108,7 +109,7 @@ func (s *SOMASServer) runDeerHunt(contributions map[shared.ClientID]shared.Resou
 		return errors.Errorf("Error running deer hunt: %v", err)
 	}
 
-	huntReport := hunt.Hunt(dhConf, uint(s.gameState.DeerPopulation.Population))
+	huntReport := hunt.Hunt(dhConf, s.availableDeer())
 	huntReport.Turn = s.gameState.Turn // update report's Turn with actual turn value
 	// update foraging history
 	if s.gameState.ForagingHistory[shared.DeerForageType] == nil {
@@ -120,9 +121,7 @@ func (s *SOMASServer) runDeerHunt(contributions map[shared.ClientID]shared.Resou
 
 	s.logf("Deer hunt report: %v", huntReport.Display())
 
-	// update deer population // TODO: decide if there is a better place to do this
-	s.logf("Updating deer population after %v deer hunted", huntReport.NumberCaught)
-	s.updateDeerPopulation(huntReport.NumberCaught) // update deer population based on hunt
+	s.deerHunts = append(s.deerHunts, huntReport.NumberCaught) // deer population updated at the end of the turn
 
 	return nil
 }
@@ -130,45 +129,23 @@ func (s *SOMASServer) runDeerHunt(contributions map[shared.ClientID]shared.Resou
 func (s *SOMASServer) distributeForageReturn(contributions map[shared.ClientID]shared.Resources, huntReport foraging.ForagingReport) {
 	// distribute return amongst participants
 
-	totalContributions := shared.Resources(0)
-	for _, c := range huntReport.ParticipantContributions {
-		totalContributions += c
-	}
-
 	if len(huntReport.ParticipantContributions) == 0 {
 		return // to prevent div0 below. Also, no need to evaluate further
 	}
 
-	// auxiliary return info. Just to avoid repetition.
-	type auxReturnInfo struct {
-		distrStrategy        shared.ResourceDistributionStrategy
-		resourceReturnReason string
+	returnReasons := map[shared.ForageType]string{
+		shared.DeerForageType: "Deer hunt return",
+		shared.FishForageType: "Fishing return",
 	}
 
 	for participantID, contribution := range contributions {
-		deerReturnStrat := s.gameConfig.ForagingConfig.DeerHuntConfig.DistributionStrategy
-		fishReturnStrat := s.gameConfig.ForagingConfig.FishingConfig.DistributionStrategy
-
-		returnInfoPerType := map[shared.ForageType]auxReturnInfo{
-			shared.DeerForageType: {distrStrategy: deerReturnStrat, resourceReturnReason: "Deer hunt return"},
-			shared.FishForageType: {distrStrategy: fishReturnStrat, resourceReturnReason: "Fishing return"},
-		}
-		participantReturn := shared.Resources(0.0)      // default to zero return
-		retReason := "Unspecified foraging return type" // default if f. type not found
-		// check if the foraging type has been specified above
-		if r, ok := returnInfoPerType[huntReport.ForageType]; ok {
-			retReason = r.resourceReturnReason
-
-			if totalContributions > 0.0 {
-				switch r.distrStrategy {
-				case shared.InputProportionalSplit:
-					participantReturn = (contribution / totalContributions) * huntReport.TotalUtility
-				case shared.EqualSplit, shared.RankProportionalSplit: // RankProportional is just same as equal split for now
-					participantReturn = huntReport.TotalUtility / shared.Resources(len(huntReport.ParticipantContributions)) // this casting is a bit lazy
-				}
-			}
+		participantReturn := s.forageReturn(huntReport, contribution)
+		retReason, ok := returnReasons[huntReport.ForageType]
+		if !ok {
+			retReason = "Unspecified foraging return type" // default if f. type not found
 		}
 
+		s.gameState.ForagingReturns[participantID] += participantReturn
 		err := s.giveResources(participantID, participantReturn, retReason)
 		if err != nil {
 			s.logf("Ignoring failure to give resources in distributeForageReturn: %v", err)
@@ -180,6 +157,36 @@ func (s *SOMASServer) distributeForageReturn(contributions map[shared.ClientID]s
 	}
 }
 
+// forageReturn returns the share of the return of a foraging session due to a participant with the given contribution,
+// according to the distribution strategy of the foraging type
+func (s *SOMASServer) forageReturn(report foraging.ForagingReport, contribution shared.Resources) shared.Resources {
+	totalContributions := shared.Resources(0)
+	for _, c := range report.ParticipantContributions {
+		totalContributions += c
+	}
+	if !(totalContributions > 0.0) {
+		return 0
+	}
+
+	var distrStrategy shared.ResourceDistributionStrategy
+	switch report.ForageType {
+	case shared.DeerForageType:
+		distrStrategy = s.gameConfig.ForagingConfig.DeerHuntConfig.DistributionStrategy
+	case shared.FishForageType:
+		distrStrategy = s.gameConfig.ForagingConfig.FishingConfig.DistributionStrategy
+	default:
+		return 0 // default to zero return if f. type not found
+	}
+
+	switch distrStrategy {
+	case shared.InputProportionalSplit:
+		return (contribution / totalContributions) * report.TotalUtility
+	case shared.EqualSplit, shared.RankProportionalSplit: // RankProportional is just same as equal split for now
+		return report.TotalUtility / shared.Resources(len(report.ParticipantContributions)) // this casting is a bit lazy
+	}
+	return 0
+}
+
 func (s *SOMASServer) runFishingExpedition(contributions map[shared.ClientID]shared.Resources) error {
 	s.logf("start runFishHunt")
 	defer s.logf("finish runFishHunt")
@@ -206,8 +213,30 @@ func (s *SOMASServer) runFishingExpedition(contributions map[shared.ClientID]sha
 	return nil
 }
 
-// updateDeerPopulation adjusts deer pop. based on consumption of deer after hunt
-func (s *SOMASServer) updateDeerPopulation(consumption uint) {
+// availableDeer returns the deer population left for hunting after the hunts of this turn
+func (s *SOMASServer) availableDeer() uint {
+	population := s.gameState.DeerPopulation.Population
+	for _, caught := range s.deerHunts {
+		population -= float64(caught)
+	}
+	if population < 0 {
+		return 0
+	}
+	return uint(population)
+}
+
+// updateDeerPopulation adjusts deer pop. based on consumption of deer after all hunts of the turn, advancing the
+// population model by one step if any deer hunt took place
+func (s *SOMASServer) updateDeerPopulation() {
+	if len(s.deerHunts) == 0 {
+		return
+	}
+	consumption := uint(0)
+	for _, caught := range s.deerHunts {
+		consumption += caught
+	}
+	s.logf("Updating deer population after %v deer hunted", consumption)
 	updatedModel := s.gameState.DeerPopulation.Simulate([]int{int(consumption)}) // updates pop. according to DE definition
 	s.gameState.DeerPopulation = updatedModel
+	s.deerHunts = nil
 }
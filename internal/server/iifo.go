@@ -1,6 +1,7 @@
 package server
 
 import (
+	"fmt"
 	"math"
 
 	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
@@ -16,6 +17,8 @@ func (s *SOMASServer) runIIFO() error {
 
 	s.runForageSharing()
 
+	s.runPredictionMarketSession()
+
 	// TODO:- IIFO team
 	return nil
 }
@@ -31,16 +34,186 @@ func (s *SOMASServer) runPredictionSession() {
 	s.logf("start runPredictionSession")
 	defer s.logf("finish runPredictionSession")
 	islandPredictionDict := s.getPredictions()
+	s.recordPredictions(islandPredictionDict)
 
 	s.distributePredictions(islandPredictionDict)
 }
 
+// recordPredictions keeps the predictions made this turn so that they can be scored once they resolve
+func (s *SOMASServer) recordPredictions(islandPredictionDict shared.DisasterPredictionInfoDict) {
+	for _, id := range shared.TeamIDs {
+		info, ok := islandPredictionDict[id]
+		if !ok {
+			continue
+		}
+		s.gameState.IIFOPredictions = append(s.gameState.IIFOPredictions, shared.ScoredDisasterPrediction{
+			Predictor:  id,
+			Prediction: info.PredictionMade,
+			TurnMade:   s.gameState.Turn,
+		})
+	}
+}
+
+// scoreDisasterPredictions scores the predictions which resolve this turn: all pending predictions if a disaster
+// happened, and predictions whose predicted turn has passed otherwise. Each gets the Brier score of its
+// confidence against whether the disaster happened in the predicted turn. If a prediction reward is configured,
+// the most accurate forecaster among the alive islands with predictions resolved is paid from the common pool.
+func (s *SOMASServer) scoreDisasterPredictions(disasterHappened bool) {
+	s.logf("start scoreDisasterPredictions")
+	defer s.logf("finish scoreDisasterPredictions")
+
+	turn := s.gameState.Turn
+	report := s.gameState.Environment.LastDisasterReport
+	brierSums := map[shared.ClientID]float64{}
+	resolvedCounts := map[shared.ClientID]int{}
+	for i := range s.gameState.IIFOPredictions {
+		scored := &s.gameState.IIFOPredictions[i]
+		predictedTurn := scored.TurnMade + scored.Prediction.TimeLeft
+		if scored.Resolved || !disasterHappened && turn < predictedTurn {
+			continue
+		}
+		outcome := 0.0
+		if disasterHappened && turn == predictedTurn {
+			outcome = 1
+		}
+		probability := math.Max(0, math.Min(1, scored.Prediction.Confidence/100))
+
+		scored.Resolved = true
+		scored.TurnResolved = turn
+		scored.DisasterHappened = disasterHappened
+		scored.BrierScore = math.Pow(probability-outcome, 2)
+		if disasterHappened {
+			scored.MagnitudeError = math.Abs(scored.Prediction.Magnitude - report.Magnitude)
+			scored.LocationError = math.Hypot(scored.Prediction.CoordinateX-report.X, scored.Prediction.CoordinateY-report.Y)
+		}
+		s.logf("[IIFO]: Prediction of %v made in turn %v scored %v", scored.Predictor, scored.TurnMade, scored.BrierScore)
+
+		brierSums[scored.Predictor] += scored.BrierScore
+		resolvedCounts[scored.Predictor]++
+	}
+
+	reward := s.gameConfig.IIFOConfig.PredictionReward
+	if !(reward > 0) {
+		return
+	}
+	forecaster, bestScore := shared.ClientID(0), math.Inf(1)
+	for _, id := range shared.TeamIDs {
+		if resolvedCounts[id] == 0 || s.gameState.ClientInfos[id].LifeStatus == shared.Dead {
+			continue
+		}
+		if score := brierSums[id] / float64(resolvedCounts[id]); score < bestScore {
+			forecaster, bestScore = id, score
+		}
+	}
+	if math.IsInf(bestScore, 1) {
+		return
+	}
+	if reward > s.gameState.CommonPool-s.gameState.IIGOCommonPoolReserve {
+		s.logf("[IIFO]: Common pool cannot afford the prediction reward of %v", reward)
+		return
+	}
+	if err := s.giveResources(forecaster, reward, "[IIFO]: prediction reward"); err != nil {
+		s.logf("Ignoring failure to give resources in scoreDisasterPredictions: %v", err)
+		return
+	}
+	s.gameState.CommonPool -= reward
+	s.gameState.IIFOPredictionRewards = append(s.gameState.IIFOPredictionRewards, shared.PredictionReward{
+		Turn:           turn,
+		Forecaster:     forecaster,
+		MeanBrierScore: bestScore,
+		Amount:         reward,
+	})
+}
+
 func (s *SOMASServer) runForageSharing() {
 	s.logf("Run Forage Predictions")
 	defer s.logf("Finish Running Forage Predictions")
 
 	otherIslandInfo := s.getForageSharing()
+	if s.gameConfig.IIFOConfig.VerifyForageSharing {
+		for id, info := range otherIslandInfo {
+			info.Verification = s.verifyForageShareInfo(id, info)
+			otherIslandInfo[id] = info
+		}
+	}
 	s.distributeForageSharing(otherIslandInfo)
+	s.runForageAudits(otherIslandInfo)
+}
+
+// verifyForageShareInfo checks foraging information shared by an island against its most recent foraging decision,
+// found in the foraging history, and the return it got from it. An island which never foraged must share
+// a contribution and a return of 0 to be truthful.
+func (s *SOMASServer) verifyForageShareInfo(clientID shared.ClientID, info shared.ForageShareInfo) shared.ForageReportVerification {
+	decision, obtained, foraged := shared.ForageDecision{}, shared.Resources(0), false
+	latestTurn := uint(0)
+	for _, forageType := range []shared.ForageType{shared.DeerForageType, shared.FishForageType} {
+		for _, report := range s.gameState.ForagingHistory[forageType] {
+			contribution, ok := report.ParticipantContributions[clientID]
+			if !ok || foraged && report.Turn < latestTurn {
+				continue
+			}
+			decision = shared.ForageDecision{Type: report.ForageType, Contribution: contribution}
+			obtained = s.forageReturn(report, contribution)
+			foraged, latestTurn = true, report.Turn
+		}
+	}
+
+	if foraged && info.DecisionMade.Type != decision.Type ||
+		!resourcesClose(info.DecisionMade.Contribution, decision.Contribution) ||
+		!resourcesClose(info.ResourceObtained, obtained) {
+		return shared.ForageReportFalse
+	}
+	return shared.ForageReportTruthful
+}
+
+// resourcesClose tells if two amounts of resources are equal, up to rounding errors
+func resourcesClose(a shared.Resources, b shared.Resources) bool {
+	return math.Abs(float64(a-b)) <= 1e-6*math.Max(1, math.Abs(float64(b)))
+}
+
+// runForageAudits asks each alive island which of the islands which shared foraging information with it this turn
+// it wants audited. Each audit is paid into the common pool, and its result is given back to the island.
+func (s *SOMASServer) runForageAudits(otherIslandInfo shared.ForagingOfferDict) {
+	s.logf("start runForageAudits")
+	defer s.logf("finish runForageAudits")
+
+	cost := s.gameConfig.IIFOConfig.ForageAuditCost
+	for _, auditor := range shared.TeamIDs {
+		clientInfo, ok := s.gameState.ClientInfos[auditor]
+		if !ok || clientInfo.LifeStatus == shared.Dead {
+			continue
+		}
+		results := map[shared.ClientID]shared.ForageReportVerification{}
+		for _, subject := range s.clientMap[auditor].RequestForageAudits() {
+			if _, audited := results[subject]; audited {
+				continue
+			}
+			info, ok := otherIslandInfo[subject]
+			if !ok || subject == auditor || !clientArrayContains(info.ShareTo, auditor) {
+				s.logf("[IIFO]: %v requested an audit of %v, which did not share foraging information with it", auditor, subject)
+				continue
+			}
+			if cost > 0 {
+				if err := s.takeResources(auditor, cost, fmt.Sprintf("[IIFO]: audit of %v's foraging information", subject)); err != nil {
+					s.logf("[IIFO]: %v cannot afford an audit of %v: %v", auditor, subject, err)
+					continue
+				}
+				s.gameState.CommonPool += cost
+			}
+
+			results[subject] = s.verifyForageShareInfo(subject, info)
+			s.gameState.IIFOForageAudits = append(s.gameState.IIFOForageAudits, shared.ForageAudit{
+				Turn:         s.gameState.Turn,
+				Auditor:      auditor,
+				Subject:      subject,
+				Verification: results[subject],
+				Cost:         cost,
+			})
+		}
+		if len(results) > 0 {
+			s.clientMap[auditor].ReceiveForageAudits(results)
+		}
+	}
 }
 
 func (s *SOMASServer) getPredictions() shared.DisasterPredictionInfoDict {
@@ -113,7 +286,8 @@ func (s *SOMASServer) distributeForageSharing(otherIslandInfo shared.ForagingOff
 				shared.ForageShareInfo{
 					DecisionMade:     foragingInfo.DecisionMade,
 					ResourceObtained: foragingInfo.ResourceObtained,
-					SharedFrom:       islandID})
+					SharedFrom:       islandID,
+					Verification:     foragingInfo.Verification})
 		}
 	}
 	nonDeadClients := getNonDeadClientIDs(s.gameState.ClientInfos)
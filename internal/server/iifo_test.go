@@ -5,6 +5,9 @@ import (
 	"testing"
 
 	"github.com/SOMAS2020/SOMAS2020/internal/common/baseclient"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/disasters"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/foraging"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/gamestate"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
 )
@@ -14,6 +17,16 @@ type mockClientIIFO struct {
 	foragingValues                shared.ForageShareInfo
 	otherIslandInfo               []shared.ForageShareInfo
 	otherIslandDisasterPrediction shared.ReceivedDisasterPredictionsDict
+	auditRequests                 []shared.ClientID
+	auditResults                  map[shared.ClientID]shared.ForageReportVerification
+}
+
+func (c *mockClientIIFO) RequestForageAudits() []shared.ClientID {
+	return c.auditRequests
+}
+
+func (c *mockClientIIFO) ReceiveForageAudits(results map[shared.ClientID]shared.ForageReportVerification) {
+	c.auditResults = results
 }
 
 func (c *mockClientIIFO) MakeForageInfo() shared.ForageShareInfo {
@@ -235,3 +248,349 @@ func TestDistributePredictions(t *testing.T) {
 	}
 
 }
+
+func TestScoreDisasterPredictions(t *testing.T) {
+	prediction := func(id shared.ClientID, turnMade uint, timeLeft uint, confidence float64) shared.ScoredDisasterPrediction {
+		return shared.ScoredDisasterPrediction{
+			Predictor: id,
+			Prediction: shared.DisasterPrediction{
+				CoordinateX: 1,
+				CoordinateY: 2,
+				Magnitude:   3,
+				TimeLeft:    timeLeft,
+				Confidence:  confidence,
+			},
+			TurnMade: turnMade,
+		}
+	}
+	scored := func(p shared.ScoredDisasterPrediction, disasterHappened bool, brierScore, magnitudeError, locationError float64) shared.ScoredDisasterPrediction {
+		p.Resolved = true
+		p.TurnResolved = 3
+		p.DisasterHappened = disasterHappened
+		p.BrierScore = brierScore
+		p.MagnitudeError = magnitudeError
+		p.LocationError = locationError
+		return p
+	}
+	alreadyScored := scored(prediction(shared.Team1, 1, 0, 100), false, 1, 0, 0)
+	alreadyScored.TurnResolved = 1
+
+	cases := []struct {
+		name             string
+		disasterHappened bool
+		predictions      []shared.ScoredDisasterPrediction
+		reward           shared.Resources
+		commonPool       shared.Resources
+		wantPredictions  []shared.ScoredDisasterPrediction
+		wantRewards      []shared.PredictionReward
+		wantCommonPool   shared.Resources
+	}{
+		{
+			name: "no disaster resolves predicted turns passed",
+			predictions: []shared.ScoredDisasterPrediction{
+				alreadyScored,
+				prediction(shared.Team1, 2, 1, 75),
+				prediction(shared.Team2, 2, 3, 50),
+				prediction(shared.Team3, 1, 1, 25),
+			},
+			reward:     10,
+			commonPool: 100,
+			wantPredictions: []shared.ScoredDisasterPrediction{
+				alreadyScored,
+				scored(prediction(shared.Team1, 2, 1, 75), false, 0.5625, 0, 0),
+				prediction(shared.Team2, 2, 3, 50),
+				scored(prediction(shared.Team3, 1, 1, 25), false, 0.0625, 0, 0),
+			},
+			wantRewards:    []shared.PredictionReward{{Turn: 3, Forecaster: shared.Team3, MeanBrierScore: 0.0625, Amount: 10}},
+			wantCommonPool: 90,
+		},
+		{
+			name:             "disaster resolves all pending predictions",
+			disasterHappened: true,
+			predictions: []shared.ScoredDisasterPrediction{
+				prediction(shared.Team1, 2, 1, 75),
+				prediction(shared.Team2, 2, 3, 50),
+				prediction(shared.Team2, 3, 0, 100),
+			},
+			reward:     10,
+			commonPool: 100,
+			wantPredictions: []shared.ScoredDisasterPrediction{
+				scored(prediction(shared.Team1, 2, 1, 75), true, 0.0625, 2, 5),
+				scored(prediction(shared.Team2, 2, 3, 50), true, 0.25, 2, 5),
+				scored(prediction(shared.Team2, 3, 0, 100), true, 0, 2, 5),
+			},
+			wantRewards:    []shared.PredictionReward{{Turn: 3, Forecaster: shared.Team1, MeanBrierScore: 0.0625, Amount: 10}},
+			wantCommonPool: 90,
+		},
+		{
+			name: "no reward configured",
+			predictions: []shared.ScoredDisasterPrediction{
+				prediction(shared.Team1, 2, 1, 75),
+			},
+			commonPool: 100,
+			wantPredictions: []shared.ScoredDisasterPrediction{
+				scored(prediction(shared.Team1, 2, 1, 75), false, 0.5625, 0, 0),
+			},
+			wantCommonPool: 100,
+		},
+		{
+			name: "common pool cannot afford reward",
+			predictions: []shared.ScoredDisasterPrediction{
+				prediction(shared.Team1, 2, 1, 75),
+			},
+			reward:     10,
+			commonPool: 5,
+			wantPredictions: []shared.ScoredDisasterPrediction{
+				scored(prediction(shared.Team1, 2, 1, 75), false, 0.5625, 0, 0),
+			},
+			wantCommonPool: 5,
+		},
+		{
+			name: "dead islands are not rewarded",
+			predictions: []shared.ScoredDisasterPrediction{
+				prediction(shared.Team4, 2, 1, 0),
+				prediction(shared.Team1, 2, 1, 75),
+			},
+			reward:     10,
+			commonPool: 100,
+			wantPredictions: []shared.ScoredDisasterPrediction{
+				scored(prediction(shared.Team4, 2, 1, 0), false, 0, 0, 0),
+				scored(prediction(shared.Team1, 2, 1, 75), false, 0.5625, 0, 0),
+			},
+			wantRewards:    []shared.PredictionReward{{Turn: 3, Forecaster: shared.Team1, MeanBrierScore: 0.5625, Amount: 10}},
+			wantCommonPool: 90,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			report := disasters.DisasterReport{}
+			if tc.disasterHappened {
+				report = disasters.DisasterReport{Magnitude: 5, X: 4, Y: 6}
+			}
+			s := &SOMASServer{
+				gameState: gamestate.GameState{
+					Turn:       3,
+					CommonPool: tc.commonPool,
+					ClientInfos: map[shared.ClientID]gamestate.ClientInfo{
+						shared.Team1: {LifeStatus: shared.Alive},
+						shared.Team2: {LifeStatus: shared.Alive},
+						shared.Team3: {LifeStatus: shared.Critical},
+						shared.Team4: {LifeStatus: shared.Dead},
+					},
+					Environment:     disasters.Environment{LastDisasterReport: report},
+					IIFOPredictions: tc.predictions,
+				},
+				gameConfig: config.Config{
+					IIFOConfig: config.IIFOConfig{PredictionReward: tc.reward},
+				},
+			}
+
+			s.scoreDisasterPredictions(tc.disasterHappened)
+
+			if !reflect.DeepEqual(tc.wantPredictions, s.gameState.IIFOPredictions) {
+				t.Errorf("predictions: want '%#v' got '%#v'", tc.wantPredictions, s.gameState.IIFOPredictions)
+			}
+			if !reflect.DeepEqual(tc.wantRewards, s.gameState.IIFOPredictionRewards) {
+				t.Errorf("rewards: want '%#v' got '%#v'", tc.wantRewards, s.gameState.IIFOPredictionRewards)
+			}
+			if tc.wantCommonPool != s.gameState.CommonPool {
+				t.Errorf("common pool: want %v got %v", tc.wantCommonPool, s.gameState.CommonPool)
+			}
+		})
+	}
+}
+
+// makeForagingHistory is the foraging history of turn 3: islands 1 and 2 hunted deer and island 3 fished last turn
+func makeForagingHistory() map[shared.ForageType][]foraging.ForagingReport {
+	history := map[shared.ForageType][]foraging.ForagingReport{
+		shared.DeerForageType: {
+			{ForageType: shared.DeerForageType, ParticipantContributions: map[shared.ClientID]shared.Resources{shared.Team3: 7, shared.Team5: 7}, TotalUtility: 18, Turn: 1},
+			{ForageType: shared.DeerForageType, ParticipantContributions: map[shared.ClientID]shared.Resources{shared.Team1: 10, shared.Team2: 5}, TotalUtility: 30, Turn: 2},
+		},
+		shared.FishForageType: {
+			{ForageType: shared.FishForageType, ParticipantContributions: map[shared.ClientID]shared.Resources{shared.Team3: 4}, TotalUtility: 6, Turn: 2},
+		},
+	}
+	return history
+}
+
+// forageSplitConfig splits foraging returns in proportion to the contributions, as in the foraging history above
+var forageSplitConfig = config.ForagingConfig{
+	DeerHuntConfig: config.DeerHuntConfig{DistributionStrategy: shared.InputProportionalSplit},
+	FishingConfig:  config.FishingConfig{DistributionStrategy: shared.InputProportionalSplit},
+}
+
+func TestVerifyForageShareInfo(t *testing.T) {
+	fishingInfo := func(contribution shared.Resources, resources shared.Resources) shared.ForageShareInfo {
+		return shared.ForageShareInfo{
+			DecisionMade:     shared.ForageDecision{Type: shared.FishForageType, Contribution: contribution},
+			ResourceObtained: resources,
+		}
+	}
+
+	cases := []struct {
+		name     string
+		clientID shared.ClientID
+		info     shared.ForageShareInfo
+		want     shared.ForageReportVerification
+	}{
+		{
+			name:     "truthful deer hunt",
+			clientID: shared.Team1,
+			info:     makeForagingInfo(10, 20, nil),
+			want:     shared.ForageReportTruthful,
+		},
+		{
+			name:     "rounding errors",
+			clientID: shared.Team1,
+			info:     makeForagingInfo(10, 20.0000000001, nil),
+			want:     shared.ForageReportTruthful,
+		},
+		{
+			name:     "false return",
+			clientID: shared.Team1,
+			info:     makeForagingInfo(10, 40, nil),
+			want:     shared.ForageReportFalse,
+		},
+		{
+			name:     "false contribution",
+			clientID: shared.Team2,
+			info:     makeForagingInfo(8, 10, nil),
+			want:     shared.ForageReportFalse,
+		},
+		{
+			name:     "truthful fishing",
+			clientID: shared.Team3,
+			info:     fishingInfo(4, 6),
+			want:     shared.ForageReportTruthful,
+		},
+		{
+			name:     "false foraging type",
+			clientID: shared.Team3,
+			info:     makeForagingInfo(4, 6, nil),
+			want:     shared.ForageReportFalse,
+		},
+		{
+			name:     "truthfully did not forage",
+			clientID: shared.Team4,
+			info:     makeForagingInfo(0, 0, nil),
+			want:     shared.ForageReportTruthful,
+		},
+		{
+			name:     "truthful older deer hunt",
+			clientID: shared.Team5,
+			info:     makeForagingInfo(7, 9, nil),
+			want:     shared.ForageReportTruthful,
+		},
+		{
+			name:     "did not forage last turn",
+			clientID: shared.Team5,
+			info:     makeForagingInfo(0, 0, nil),
+			want:     shared.ForageReportFalse,
+		},
+		{
+			name:     "falsely foraged",
+			clientID: shared.Team4,
+			info:     makeForagingInfo(5, 12, nil),
+			want:     shared.ForageReportFalse,
+		},
+	}
+
+	server := &SOMASServer{
+		gameState: gamestate.GameState{
+			Turn:            3,
+			ForagingHistory: makeForagingHistory(),
+		},
+		gameConfig: config.Config{ForagingConfig: forageSplitConfig},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := server.verifyForageShareInfo(tc.clientID, tc.info)
+			if got != tc.want {
+				t.Errorf("want %v got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestRunForageSharingVerificationAndAudits(t *testing.T) {
+	mockClient := map[shared.ClientID]*mockClientIIFO{
+		shared.Team1: {
+			foragingValues: makeForagingInfo(10, 20, []shared.ClientID{shared.Team2, shared.Team3}),
+			// Team 3 did not share with Team 1, and Team 2 is only audited once
+			auditRequests: []shared.ClientID{shared.Team2, shared.Team2, shared.Team3},
+		},
+		shared.Team2: {
+			foragingValues: makeForagingInfo(5, 50, []shared.ClientID{shared.Team1}),
+			// Team 2 cannot afford an audit
+			auditRequests: []shared.ClientID{shared.Team1},
+		},
+		shared.Team3: {
+			auditRequests: []shared.ClientID{shared.Team1},
+		},
+	}
+	clientMap := map[shared.ClientID]baseclient.Client{}
+	for id, c := range mockClient {
+		clientMap[id] = c
+	}
+
+	server := &SOMASServer{
+		gameState: gamestate.GameState{
+			Turn: 3,
+			ClientInfos: map[shared.ClientID]gamestate.ClientInfo{
+				shared.Team1: {LifeStatus: shared.Alive, Resources: 100},
+				shared.Team2: {LifeStatus: shared.Alive, Resources: 3},
+				shared.Team3: {LifeStatus: shared.Critical, Resources: 100},
+			},
+			ForagingHistory: makeForagingHistory(),
+		},
+		gameConfig: config.Config{
+			ForagingConfig: forageSplitConfig,
+			IIFOConfig:     config.IIFOConfig{VerifyForageSharing: true, ForageAuditCost: 5},
+		},
+		clientMap: clientMap,
+	}
+
+	server.runForageSharing()
+
+	truthful := receiveForagingInfo(10, 20, shared.Team1)
+	truthful.Verification = shared.ForageReportTruthful
+	falseInfo := receiveForagingInfo(5, 50, shared.Team2)
+	falseInfo.Verification = shared.ForageReportFalse
+	wantReceived := map[shared.ClientID][]shared.ForageShareInfo{
+		shared.Team1: {falseInfo},
+		shared.Team2: {truthful},
+		shared.Team3: {truthful},
+	}
+	wantResults := map[shared.ClientID]map[shared.ClientID]shared.ForageReportVerification{
+		shared.Team1: {shared.Team2: shared.ForageReportFalse},
+		shared.Team3: {shared.Team1: shared.ForageReportTruthful},
+	}
+	for id, c := range mockClient {
+		if !reflect.DeepEqual(wantReceived[id], c.getOtherIslandInfo()) {
+			t.Errorf("%v: want received '%#v' got '%#v'", id, wantReceived[id], c.getOtherIslandInfo())
+		}
+		if !reflect.DeepEqual(wantResults[id], c.auditResults) {
+			t.Errorf("%v: want audit results '%v' got '%v'", id, wantResults[id], c.auditResults)
+		}
+	}
+
+	wantAudits := []shared.ForageAudit{
+		{Turn: 3, Auditor: shared.Team1, Subject: shared.Team2, Verification: shared.ForageReportFalse, Cost: 5},
+		{Turn: 3, Auditor: shared.Team3, Subject: shared.Team1, Verification: shared.ForageReportTruthful, Cost: 5},
+	}
+	if !reflect.DeepEqual(wantAudits, server.gameState.IIFOForageAudits) {
+		t.Errorf("want audits '%v' got '%v'", wantAudits, server.gameState.IIFOForageAudits)
+	}
+	wantResources := map[shared.ClientID]shared.Resources{shared.Team1: 95, shared.Team2: 3, shared.Team3: 95}
+	for id, want := range wantResources {
+		if got := server.gameState.ClientInfos[id].Resources; got != want {
+			t.Errorf("%v: want resources %v got %v", id, want, got)
+		}
+	}
+	if server.gameState.CommonPool != 10 {
+		t.Errorf("want common pool 10 got %v", server.gameState.CommonPool)
+	}
+}
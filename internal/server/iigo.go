@@ -6,6 +6,7 @@ import (
 	"github.com/SOMAS2020/SOMAS2020/internal/common/gamestate"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/rules"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/voting"
 	"github.com/SOMAS2020/SOMAS2020/internal/server/iigointernal"
 	"github.com/pkg/errors"
 )
@@ -38,6 +39,7 @@ func (s *SOMASServer) runIIGOTax() error {
 	s.logf("start runIIGOTaxCommonPool")
 	defer s.logf("finish runIIGOTaxCommonPool")
 	clientMap := getNonDeadClients(s.gameState.ClientInfos, s.clientMap)
+	taxPaidMap := make(map[shared.ClientID]shared.Resources)
 	for clientID, v := range clientMap {
 		var taxPaid shared.Resources
 		var sanctionPaid shared.Resources
@@ -51,6 +53,7 @@ func (s *SOMASServer) runIIGOTax() error {
 			s.gameState.CommonPool += tax
 			taxPaid = tax
 		}
+		taxPaidMap[clientID] = taxPaid
 		clientSanctionErr := s.takeResources(clientID, sanction, "sanction")
 		if clientSanctionErr != nil {
 			s.logf("Error getting sanctions from %v: %v ", clientID, clientSanctionErr)
@@ -80,11 +83,60 @@ func (s *SOMASServer) runIIGOTax() error {
 				Values:       []float64{float64(s.gameState.IIGOSanctionMap[clientID])},
 			},
 		})
+		record := s.reputationRecord(clientID)
+		record.ActualContribution = taxPaid
+		record.SanctionDue = s.gameState.IIGOSanctionMap[clientID]
+		record.SanctionPaid = sanctionPaid
+		if record.ContributionAnnounced {
+			record.ContributionGap = record.IntendedContribution - taxPaid
+			s.updateIIGOHistoryAndRules(clientID, []rules.VariableValuePair{
+				{
+					VariableName: rules.IntendedContributionGap,
+					Values:       []float64{float64(record.ContributionGap)},
+				},
+			})
+		}
 
 	}
+	s.updateContributionHonestyScores()
+	s.runIIGOCollectiveSanctions(taxPaidMap)
 	return nil
 }
 
+// runIIGOCollectiveSanctions evaluates the collective sanction rules in play against every island that paid
+// tax this turn and charges the resulting surcharges into the common pool
+func (s *SOMASServer) runIIGOCollectiveSanctions(taxPaidMap map[shared.ClientID]shared.Resources) {
+	islandVariables := make(map[shared.ClientID]map[rules.VariableFieldName]rules.VariableValuePair, len(taxPaidMap))
+	for clientID, taxPaid := range taxPaidMap {
+		islandVariables[clientID] = map[rules.VariableFieldName]rules.VariableValuePair{
+			rules.CommonPoolResources:         rules.MakeVariableValuePair(rules.CommonPoolResources, []float64{float64(s.gameState.CommonPool)}),
+			rules.CommonPoolDisasterThreshold: rules.MakeVariableValuePair(rules.CommonPoolDisasterThreshold, []float64{float64(s.gameConfig.DisasterConfig.CommonpoolThreshold)}),
+			rules.IslandTaxPaid:               rules.MakeVariableValuePair(rules.IslandTaxPaid, []float64{float64(taxPaid)}),
+			rules.IslandTaxShare:              rules.MakeVariableValuePair(rules.IslandTaxShare, []float64{float64(s.gameState.IIGOTaxAmount[clientID])}),
+		}
+	}
+	surcharges := iigointernal.EvaluateCollectiveSanctions(
+		s.gameConfig.IIGOConfig.CollectiveSanctionRules,
+		s.gameState.RulesInfo.CurrentRulesInPlay,
+		islandVariables,
+	)
+
+	collectiveSanctions := make(map[shared.ClientID]shared.Resources, len(surcharges))
+	for clientID, surcharge := range surcharges {
+		err := s.takeResources(clientID, surcharge, "collective sanction")
+		if err != nil {
+			s.logf("Error getting collective sanction from %v: %v", clientID, err)
+			continue
+		}
+		s.gameState.CommonPool += surcharge
+		collectiveSanctions[clientID] = surcharge
+	}
+	if len(collectiveSanctions) > 0 {
+		s.logf("Collective sanctions charged: %v", collectiveSanctions)
+	}
+	s.gameState.IIGOCollectiveSanctions = collectiveSanctions
+}
+
 func (s *SOMASServer) runIIGOAllocations() error {
 	s.logf("start runIIGOAllocations")
 	defer s.logf("finish runIIGOAllocations")
@@ -96,7 +148,17 @@ func (s *SOMASServer) runIIGOAllocations() error {
 			s.logf("Invalid allocation of %v by %v. Changing allocation to 0", allocation, clientID)
 			allocation = 0
 		}
-		if allocation <= s.gameState.CommonPool {
+		if allocation > 0 && voting.HasSanctionConsequence(clientID, shared.ExcludedFromAllocation, &s.gameState, &s.gameConfig.IIGOConfig) {
+			s.logf("%v is excluded from common pool allocations by its sanction. Changing allocation to 0", clientID)
+			allocation = 0
+		}
+		// Allocations rejected by the Auditor are withheld
+		if approved, audited := s.gameState.IIGOAllocationAudit[clientID]; audited && !approved && allocation > 0 {
+			s.logf("The Auditor rejected the allocation of %v. Changing allocation to 0", clientID)
+			allocation = 0
+		}
+		// The Treasurer's reserve cannot be allocated
+		if allocation <= s.gameState.CommonPool-s.gameState.IIGOCommonPoolReserve {
 			err := s.giveResources(clientID, allocation, "allocation")
 			if err != nil {
 				return errors.Errorf("Failed to give resources: %v", err)
@@ -104,6 +166,10 @@ func (s *SOMASServer) runIIGOAllocations() error {
 			s.gameState.CommonPool -= allocation
 
 			if s.gameState.IIGOAllocationMade {
+				expectedAllocation := s.gameState.IIGOAllocationMap[clientID]
+				if approved, audited := s.gameState.IIGOAllocationAudit[clientID]; audited && !approved {
+					expectedAllocation = 0
+				}
 				s.updateIIGOHistoryAndRules(clientID, []rules.VariableValuePair{
 					{
 						VariableName: rules.IslandAllocation,
@@ -111,7 +177,7 @@ func (s *SOMASServer) runIIGOAllocations() error {
 					},
 					{
 						VariableName: rules.ExpectedAllocation,
-						Values:       []float64{float64(s.gameState.IIGOAllocationMap[clientID])},
+						Values:       []float64{float64(expectedAllocation)},
 					},
 				})
 			} else {
@@ -0,0 +1,186 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/baseclient"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/gamestate"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/rules"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+func TestRunIIGOCollectiveSanctions(t *testing.T) {
+	availableRules, _ := rules.InitialRuleRegistration(false)
+	freeRiderRulesInPlay := map[string]rules.RuleMatrix{
+		"collective_free_rider_surcharge": availableRules["collective_free_rider_surcharge"],
+	}
+	freeRiderRule := []string{"collective_free_rider_surcharge"}
+	taxAmount := map[shared.ClientID]shared.Resources{
+		shared.Team1: 20,
+		shared.Team2: 20,
+		shared.Team3: 20,
+	}
+	taxPaid := map[shared.ClientID]shared.Resources{
+		shared.Team1: 20,
+		shared.Team2: 5,
+		shared.Team3: 0,
+	}
+
+	cases := []struct {
+		name            string
+		commonPool      shared.Resources
+		rulesInPlay     map[string]rules.RuleMatrix
+		collectiveRules []string
+		resources       shared.Resources
+		want            map[shared.ClientID]shared.Resources
+		wantCommonPool  shared.Resources
+	}{
+		{
+			name:            "Common pool below threshold surcharges free riders",
+			commonPool:      25,
+			rulesInPlay:     freeRiderRulesInPlay,
+			collectiveRules: freeRiderRule,
+			resources:       100,
+			want: map[shared.ClientID]shared.Resources{
+				shared.Team2: 15,
+				shared.Team3: 20,
+			},
+			wantCommonPool: 60,
+		},
+		{
+			name:            "Common pool above threshold",
+			commonPool:      200,
+			rulesInPlay:     freeRiderRulesInPlay,
+			collectiveRules: freeRiderRule,
+			resources:       100,
+			want:            map[shared.ClientID]shared.Resources{},
+			wantCommonPool:  200,
+		},
+		{
+			name:            "Collective rule not in play",
+			commonPool:      25,
+			rulesInPlay:     map[string]rules.RuleMatrix{},
+			collectiveRules: freeRiderRule,
+			resources:       100,
+			want:            map[shared.ClientID]shared.Resources{},
+			wantCommonPool:  25,
+		},
+		{
+			name:            "Rule in play but not configured as collective",
+			commonPool:      25,
+			rulesInPlay:     freeRiderRulesInPlay,
+			collectiveRules: nil,
+			resources:       100,
+			want:            map[shared.ClientID]shared.Resources{},
+			wantCommonPool:  25,
+		},
+		{
+			name:            "Islands unable to pay are not charged",
+			commonPool:      25,
+			rulesInPlay:     freeRiderRulesInPlay,
+			collectiveRules: freeRiderRule,
+			resources:       10,
+			want:            map[shared.ClientID]shared.Resources{},
+			wantCommonPool:  25,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			clientInfos := map[shared.ClientID]gamestate.ClientInfo{}
+			for clientID := range taxPaid {
+				clientInfos[clientID] = gamestate.ClientInfo{Resources: tc.resources, LifeStatus: shared.Alive}
+			}
+			s := &SOMASServer{
+				gameState: gamestate.GameState{
+					ClientInfos:   clientInfos,
+					CommonPool:    tc.commonPool,
+					IIGOTaxAmount: taxAmount,
+					RulesInfo: gamestate.RulesContext{
+						CurrentRulesInPlay: tc.rulesInPlay,
+					},
+				},
+				gameConfig: config.Config{
+					DisasterConfig: config.DisasterConfig{
+						CommonpoolThreshold: 50,
+					},
+					IIGOConfig: config.IIGOConfig{
+						CollectiveSanctionRules: tc.collectiveRules,
+					},
+				},
+			}
+
+			s.runIIGOCollectiveSanctions(taxPaid)
+
+			if !reflect.DeepEqual(tc.want, s.gameState.IIGOCollectiveSanctions) {
+				t.Errorf("want collective sanctions '%v' got '%v'", tc.want, s.gameState.IIGOCollectiveSanctions)
+			}
+			if tc.wantCommonPool != s.gameState.CommonPool {
+				t.Errorf("want common pool %v got %v", tc.wantCommonPool, s.gameState.CommonPool)
+			}
+			for clientID, surcharge := range tc.want {
+				if got := s.gameState.ClientInfos[clientID].Resources; got != tc.resources-surcharge {
+					t.Errorf("want %v resources for %v got %v", tc.resources-surcharge, clientID, got)
+				}
+			}
+		})
+	}
+}
+
+type mockClientAllocation struct {
+	baseclient.Client
+	request shared.Resources
+}
+
+func (c *mockClientAllocation) RequestAllocation() shared.Resources {
+	return c.request
+}
+
+func TestRunIIGOAllocationsWithholdsRejectedAllocations(t *testing.T) {
+	s := &SOMASServer{
+		gameState: gamestate.GameState{
+			Turn:       2,
+			CommonPool: 100,
+			ClientInfos: map[shared.ClientID]gamestate.ClientInfo{
+				shared.Team1: {LifeStatus: shared.Alive, Resources: 10},
+				shared.Team2: {LifeStatus: shared.Alive, Resources: 10},
+			},
+			IIGOHistory:         map[uint][]shared.Accountability{},
+			IIGOAllocationMade:  true,
+			IIGOAllocationMap:   map[shared.ClientID]shared.Resources{shared.Team1: 20, shared.Team2: 30},
+			IIGOAllocationAudit: map[shared.ClientID]bool{shared.Team1: true, shared.Team2: false},
+		},
+		clientMap: map[shared.ClientID]baseclient.Client{
+			shared.Team1: &mockClientAllocation{request: 20},
+			shared.Team2: &mockClientAllocation{request: 30},
+		},
+	}
+
+	if err := s.runIIGOAllocations(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantResources := map[shared.ClientID]shared.Resources{shared.Team1: 30, shared.Team2: 10}
+	for clientID, want := range wantResources {
+		if got := s.gameState.ClientInfos[clientID].Resources; got != want {
+			t.Errorf("want %v resources for %v got %v", want, clientID, got)
+		}
+	}
+	if s.gameState.CommonPool != 80 {
+		t.Errorf("want common pool 80 got %v", s.gameState.CommonPool)
+	}
+	for _, accountability := range s.gameState.IIGOHistory[2] {
+		if accountability.ClientID != shared.Team2 {
+			continue
+		}
+		want := []rules.VariableValuePair{
+			{VariableName: rules.IslandAllocation, Values: []float64{0}},
+			{VariableName: rules.ExpectedAllocation, Values: []float64{0}},
+		}
+		if !reflect.DeepEqual(want, accountability.Pairs) {
+			t.Errorf("want Team2 allocation variables '%v' got '%v'", want, accountability.Pairs)
+		}
+	}
+}
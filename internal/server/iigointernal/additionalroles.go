@@ -0,0 +1,134 @@
+package iigointernal
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/baseclient"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/gamestate"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/rules"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/voting"
+	"github.com/pkg/errors"
+)
+
+// additionalRoleVotingMethod is the voting method used by all elections for additional roles
+const additionalRoleVotingMethod = shared.Runoff
+
+// additionalRole is an IIGO role enabled in the config on top of the President, Speaker and Judge
+type additionalRole struct {
+	gameState   *gamestate.GameState
+	gameConf    *config.IIGOConfig
+	Role        shared.Role
+	HolderID    shared.ClientID
+	iigoClients map[shared.ClientID]baseclient.Client
+	monitoring  *monitor
+	logger      shared.Logger
+}
+
+func (a *additionalRole) Logf(format string, b ...interface{}) {
+	a.logger("[%v]: %v", strings.ToUpper(a.Role.String()), fmt.Sprintf(format, b...))
+}
+
+func (a *additionalRole) roleConfig() config.IIGORoleConfig {
+	return a.gameConf.AdditionalRoles[a.Role]
+}
+
+// incur charges in both budget and commonpool for performing an actions
+// only return false if we can't withdraw from common pool
+func (a *additionalRole) incurServiceCharge(cost shared.Resources) bool {
+	_, ok := WithdrawFromCommonPool(cost, a.gameState)
+	if ok {
+		a.gameState.IIGORolesBudget[a.Role] -= cost
+	}
+	return ok
+}
+
+// setCommonPoolReserve asks the Treasurer how much of the common pool the President may not allocate this turn
+func (a *additionalRole) setCommonPoolReserve() error {
+	a.gameState.IIGOCommonPoolReserve = 0
+	if !a.incurServiceCharge(a.roleConfig().ActionCost) {
+		return errors.Errorf("Insufficient Budget in common Pool: setCommonPoolReserve")
+	}
+	reserve, set := a.iigoClients[a.HolderID].GetClientTreasurerPointer().SetCommonPoolReserve(a.gameState.CommonPool)
+	if !set {
+		return nil
+	}
+	if reserve < 0 {
+		reserve = 0
+	}
+	if reserve > a.gameState.CommonPool {
+		reserve = a.gameState.CommonPool
+	}
+	a.gameState.IIGOCommonPoolReserve = reserve
+	a.Logf("Common pool reserve set to %v", reserve)
+	return nil
+}
+
+// auditAllocations asks the Auditor to check the allocations decided by the President against the requests.
+// Allocations rejected by the Auditor are withheld at the end of the turn, and the audit is visible to every island.
+func (a *additionalRole) auditAllocations(requests map[shared.ClientID]shared.Resources, allocationsMade bool) error {
+	a.gameState.IIGOAllocationAudit = map[shared.ClientID]bool{}
+	performed := false
+	if allocationsMade {
+		if !a.incurServiceCharge(a.roleConfig().ActionCost) {
+			return errors.Errorf("Insufficient Budget in common Pool: auditAllocations")
+		}
+		requestsCopy := map[shared.ClientID]shared.Resources{}
+		for island, request := range requests {
+			requestsCopy[island] = request
+		}
+		allocationsCopy := map[shared.ClientID]shared.Resources{}
+		for island, allocation := range a.gameState.IIGOAllocationMap {
+			allocationsCopy[island] = allocation
+		}
+		var audit map[shared.ClientID]bool
+		audit, performed = a.iigoClients[a.HolderID].GetClientAuditorPointer().AuditAllocations(requestsCopy, allocationsCopy)
+		if performed {
+			for island := range a.gameState.IIGOAllocationMap {
+				if approved, ok := audit[island]; ok {
+					a.gameState.IIGOAllocationAudit[island] = approved
+				}
+			}
+		}
+	}
+
+	//Log rule: Auditor must audit allocations
+	variablesToCache := []rules.VariableFieldName{rules.AllocationAuditRequired, rules.AllocationAuditPerformed}
+	valuesToCache := [][]float64{{boolToFloat(allocationsMade)}, {boolToFloat(performed)}}
+	a.monitoring.addToCache(a.HolderID, variablesToCache, valuesToCache)
+	return nil
+}
+
+// appointNextHolder holds an election for the role once its term has ended or its monitoring failed.
+// The election is run and paid for by the appointing role and the elected island is appointed directly.
+func (a *additionalRole) appointNextHolder(monitoring shared.MonitorResult, runner electionRunner, allIslands []shared.ClientID) (shared.ClientID, error) {
+	var election = voting.Election{
+		Logger: a.logger,
+	}
+	termCondition := a.gameState.IIGOTurnsInPower[a.Role] > a.gameConf.IIGOTermLengths[a.Role]
+	holdElection := termCondition || (monitoring.Performed && !monitoring.Result)
+
+	//Log election rule
+	variablesToCache := []rules.VariableFieldName{rules.TermEnded, rules.ElectionHeld}
+	valuesToCache := [][]float64{{boolToFloat(termCondition)}, {boolToFloat(holdElection)}}
+	a.monitoring.addToCache(runner.ID, variablesToCache, valuesToCache)
+
+	appointed := a.HolderID
+	if holdElection {
+		if !runner.charge(appointActionCost(a.gameConf, a.Role)) {
+			return a.HolderID, errors.Errorf("Insufficient Budget in common Pool: appointNext%v", a.Role)
+		}
+		election.ProposeElection(a.Role, additionalRoleVotingMethod)
+		election.SetEligibility(a.gameState, a.gameConf)
+		election.SetDelegations(voteDelegations(a.gameState, shared.ElectionVotes))
+		election.OpenBallot(copyClientList(allIslands), copyClientList(allIslands))
+		election.Vote(a.iigoClients)
+		a.gameState.IIGOTurnsInPower[a.Role] = 0
+		appointed = election.CloseBallot(a.iigoClients)
+		a.Logf("Result of election for new %v: %v", a.Role, appointed)
+	}
+	recordElection(a.gameState, a.gameConf, election.GetVotingInfo(), appointed, runner.ID)
+	return appointed, nil
+}
@@ -0,0 +1,285 @@
+package iigointernal
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/baseclient"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/gamestate"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/roles"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/rules"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+type mockTreasurer struct {
+	reserve shared.Resources
+	set     bool
+}
+
+func (t *mockTreasurer) SetCommonPoolReserve(commonPool shared.Resources) (shared.Resources, bool) {
+	return t.reserve, t.set
+}
+
+type mockAdditionalRoleClient struct {
+	*baseclient.BaseClient
+	treasurer mockTreasurer
+}
+
+func (c *mockAdditionalRoleClient) GetClientTreasurerPointer() roles.Treasurer {
+	return &c.treasurer
+}
+
+func (c *mockAdditionalRoleClient) GetClientAuditorPointer() roles.Auditor {
+	return &baseclient.BaseAuditor{}
+}
+
+// VoteForElection ranks the candidates in the order they are offered
+func (c *mockAdditionalRoleClient) VoteForElection(roleToElect shared.Role, candidateList []shared.ClientID) []shared.ClientID {
+	return candidateList
+}
+
+func newAdditionalRoleTestState(commonPool shared.Resources) *gamestate.GameState {
+	return &gamestate.GameState{
+		CommonPool:  commonPool,
+		PresidentID: shared.Team1,
+		SpeakerID:   shared.Team2,
+		JudgeID:     shared.Team3,
+		IIGOAdditionalRoleIDs: map[shared.Role]shared.ClientID{
+			shared.Treasurer: shared.Team4,
+			shared.Auditor:   shared.Team4,
+		},
+		IIGORolesBudget:  map[shared.Role]shared.Resources{},
+		IIGOTurnsInPower: map[shared.Role]uint{},
+	}
+}
+
+func newAdditionalRoleTestConfig() *config.IIGOConfig {
+	return &config.IIGOConfig{
+		IIGOTermLengths: map[shared.Role]uint{shared.Treasurer: 2, shared.Auditor: 2},
+		AdditionalRoles: map[shared.Role]config.IIGORoleConfig{
+			shared.Treasurer: {AppointedBy: shared.President, ActionCost: 2, AppointActionCost: 5},
+			shared.Auditor:   {AppointedBy: shared.Judge, ActionCost: 2, AppointActionCost: 5},
+		},
+	}
+}
+
+func TestSetCommonPoolReserve(t *testing.T) {
+	cases := []struct {
+		name            string
+		treasurer       mockTreasurer
+		commonPool      shared.Resources
+		expectedReserve shared.Resources
+		expectedError   bool
+	}{
+		{
+			name:            "Reserve set",
+			treasurer:       mockTreasurer{reserve: 30, set: true},
+			commonPool:      100,
+			expectedReserve: 30,
+		},
+		{
+			name:            "No reserve set",
+			treasurer:       mockTreasurer{reserve: 30, set: false},
+			commonPool:      100,
+			expectedReserve: 0,
+		},
+		{
+			name:            "Reserve capped to common pool",
+			treasurer:       mockTreasurer{reserve: 500, set: true},
+			commonPool:      100,
+			expectedReserve: 98,
+		},
+		{
+			name:            "Negative reserve ignored",
+			treasurer:       mockTreasurer{reserve: -10, set: true},
+			commonPool:      100,
+			expectedReserve: 0,
+		},
+		{
+			name:            "Insufficient budget",
+			treasurer:       mockTreasurer{reserve: 1, set: true},
+			commonPool:      1,
+			expectedReserve: 0,
+			expectedError:   true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeGameState := newAdditionalRoleTestState(tc.commonPool)
+			treasurer := additionalRole{
+				gameState: fakeGameState,
+				gameConf:  newAdditionalRoleTestConfig(),
+				Role:      shared.Treasurer,
+				HolderID:  shared.Team4,
+				iigoClients: map[shared.ClientID]baseclient.Client{
+					shared.Team4: &mockAdditionalRoleClient{BaseClient: baseclient.NewClient(shared.Team4), treasurer: tc.treasurer},
+				},
+				logger: func(format string, a ...interface{}) {},
+			}
+			err := treasurer.setCommonPoolReserve()
+			if (err != nil) != tc.expectedError {
+				t.Errorf("Expected error %v got %v", tc.expectedError, err)
+			}
+			if fakeGameState.IIGOCommonPoolReserve != tc.expectedReserve {
+				t.Errorf("Expected reserve %v got %v", tc.expectedReserve, fakeGameState.IIGOCommonPoolReserve)
+			}
+		})
+	}
+}
+
+func TestAuditAllocations(t *testing.T) {
+	cases := []struct {
+		name            string
+		requests        map[shared.ClientID]shared.Resources
+		allocations     map[shared.ClientID]shared.Resources
+		allocationsMade bool
+		commonPool      shared.Resources
+		expectedAudit   map[shared.ClientID]bool
+		expectedCache   []float64
+		expectedError   bool
+	}{
+		{
+			name:            "Allocation above request rejected",
+			requests:        map[shared.ClientID]shared.Resources{shared.Team1: 10, shared.Team2: 10},
+			allocations:     map[shared.ClientID]shared.Resources{shared.Team1: 10, shared.Team2: 20},
+			allocationsMade: true,
+			commonPool:      100,
+			expectedAudit:   map[shared.ClientID]bool{shared.Team1: true, shared.Team2: false},
+			expectedCache:   []float64{1, 1},
+		},
+		{
+			name:            "No allocations to audit",
+			requests:        map[shared.ClientID]shared.Resources{shared.Team1: 10},
+			allocations:     map[shared.ClientID]shared.Resources{shared.Team1: 10},
+			allocationsMade: false,
+			commonPool:      100,
+			expectedAudit:   map[shared.ClientID]bool{},
+			expectedCache:   []float64{0, 0},
+		},
+		{
+			name:            "Insufficient budget",
+			requests:        map[shared.ClientID]shared.Resources{shared.Team1: 10},
+			allocations:     map[shared.ClientID]shared.Resources{shared.Team1: 20},
+			allocationsMade: true,
+			commonPool:      1,
+			expectedAudit:   map[shared.ClientID]bool{},
+			expectedError:   true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeGameState := newAdditionalRoleTestState(tc.commonPool)
+			fakeGameState.IIGOAllocationMap = tc.allocations
+			auditor := additionalRole{
+				gameState: fakeGameState,
+				gameConf:  newAdditionalRoleTestConfig(),
+				Role:      shared.Auditor,
+				HolderID:  shared.Team4,
+				iigoClients: map[shared.ClientID]baseclient.Client{
+					shared.Team4: &mockAdditionalRoleClient{BaseClient: baseclient.NewClient(shared.Team4)},
+				},
+				monitoring: &monitor{gameState: fakeGameState},
+				logger:     func(format string, a ...interface{}) {},
+			}
+			err := auditor.auditAllocations(tc.requests, tc.allocationsMade)
+			if (err != nil) != tc.expectedError {
+				t.Errorf("Expected error %v got %v", tc.expectedError, err)
+			}
+			if !reflect.DeepEqual(tc.expectedAudit, fakeGameState.IIGOAllocationAudit) {
+				t.Errorf("Expected audit %v got %v", tc.expectedAudit, fakeGameState.IIGOAllocationAudit)
+			}
+			if tc.expectedError {
+				return
+			}
+			expectedCache := []shared.Accountability{
+				{
+					ClientID: shared.Team4,
+					Pairs: []rules.VariableValuePair{
+						rules.MakeVariableValuePair(rules.AllocationAuditRequired, []float64{tc.expectedCache[0]}),
+						rules.MakeVariableValuePair(rules.AllocationAuditPerformed, []float64{tc.expectedCache[1]}),
+					},
+				},
+			}
+			if !reflect.DeepEqual(expectedCache, fakeGameState.IIGORoleMonitoringCache) {
+				t.Errorf("Expected monitoring cache %v got %v", expectedCache, fakeGameState.IIGORoleMonitoringCache)
+			}
+		})
+	}
+}
+
+func TestAppointNextHolder(t *testing.T) {
+	cases := []struct {
+		name              string
+		turnsInPower      uint
+		monitoring        shared.MonitorResult
+		commonPool        shared.Resources
+		expectedHolder    shared.ClientID
+		expectedElections int
+		expectedError     bool
+	}{
+		{
+			name:              "Term not ended",
+			turnsInPower:      1,
+			commonPool:        100,
+			expectedHolder:    shared.Team4,
+			expectedElections: 1,
+		},
+		{
+			name:              "Term ended",
+			turnsInPower:      3,
+			commonPool:        100,
+			expectedHolder:    shared.Team1,
+			expectedElections: 1,
+		},
+		{
+			name:              "Monitoring failed",
+			turnsInPower:      1,
+			monitoring:        shared.MonitorResult{Performed: true, Result: false},
+			commonPool:        100,
+			expectedHolder:    shared.Team1,
+			expectedElections: 1,
+		},
+		{
+			name:              "Insufficient budget",
+			turnsInPower:      3,
+			commonPool:        1,
+			expectedHolder:    shared.Team4,
+			expectedElections: 0,
+			expectedError:     true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			aliveClientIDs := []shared.ClientID{shared.Team1, shared.Team2, shared.Team3, shared.Team4}
+			clients := map[shared.ClientID]baseclient.Client{}
+			for _, clientID := range aliveClientIDs {
+				clients[clientID] = &mockAdditionalRoleClient{BaseClient: baseclient.NewClient(clientID)}
+			}
+			fakeGameState := newAdditionalRoleTestState(tc.commonPool)
+			fakeGameState.IIGOTurnsInPower[shared.Treasurer] = tc.turnsInPower
+			fakeMonitoring := &monitor{gameState: fakeGameState}
+			executiveBranch := executive{gameState: fakeGameState, PresidentID: shared.Team1, monitoring: fakeMonitoring}
+			treasurer := additionalRole{
+				gameState:   fakeGameState,
+				gameConf:    newAdditionalRoleTestConfig(),
+				Role:        shared.Treasurer,
+				HolderID:    shared.Team4,
+				iigoClients: clients,
+				monitoring:  fakeMonitoring,
+				logger:      func(format string, a ...interface{}) {},
+			}
+			runner := electionRunner{ID: shared.Team1, charge: executiveBranch.incurServiceCharge}
+			holder, err := treasurer.appointNextHolder(tc.monitoring, runner, aliveClientIDs)
+			if (err != nil) != tc.expectedError {
+				t.Errorf("Expected error %v got %v", tc.expectedError, err)
+			}
+			if holder != tc.expectedHolder {
+				t.Errorf("Expected holder %v got %v", tc.expectedHolder, holder)
+			}
+			if len(fakeGameState.IIGOElection) != tc.expectedElections {
+				t.Errorf("Expected %v election records got %v", tc.expectedElections, len(fakeGameState.IIGOElection))
+			}
+		})
+	}
+}
@@ -0,0 +1,337 @@
+package iigointernal
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/gamestate"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/rules"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+	"github.com/pkg/errors"
+)
+
+// queueAgendaItem adds a rule to the end of the legislative agenda, unless the same rule is already queued
+func (l *legislature) queueAgendaItem(ruleMatrix rules.RuleMatrix) {
+	if ruleMatrix.RuleMatrixIsEmpty() {
+		return
+	}
+	for _, item := range l.gameState.IIGOLegislativeAgenda {
+		for _, queued := range item.Rules {
+			if reflect.DeepEqual(queued, ruleMatrix) {
+				return
+			}
+		}
+	}
+	item := shared.AgendaItem{
+		ID:         l.gameState.IIGONextAgendaItemID,
+		Rules:      rules.CopyRuleMatrices([]rules.RuleMatrix{ruleMatrix}),
+		TurnQueued: l.gameState.Turn,
+	}
+	l.gameState.IIGONextAgendaItemID++
+	l.gameState.IIGOLegislativeAgenda = append(l.gameState.IIGOLegislativeAgenda, item)
+	l.Logf("Rule %v queued on the agenda as item %v", ruleMatrix.RuleName, item.ID)
+}
+
+// runLegislativeSession votes on the items of the legislative agenda in the order decided by the Speaker.
+// Items can be bundled and amended before they are voted on. Every item and amendment put to a vote costs
+// SetRuleToVoteActionCost and the session ends once the LegislativeSessionBudget cannot cover the next vote.
+// Items that are not voted on stay on the agenda for the next session.
+func (l *legislature) runLegislativeSession(clientIDs []shared.ClientID) (bool, bool, error) {
+	voteCalled, resultAnnounced := false, true
+	l.gameState.IIGOLegislativeSession = []gamestate.MotionRecord{}
+	if len(l.gameState.IIGOLegislativeAgenda) == 0 {
+		return voteCalled, resultAnnounced, nil
+	}
+
+	amendments := l.proposeAmendments(clientIDs)
+	session := l.clientSpeaker.DecideLegislativeSession(
+		gamestate.CopyLegislativeAgenda(l.gameState.IIGOLegislativeAgenda),
+		copyAmendments(amendments),
+	)
+	if !session.ActionTaken {
+		return voteCalled, resultAnnounced, nil
+	}
+	bundledInto := l.bundleAgendaItems(session.Bundles)
+
+	amendmentsByItem := map[uint][]shared.Amendment{}
+	seenAmendments := map[uint]bool{}
+	for _, amendmentID := range session.Amendments {
+		if seenAmendments[amendmentID] || int(amendmentID) >= len(amendments) {
+			continue
+		}
+		seenAmendments[amendmentID] = true
+		amendment := amendments[amendmentID]
+		if leadID, bundled := bundledInto[amendment.ItemID]; bundled {
+			amendment.ItemID = leadID
+		}
+		amendmentsByItem[amendment.ItemID] = append(amendmentsByItem[amendment.ItemID], amendment)
+	}
+
+	var spent shared.Resources
+	seenItems := map[uint]bool{}
+	for _, itemID := range session.Order {
+		if seenItems[itemID] || l.agendaIndex(itemID) < 0 {
+			continue
+		}
+		seenItems[itemID] = true
+		for _, amendment := range amendmentsByItem[itemID] {
+			sessionOpen, err := l.chargeSessionItem(&spent)
+			if !sessionOpen || err != nil {
+				return voteCalled, resultAnnounced, err
+			}
+			if err := l.voteOnAmendment(amendment, clientIDs); err != nil {
+				return voteCalled, resultAnnounced, err
+			}
+		}
+
+		sessionOpen, err := l.chargeSessionItem(&spent)
+		if !sessionOpen || err != nil {
+			return voteCalled, resultAnnounced, err
+		}
+		itemVoteCalled, announced, err := l.voteOnItem(itemID, clientIDs)
+		if err != nil {
+			return voteCalled, resultAnnounced, err
+		}
+		if itemVoteCalled {
+			voteCalled = true
+			resultAnnounced = resultAnnounced && announced
+		}
+	}
+	return voteCalled, resultAnnounced, nil
+}
+
+// proposeAmendments gathers the amendments proposed by the islands to the items queued on the agenda.
+// Amendments which do not keep the name of a rule in the item, do not change it, or change an immutable
+// rule are discarded. The ID of each amendment is its index in the returned list.
+func (l *legislature) proposeAmendments(clientIDs []shared.ClientID) []shared.Amendment {
+	islands := copyClientList(clientIDs)
+	sort.Sort(shared.SortClientByID(islands))
+
+	amendments := []shared.Amendment{}
+	for _, island := range islands {
+		client, ok := l.iigoClients[island]
+		if !ok {
+			continue
+		}
+		proposals := client.ProposeAmendments(gamestate.CopyLegislativeAgenda(l.gameState.IIGOLegislativeAgenda))
+		itemIDs := make([]uint, 0, len(proposals))
+		for itemID := range proposals {
+			itemIDs = append(itemIDs, itemID)
+		}
+		sort.Slice(itemIDs, func(i, j int) bool { return itemIDs[i] < itemIDs[j] })
+		for _, itemID := range itemIDs {
+			if !l.validAmendment(itemID, proposals[itemID]) {
+				continue
+			}
+			amendments = append(amendments, shared.Amendment{
+				ID:         uint(len(amendments)),
+				ItemID:     itemID,
+				Proposer:   island,
+				RuleMatrix: rules.CopyRuleMatrices([]rules.RuleMatrix{proposals[itemID]})[0],
+			})
+		}
+	}
+	return amendments
+}
+
+func (l *legislature) validAmendment(itemID uint, ruleMatrix rules.RuleMatrix) bool {
+	index := l.agendaIndex(itemID)
+	if index < 0 || ruleMatrix.RuleMatrixIsEmpty() {
+		return false
+	}
+	if available, ok := l.gameState.RulesInfo.AvailableRules[ruleMatrix.RuleName]; ok && !available.Mutable {
+		return false
+	}
+	for _, queued := range l.gameState.IIGOLegislativeAgenda[index].Rules {
+		if queued.RuleName == ruleMatrix.RuleName {
+			return !reflect.DeepEqual(queued, ruleMatrix)
+		}
+	}
+	return false
+}
+
+// bundleAgendaItems merges each group of agenda items into its first item, which keeps its place on the agenda.
+// It returns the ID of the item each merged item was bundled into.
+func (l *legislature) bundleAgendaItems(bundles [][]uint) map[uint]uint {
+	bundledInto := map[uint]uint{}
+	for _, bundle := range bundles {
+		if len(bundle) < 2 {
+			continue
+		}
+		leadIndex := l.agendaIndex(bundle[0])
+		if leadIndex < 0 {
+			continue
+		}
+		lead := l.gameState.IIGOLegislativeAgenda[leadIndex]
+		for _, itemID := range bundle[1:] {
+			index := l.agendaIndex(itemID)
+			if index < 0 || itemID == lead.ID {
+				continue
+			}
+			item := l.gameState.IIGOLegislativeAgenda[index]
+			lead.Rules = append(lead.Rules, item.Rules...)
+			lead.AmendmentsAdopted += item.AmendmentsAdopted
+			// the bundle is a new motion, so constitutional sessions start over
+			lead.SessionsPassed = 0
+			if item.TurnQueued < lead.TurnQueued {
+				lead.TurnQueued = item.TurnQueued
+			}
+			l.removeAgendaItem(itemID)
+			bundledInto[itemID] = lead.ID
+			for merged, into := range bundledInto {
+				if into == itemID {
+					bundledInto[merged] = lead.ID
+				}
+			}
+		}
+		l.gameState.IIGOLegislativeAgenda[l.agendaIndex(lead.ID)] = lead
+		l.Logf("Agenda items %v bundled into item %v", bundle, lead.ID)
+	}
+	return bundledInto
+}
+
+// voteOnAmendment puts an amendment to a vote and, if it passes, replaces the amended rule in its agenda item
+func (l *legislature) voteOnAmendment(amendment shared.Amendment, clientIDs []shared.ClientID) error {
+	index := l.agendaIndex(amendment.ItemID)
+	if index < 0 {
+		return nil
+	}
+	l.ruleToVote = amendment.RuleMatrix
+	l.bundledRules = nil
+	voteCalled, err := l.setVotingResult(clientIDs, l.gameConf.RuleVoteMajority)
+	if err != nil || !voteCalled {
+		return err
+	}
+	l.recordMotion(amendment.ItemID, &amendment, []rules.RuleMatrix{amendment.RuleMatrix})
+
+	if l.votingResult {
+		item := &l.gameState.IIGOLegislativeAgenda[index]
+		for i, queued := range item.Rules {
+			if queued.RuleName == amendment.RuleMatrix.RuleName {
+				item.Rules[i] = amendment.RuleMatrix
+			}
+		}
+		item.AmendmentsAdopted++
+		item.SessionsPassed = 0
+		l.Logf("Amendment %v by %v to %v adopted", amendment.ID, amendment.Proposer, amendment.RuleMatrix.RuleName)
+	}
+	return nil
+}
+
+// voteOnItem puts an agenda item to a vote, updates the rules with the result and announces it for every rule
+// in the item. The item leaves the agenda once a vote has been called on it, unless it changes constitutional
+// rules and must pass more sessions. Constitutional rules are left as they are by votes which fail.
+func (l *legislature) voteOnItem(itemID uint, clientIDs []shared.ClientID) (bool, bool, error) {
+	index := l.agendaIndex(itemID)
+	item := l.gameState.IIGOLegislativeAgenda[index]
+	ruleNames := make([]string, 0, len(item.Rules))
+	for _, ruleMatrix := range item.Rules {
+		ruleNames = append(ruleNames, ruleMatrix.RuleName)
+	}
+	procedure := l.gameConf.GetAmendmentProcedure(ruleNames)
+
+	l.ruleToVote = item.Rules[0]
+	l.bundledRules = item.Rules[1:]
+	voteCalled, err := l.setVotingResult(clientIDs, procedure.Majority)
+	l.bundledRules = nil
+	if err != nil || !voteCalled {
+		return voteCalled, false, err
+	}
+
+	if l.votingResult {
+		if item.SessionsPassed > 0 && item.TurnLastPassed+1 != l.gameState.Turn {
+			item.SessionsPassed = 0
+		}
+		item.SessionsPassed++
+		item.TurnLastPassed = l.gameState.Turn
+	}
+	l.recordMotion(itemID, nil, item.Rules)
+	motion := &l.gameState.IIGOLegislativeSession[len(l.gameState.IIGOLegislativeSession)-1]
+	motion.SessionsPassed = item.SessionsPassed
+	motion.SessionsRequired = procedure.Sessions
+
+	if l.votingResult && item.SessionsPassed < procedure.Sessions {
+		l.gameState.IIGOLegislativeAgenda[index] = item
+		l.Logf("Agenda item %v passed %v of the %v sessions required by the constitution", itemID, item.SessionsPassed, procedure.Sessions)
+		return voteCalled, true, nil
+	}
+	l.removeAgendaItem(itemID)
+
+	resultAnnounced := true
+	for _, ruleMatrix := range item.Rules {
+		if l.votingResult || !l.gameConf.IsConstitutional(ruleMatrix.RuleName) {
+			if err := l.updateRules(ruleMatrix, l.votingResult); err != nil {
+				l.Logf("Error updating rules with result: %v", err)
+			}
+		}
+		l.ruleToVote = ruleMatrix
+		announced, err := l.announceVotingResult()
+		if err != nil {
+			return voteCalled, false, err
+		}
+		resultAnnounced = resultAnnounced && announced
+	}
+	return voteCalled, resultAnnounced, nil
+}
+
+// chargeSessionItem pays for putting one more item to a vote. It returns false if the session budget is spent.
+func (l *legislature) chargeSessionItem(spent *shared.Resources) (bool, error) {
+	cost := l.gameConf.SetRuleToVoteActionCost
+	if *spent+cost > l.gameConf.LegislativeSessionBudget {
+		l.Logf("Legislative session budget spent, %v items remain on the agenda", len(l.gameState.IIGOLegislativeAgenda))
+		return false, nil
+	}
+	if !l.incurServiceCharge(cost) {
+		return false, errors.Errorf("Insufficient Budget in common Pool: runLegislativeSession")
+	}
+	*spent += cost
+	return true, nil
+}
+
+func (l *legislature) recordMotion(itemID uint, amendment *shared.Amendment, ruleMatrices []rules.RuleMatrix) {
+	record := gamestate.MotionRecord{
+		ItemID:        itemID,
+		RuleNames:     make([]string, 0, len(ruleMatrices)),
+		Voters:        copyClientList(l.votingIslands),
+		VotesInFavour: l.ballotBox.VotesInFavour,
+		VotesAgainst:  l.ballotBox.VotesAgainst,
+		Passed:        l.votingResult,
+	}
+	if amendment != nil {
+		record.AmendmentID = amendment.ID
+		record.IsAmendment = true
+	}
+	for _, ruleMatrix := range ruleMatrices {
+		record.RuleNames = append(record.RuleNames, ruleMatrix.RuleName)
+	}
+	l.gameState.IIGOLegislativeSession = append(l.gameState.IIGOLegislativeSession, record)
+}
+
+// agendaIndex returns the position of the item on the agenda, or -1 if it is not queued
+func (l *legislature) agendaIndex(itemID uint) int {
+	for i, item := range l.gameState.IIGOLegislativeAgenda {
+		if item.ID == itemID {
+			return i
+		}
+	}
+	return -1
+}
+
+func (l *legislature) removeAgendaItem(itemID uint) {
+	agenda := []shared.AgendaItem{}
+	for _, item := range l.gameState.IIGOLegislativeAgenda {
+		if item.ID != itemID {
+			agenda = append(agenda, item)
+		}
+	}
+	l.gameState.IIGOLegislativeAgenda = agenda
+}
+
+func copyAmendments(amendments []shared.Amendment) []shared.Amendment {
+	ret := make([]shared.Amendment, len(amendments))
+	for i, amendment := range amendments {
+		ret[i] = amendment
+		ret[i].RuleMatrix = rules.CopyRuleMatrices([]rules.RuleMatrix{amendment.RuleMatrix})[0]
+	}
+	return ret
+}
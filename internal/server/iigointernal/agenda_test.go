@@ -0,0 +1,442 @@
+package iigointernal
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/baseclient"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/gamestate"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/rules"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+type mockAgendaClient struct {
+	*baseclient.BaseClient
+	votes      map[string]shared.RuleVoteType
+	amendments map[uint]rules.RuleMatrix
+}
+
+func (c *mockAgendaClient) VoteForRule(ruleMatrix rules.RuleMatrix) shared.RuleVoteType {
+	return c.votes[ruleMatrix.RuleName]
+}
+
+func (c *mockAgendaClient) ProposeAmendments(agenda []shared.AgendaItem) map[uint]rules.RuleMatrix {
+	return c.amendments
+}
+
+type mockSessionSpeaker struct {
+	*baseclient.BaseSpeaker
+	session  *shared.LegislativeSession
+	swapRule string
+}
+
+func (s *mockSessionSpeaker) DecideVote(ruleMatrix rules.RuleMatrix, aliveClients []shared.ClientID) shared.SpeakerReturnContent {
+	if s.swapRule != "" {
+		ruleMatrix = genRuleMatrixExample1(s.swapRule)
+	}
+	return s.BaseSpeaker.DecideVote(ruleMatrix, aliveClients)
+}
+
+func (s *mockSessionSpeaker) DecideLegislativeSession(agenda []shared.AgendaItem, amendments []shared.Amendment) shared.LegislativeSession {
+	if s.session != nil {
+		return *s.session
+	}
+	return s.BaseSpeaker.DecideLegislativeSession(agenda, amendments)
+}
+
+type expectedMotion struct {
+	itemID      uint
+	isAmendment bool
+	ruleNames   []string
+	passed      bool
+}
+
+func TestRunLegislativeSession(t *testing.T) {
+	cases := []struct {
+		name               string
+		sessionBudget      shared.Resources
+		session            *shared.LegislativeSession
+		swapRule           string
+		votes              map[shared.ClientID]map[string]shared.RuleVoteType
+		amendments         map[shared.ClientID]map[uint]rules.RuleMatrix
+		expectedVoteCalled bool
+		expectedMotions    []expectedMotion
+		expectedAgenda     []uint
+		expectedInPlay     []string
+		expectedCommonPool shared.Resources
+	}{
+		{
+			name:               "Budget covers two items",
+			sessionBudget:      4,
+			expectedVoteCalled: true,
+			expectedMotions: []expectedMotion{
+				{itemID: 0, ruleNames: []string{"TestingRule1"}, passed: true},
+				{itemID: 1, ruleNames: []string{"TestingRule2"}, passed: true},
+			},
+			expectedAgenda:     []uint{2},
+			expectedInPlay:     []string{"Kinda Test Rule 2", "TestingRule1", "TestingRule2"},
+			expectedCommonPool: 96,
+		},
+		{
+			name:          "Bundle rejected as a whole",
+			sessionBudget: 10,
+			session:       &shared.LegislativeSession{Bundles: [][]uint{{0, 2}}, Order: []uint{0, 1}, ActionTaken: true},
+			votes: map[shared.ClientID]map[string]shared.RuleVoteType{
+				shared.Team1: {"Kinda Test Rule 3": shared.Reject},
+				shared.Team2: {"Kinda Test Rule 3": shared.Reject},
+			},
+			expectedVoteCalled: true,
+			expectedMotions: []expectedMotion{
+				{itemID: 0, ruleNames: []string{"TestingRule1", "Kinda Test Rule 3"}, passed: false},
+				{itemID: 1, ruleNames: []string{"TestingRule2"}, passed: true},
+			},
+			expectedAgenda:     []uint{},
+			expectedInPlay:     []string{"Kinda Test Rule 2", "TestingRule2"},
+			expectedCommonPool: 96,
+		},
+		{
+			name:          "Speaker cannot change the rules of a bundle",
+			sessionBudget: 10,
+			session:       &shared.LegislativeSession{Bundles: [][]uint{{0, 2}}, Order: []uint{0}, ActionTaken: true},
+			swapRule:      "Swapped Rule",
+			votes: map[shared.ClientID]map[string]shared.RuleVoteType{
+				shared.Team1: {"Swapped Rule": shared.Reject},
+				shared.Team2: {"Swapped Rule": shared.Reject},
+			},
+			expectedVoteCalled: true,
+			expectedMotions: []expectedMotion{
+				{itemID: 0, ruleNames: []string{"TestingRule1", "Kinda Test Rule 3"}, passed: true},
+			},
+			expectedAgenda:     []uint{1},
+			expectedInPlay:     []string{"Kinda Test Rule 2", "Kinda Test Rule 3", "TestingRule1"},
+			expectedCommonPool: 98,
+		},
+		{
+			name:          "Amendment adopted before the item is voted on",
+			sessionBudget: 4,
+			session:       &shared.LegislativeSession{Order: []uint{0}, Amendments: []uint{0}, ActionTaken: true},
+			amendments: map[shared.ClientID]map[uint]rules.RuleMatrix{
+				shared.Team1: {0: genRuleMatrixExample2("TestingRule1")},
+			},
+			expectedVoteCalled: true,
+			expectedMotions: []expectedMotion{
+				{itemID: 0, isAmendment: true, ruleNames: []string{"TestingRule1"}, passed: true},
+				{itemID: 0, ruleNames: []string{"TestingRule1"}, passed: true},
+			},
+			expectedAgenda:     []uint{1, 2},
+			expectedInPlay:     []string{"Kinda Test Rule 2"},
+			expectedCommonPool: 96,
+		},
+		{
+			name:          "Invalid amendments discarded",
+			sessionBudget: 2,
+			amendments: map[shared.ClientID]map[uint]rules.RuleMatrix{
+				shared.Team1: {0: genRuleMatrixExample2("TestingRule2")},
+				shared.Team2: {0: genRuleMatrixExample1("TestingRule1"), 7: genRuleMatrixExample2("TestingRule1")},
+			},
+			expectedVoteCalled: true,
+			expectedMotions: []expectedMotion{
+				{itemID: 0, ruleNames: []string{"TestingRule1"}, passed: true},
+			},
+			expectedAgenda:     []uint{1, 2},
+			expectedInPlay:     []string{"Kinda Test Rule 2", "TestingRule1"},
+			expectedCommonPool: 98,
+		},
+		{
+			name:          "Session budget spent on amendment",
+			sessionBudget: 2,
+			amendments: map[shared.ClientID]map[uint]rules.RuleMatrix{
+				shared.Team3: {0: genRuleMatrixExample2("TestingRule1")},
+			},
+			expectedVoteCalled: false,
+			expectedMotions: []expectedMotion{
+				{itemID: 0, isAmendment: true, ruleNames: []string{"TestingRule1"}, passed: true},
+			},
+			expectedAgenda:     []uint{0, 1, 2},
+			expectedInPlay:     []string{"Kinda Test Rule 2"},
+			expectedCommonPool: 98,
+		},
+		{
+			name:               "Speaker holds no session",
+			sessionBudget:      10,
+			session:            &shared.LegislativeSession{ActionTaken: false},
+			expectedVoteCalled: false,
+			expectedMotions:    []expectedMotion{},
+			expectedAgenda:     []uint{0, 1, 2},
+			expectedInPlay:     []string{"Kinda Test Rule 2"},
+			expectedCommonPool: 100,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			aliveClientIDs := []shared.ClientID{shared.Team1, shared.Team2, shared.Team3}
+			clients := map[shared.ClientID]baseclient.Client{}
+			for _, clientID := range aliveClientIDs {
+				clients[clientID] = &mockAgendaClient{
+					BaseClient: baseclient.NewClient(clientID),
+					votes:      tc.votes[clientID],
+					amendments: tc.amendments[clientID],
+				}
+			}
+			avail, inPlay := generateRulesTestStores()
+			fakeGameState := &gamestate.GameState{
+				Turn:       3,
+				CommonPool: 100,
+				IIGORolesBudget: map[shared.Role]shared.Resources{
+					shared.Speaker: 100,
+				},
+				RulesInfo: gamestate.RulesContext{
+					VariableMap:        map[rules.VariableFieldName]rules.VariableValuePair{},
+					AvailableRules:     avail,
+					CurrentRulesInPlay: inPlay,
+				},
+			}
+			legislativeBranch := legislature{
+				gameState:     fakeGameState,
+				gameConf:      &config.IIGOConfig{SetRuleToVoteActionCost: 2, LegislativeSessionBudget: tc.sessionBudget},
+				SpeakerID:     shared.Team2,
+				clientSpeaker: &mockSessionSpeaker{BaseSpeaker: &baseclient.BaseSpeaker{}, session: tc.session, swapRule: tc.swapRule},
+				iigoClients:   clients,
+				monitoring:    &monitor{gameState: fakeGameState},
+				logger:        func(format string, a ...interface{}) {},
+			}
+			for _, ruleName := range []string{"TestingRule1", "TestingRule2", "Kinda Test Rule 3"} {
+				legislativeBranch.queueAgendaItem(genRuleMatrixExample1(ruleName))
+			}
+			// Queuing a rule already on the agenda is ignored
+			legislativeBranch.queueAgendaItem(genRuleMatrixExample1("TestingRule1"))
+
+			voteCalled, _, err := legislativeBranch.runLegislativeSession(aliveClientIDs)
+			if err != nil {
+				t.Fatalf("Unexpected error %v", err)
+			}
+			if voteCalled != tc.expectedVoteCalled {
+				t.Errorf("Expected vote called %v got %v", tc.expectedVoteCalled, voteCalled)
+			}
+
+			gotMotions := []expectedMotion{}
+			for _, motion := range fakeGameState.IIGOLegislativeSession {
+				gotMotions = append(gotMotions, expectedMotion{
+					itemID:      motion.ItemID,
+					isAmendment: motion.IsAmendment,
+					ruleNames:   motion.RuleNames,
+					passed:      motion.Passed,
+				})
+			}
+			if !reflect.DeepEqual(tc.expectedMotions, gotMotions) {
+				t.Errorf("Expected motions %v got %v", tc.expectedMotions, gotMotions)
+			}
+
+			gotAgenda := []uint{}
+			for _, item := range fakeGameState.IIGOLegislativeAgenda {
+				gotAgenda = append(gotAgenda, item.ID)
+			}
+			if !reflect.DeepEqual(tc.expectedAgenda, gotAgenda) {
+				t.Errorf("Expected agenda %v got %v", tc.expectedAgenda, gotAgenda)
+			}
+
+			gotInPlay := []string{}
+			for ruleName := range inPlay {
+				gotInPlay = append(gotInPlay, ruleName)
+			}
+			sort.Strings(gotInPlay)
+			if !reflect.DeepEqual(tc.expectedInPlay, gotInPlay) {
+				t.Errorf("Expected rules in play %v got %v", tc.expectedInPlay, gotInPlay)
+			}
+			if fakeGameState.CommonPool != tc.expectedCommonPool {
+				t.Errorf("Expected common pool %v got %v", tc.expectedCommonPool, fakeGameState.CommonPool)
+			}
+		})
+	}
+}
+
+func TestAmendmentModifiesRule(t *testing.T) {
+	clients := map[shared.ClientID]baseclient.Client{
+		shared.Team1: &mockAgendaClient{
+			BaseClient: baseclient.NewClient(shared.Team1),
+			amendments: map[uint]rules.RuleMatrix{0: genRuleMatrixExample2("TestingRule1")},
+		},
+	}
+	avail, inPlay := generateRulesTestStores()
+	fakeGameState := &gamestate.GameState{
+		CommonPool:      100,
+		IIGORolesBudget: map[shared.Role]shared.Resources{},
+		RulesInfo: gamestate.RulesContext{
+			VariableMap:        map[rules.VariableFieldName]rules.VariableValuePair{},
+			AvailableRules:     avail,
+			CurrentRulesInPlay: inPlay,
+		},
+	}
+	legislativeBranch := legislature{
+		gameState:     fakeGameState,
+		gameConf:      &config.IIGOConfig{SetRuleToVoteActionCost: 1, LegislativeSessionBudget: 1},
+		clientSpeaker: &baseclient.BaseSpeaker{},
+		iigoClients:   clients,
+		monitoring:    &monitor{gameState: fakeGameState},
+		logger:        func(format string, a ...interface{}) {},
+	}
+	legislativeBranch.queueAgendaItem(genRuleMatrixExample1("TestingRule1"))
+
+	// The budget only covers the amendment, so the amended item stays on the agenda
+	if _, _, err := legislativeBranch.runLegislativeSession([]shared.ClientID{shared.Team1}); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if len(fakeGameState.IIGOLegislativeAgenda) != 1 {
+		t.Fatalf("Expected the amended item to stay on the agenda got %v", fakeGameState.IIGOLegislativeAgenda)
+	}
+	item := fakeGameState.IIGOLegislativeAgenda[0]
+	if item.AmendmentsAdopted != 1 || !reflect.DeepEqual(item.Rules, []rules.RuleMatrix{genRuleMatrixExample2("TestingRule1")}) {
+		t.Errorf("Expected the item to hold the amended rule got %v", item)
+	}
+
+	// The next session votes on the amended rule, which modifies the rule's content
+	if _, _, err := legislativeBranch.runLegislativeSession([]shared.ClientID{shared.Team1}); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if !reflect.DeepEqual(avail["TestingRule1"].ApplicableMatrix, genRuleMatrixExample2("TestingRule1").ApplicableMatrix) {
+		t.Errorf("Expected TestingRule1 to be modified got %v", avail["TestingRule1"])
+	}
+}
+
+func TestConstitutionalAmendment(t *testing.T) {
+	type session struct {
+		turn  uint
+		votes map[shared.ClientID]shared.RuleVoteType
+	}
+	oneAgainst := map[shared.ClientID]shared.RuleVoteType{shared.Team3: shared.Reject}
+	twoAgainst := map[shared.ClientID]shared.RuleVoteType{shared.Team2: shared.Reject, shared.Team3: shared.Reject}
+	cases := []struct {
+		name                   string
+		ruleName               string
+		constitution           map[string]config.AmendmentProcedure
+		ruleVoteMajority       float64
+		sessions               []session
+		expectedPassed         []bool
+		expectedSessionsPassed []uint
+		expectedInPlay         bool
+		expectedOnAgenda       bool
+	}{
+		{
+			name:     "Supermajority across two consecutive sessions",
+			ruleName: "TestingRule1",
+			constitution: map[string]config.AmendmentProcedure{
+				"TestingRule1": {Majority: 2.0 / 3.0, Sessions: 2},
+			},
+			sessions:               []session{{turn: 3, votes: oneAgainst}, {turn: 4, votes: oneAgainst}},
+			expectedPassed:         []bool{true, true},
+			expectedSessionsPassed: []uint{1, 2},
+			expectedInPlay:         true,
+			expectedOnAgenda:       false,
+		},
+		{
+			name:     "Sessions must be consecutive",
+			ruleName: "TestingRule1",
+			constitution: map[string]config.AmendmentProcedure{
+				"TestingRule1": {Majority: 2.0 / 3.0, Sessions: 2},
+			},
+			sessions:               []session{{turn: 3, votes: oneAgainst}, {turn: 5, votes: oneAgainst}},
+			expectedPassed:         []bool{true, true},
+			expectedSessionsPassed: []uint{1, 1},
+			expectedInPlay:         false,
+			expectedOnAgenda:       true,
+		},
+		{
+			name:     "Supermajority missed",
+			ruleName: "TestingRule1",
+			constitution: map[string]config.AmendmentProcedure{
+				"TestingRule1": {Majority: 2.0 / 3.0, Sessions: 2},
+			},
+			sessions:               []session{{turn: 3, votes: twoAgainst}},
+			expectedPassed:         []bool{false},
+			expectedSessionsPassed: []uint{0},
+			expectedInPlay:         false,
+			expectedOnAgenda:       false,
+		},
+		{
+			name:     "Failed vote leaves constitutional rule in play",
+			ruleName: "Kinda Test Rule 2",
+			constitution: map[string]config.AmendmentProcedure{
+				"Kinda Test Rule 2": {Majority: 1},
+			},
+			sessions:               []session{{turn: 3, votes: oneAgainst}},
+			expectedPassed:         []bool{false},
+			expectedSessionsPassed: []uint{0},
+			expectedInPlay:         true,
+			expectedOnAgenda:       false,
+		},
+		{
+			name:                   "Ordinary rule vote follows the rule vote majority",
+			ruleName:               "Kinda Test Rule 2",
+			ruleVoteMajority:       1,
+			sessions:               []session{{turn: 3, votes: oneAgainst}},
+			expectedPassed:         []bool{false},
+			expectedSessionsPassed: []uint{0},
+			expectedInPlay:         false,
+			expectedOnAgenda:       false,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			avail, inPlay := generateRulesTestStores()
+			fakeGameState := &gamestate.GameState{
+				CommonPool:      100,
+				IIGORolesBudget: map[shared.Role]shared.Resources{},
+				RulesInfo: gamestate.RulesContext{
+					VariableMap:        map[rules.VariableFieldName]rules.VariableValuePair{},
+					AvailableRules:     avail,
+					CurrentRulesInPlay: inPlay,
+				},
+			}
+			legislativeBranch := legislature{
+				gameState: fakeGameState,
+				gameConf: &config.IIGOConfig{
+					SetRuleToVoteActionCost:  1,
+					LegislativeSessionBudget: 10,
+					RuleVoteMajority:         tc.ruleVoteMajority,
+					Constitution:             tc.constitution,
+				},
+				clientSpeaker: &baseclient.BaseSpeaker{},
+				monitoring:    &monitor{gameState: fakeGameState},
+				logger:        func(format string, a ...interface{}) {},
+			}
+			legislativeBranch.queueAgendaItem(genRuleMatrixExample1(tc.ruleName))
+
+			aliveClientIDs := []shared.ClientID{shared.Team1, shared.Team2, shared.Team3}
+			gotPassed := []bool{}
+			gotSessionsPassed := []uint{}
+			for _, s := range tc.sessions {
+				clients := map[shared.ClientID]baseclient.Client{}
+				for _, clientID := range aliveClientIDs {
+					clients[clientID] = &mockAgendaClient{
+						BaseClient: baseclient.NewClient(clientID),
+						votes:      map[string]shared.RuleVoteType{tc.ruleName: s.votes[clientID]},
+					}
+				}
+				legislativeBranch.iigoClients = clients
+				fakeGameState.Turn = s.turn
+				if _, _, err := legislativeBranch.runLegislativeSession(aliveClientIDs); err != nil {
+					t.Fatalf("Unexpected error %v", err)
+				}
+				for _, motion := range fakeGameState.IIGOLegislativeSession {
+					gotPassed = append(gotPassed, motion.Passed)
+					gotSessionsPassed = append(gotSessionsPassed, motion.SessionsPassed)
+				}
+			}
+
+			if !reflect.DeepEqual(tc.expectedPassed, gotPassed) {
+				t.Errorf("Expected motions passed %v got %v", tc.expectedPassed, gotPassed)
+			}
+			if !reflect.DeepEqual(tc.expectedSessionsPassed, gotSessionsPassed) {
+				t.Errorf("Expected sessions passed %v got %v", tc.expectedSessionsPassed, gotSessionsPassed)
+			}
+			if _, ok := inPlay[tc.ruleName]; ok != tc.expectedInPlay {
+				t.Errorf("Expected %v in play %v got %v", tc.ruleName, tc.expectedInPlay, ok)
+			}
+			if onAgenda := len(fakeGameState.IIGOLegislativeAgenda) > 0; onAgenda != tc.expectedOnAgenda {
+				t.Errorf("Expected item on the agenda %v got %v", tc.expectedOnAgenda, onAgenda)
+			}
+		})
+	}
+}
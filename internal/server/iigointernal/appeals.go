@@ -0,0 +1,184 @@
+package iigointernal
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/gamestate"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/rules"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+// hearAppeals lets every island sanctioned this turn appeal against its sanction by paying the AppealFee.
+// A randomly selected panel of other islands re-evaluates the contested rules against the variables recorded
+// for the appellant, and the sanction is upheld, reduced or voided according to the rules the panel upholds.
+func (j *judiciary) hearAppeals(aliveClientIDs []shared.ClientID) {
+	history := j.gameState.IIGOHistory[j.gameState.Turn-1]
+	sanctions := []shared.Sanction{}
+	for _, sanction := range j.gameState.IIGOSanctionCache[0] {
+		appellant := sanction.ClientID
+		client, clientFound := j.iigoClients[appellant]
+		if sanction.SanctionTier == shared.NoSanction || !clientFound || !Contains(aliveClientIDs, appellant) {
+			sanctions = append(sanctions, sanction)
+			continue
+		}
+		rulesBroken := unpackSingleIslandTransgressions(j.evaluationResults[appellant])
+		if !client.AppealSanction(sanction, copyRuleNames(rulesBroken)) {
+			sanctions = append(sanctions, sanction)
+			continue
+		}
+		if !withdrawFromClientPrivatePool(j.gameConf.AppealFee, appellant, j.gameState) {
+			j.Logf("%v cannot afford to appeal its sanction", appellant)
+			sanctions = append(sanctions, sanction)
+			continue
+		}
+		depositIntoCommonPool(j.gameConf.AppealFee, j.gameState)
+
+		appeal := shared.SanctionAppeal{
+			Appellant:         appellant,
+			SanctionTier:      sanction.SanctionTier,
+			RulesBroken:       rulesBroken,
+			RecordedVariables: recordedVariables(history, appellant),
+		}
+		panel := selectAppealPanel(aliveClientIDs, []shared.ClientID{appellant, j.JudgeID}, int(j.gameConf.AppealPanelSize))
+		record := j.decideAppeal(appeal, panel)
+		j.Logf("Appeal by %v against %v: %v", appellant, sanction.SanctionTier, record.Outcome)
+
+		j.removeOverturnedRules(appellant, record.RuleVerdicts)
+		switch record.Outcome {
+		case shared.AppealVoided:
+			if refund, ok := WithdrawFromCommonPool(j.gameConf.AppealFee, j.gameState); ok {
+				depositIntoClientPrivatePool(refund, appellant, j.gameState)
+				record.Reasoning = append(record.Reasoning, "Sanction voided, appeal fee refunded")
+			}
+		case shared.AppealReduced:
+			sanction.SanctionTier = record.FinalTier
+			sanction.TurnsLeft = int(j.sanctionDuration(record.FinalTier))
+			sanctions = append(sanctions, sanction)
+		default:
+			sanctions = append(sanctions, sanction)
+		}
+		if record.Outcome != shared.AppealUpheld {
+			broadcastToAllIslands(j.iigoClients, j.JudgeID, createBroadcastForSanction(appellant, record.FinalTier), *j.gameState)
+		}
+		j.gameState.IIGOSanctionAppeals = append(j.gameState.IIGOSanctionAppeals, record)
+	}
+	j.gameState.IIGOSanctionCache[0] = sanctions
+}
+
+// decideAppeal collects the panel's review of every contested rule. A rule breach is upheld if at least half
+// of the panel finds the rule broken, so ties side with the Judge. The sanction is then recalculated from the
+// upheld breaches only, and can never be increased by an appeal.
+func (j *judiciary) decideAppeal(appeal shared.SanctionAppeal, panel []shared.ClientID) gamestate.AppealRecord {
+	record := gamestate.AppealRecord{
+		Appeal:       appeal,
+		FeePaid:      j.gameConf.AppealFee,
+		Panel:        panel,
+		RuleVerdicts: map[string]bool{},
+		Reasoning:    []string{},
+	}
+
+	breachVotes := map[string]int{}
+	for _, panelist := range panel {
+		appealCopy := appeal
+		appealCopy.RulesBroken = copyRuleNames(appeal.RulesBroken)
+		appealCopy.RecordedVariables = append([]rules.VariableValuePair{}, appeal.RecordedVariables...)
+		verdicts := j.iigoClients[panelist].ReviewSanctionAppeal(appealCopy)
+		for rule, broken := range verdicts {
+			if broken {
+				breachVotes[rule]++
+			}
+		}
+	}
+
+	if len(panel) == 0 {
+		record.Reasoning = append(record.Reasoning, "No island was available to sit on the panel, the Judge's decision stands")
+	}
+	score := shared.IIGOSanctionsScore(0)
+	for _, rule := range appeal.RulesBroken {
+		if _, decided := record.RuleVerdicts[rule]; !decided {
+			record.RuleVerdicts[rule] = 2*breachVotes[rule] >= len(panel)
+			if len(panel) > 0 {
+				record.Reasoning = append(record.Reasoning, fmt.Sprintf("%v: %v of %v panel islands found the rule broken, breach %v",
+					rule, breachVotes[rule], len(panel), upheldOrOverturned(record.RuleVerdicts[rule])))
+			}
+		}
+		if record.RuleVerdicts[rule] {
+			score += j.ruleScore(rule)
+		}
+	}
+
+	record.FinalTier = getIslandSanctionTier(score, j.sanctionThresholds)
+	switch {
+	case sanctionSeverity(record.FinalTier) >= sanctionSeverity(appeal.SanctionTier):
+		record.FinalTier = appeal.SanctionTier
+		record.Outcome = shared.AppealUpheld
+	case record.FinalTier == shared.NoSanction:
+		record.Outcome = shared.AppealVoided
+	default:
+		record.Outcome = shared.AppealReduced
+	}
+	record.Reasoning = append(record.Reasoning, fmt.Sprintf("Sanction score of upheld breaches is %v, %v becomes %v",
+		score, appeal.SanctionTier, record.FinalTier))
+	return record
+}
+
+// removeOverturnedRules removes the breaches overturned on appeal from the rules broken by the island this turn
+func (j *judiciary) removeOverturnedRules(island shared.ClientID, ruleVerdicts map[string]bool) {
+	remaining := []string{}
+	for _, rule := range j.gameState.RulesBrokenByIslands[island] {
+		if broken, contested := ruleVerdicts[rule]; !contested || broken {
+			remaining = append(remaining, rule)
+		}
+	}
+	j.gameState.RulesBrokenByIslands[island] = remaining
+}
+
+// selectAppealPanel randomly selects up to size alive islands, leaving out the excluded islands
+func selectAppealPanel(aliveClientIDs []shared.ClientID, excluded []shared.ClientID, size int) []shared.ClientID {
+	candidates := []shared.ClientID{}
+	for _, island := range aliveClientIDs {
+		if !Contains(excluded, island) {
+			candidates = append(candidates, island)
+		}
+	}
+	rand.Shuffle(len(candidates), func(i, j int) { candidates[i], candidates[j] = candidates[j], candidates[i] })
+	if len(candidates) > size {
+		candidates = candidates[:size]
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i] < candidates[j] })
+	return candidates
+}
+
+// recordedVariables gathers the variables recorded for island in the turn's accountability history
+func recordedVariables(history []shared.Accountability, island shared.ClientID) []rules.VariableValuePair {
+	pairs := []rules.VariableValuePair{}
+	for _, entry := range history {
+		if entry.ClientID == island {
+			pairs = append(pairs, entry.Pairs...)
+		}
+	}
+	return pairs
+}
+
+// sanctionSeverity orders sanction tiers from no sanction (0) to the harshest tier
+func sanctionSeverity(tier shared.IIGOSanctionsTier) int {
+	if tier == shared.NoSanction {
+		return 0
+	}
+	return int(tier) + 1
+}
+
+func upheldOrOverturned(upheld bool) string {
+	if upheld {
+		return "upheld"
+	}
+	return "overturned"
+}
+
+func copyRuleNames(in []string) []string {
+	ret := make([]string, len(in))
+	copy(ret, in)
+	return ret
+}
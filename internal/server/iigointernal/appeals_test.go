@@ -0,0 +1,214 @@
+package iigointernal
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/baseclient"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/gamestate"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/rules"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+type mockAppealClient struct {
+	*baseclient.BaseClient
+	appeal   bool
+	verdicts map[string]bool
+}
+
+func (c *mockAppealClient) AppealSanction(sanction shared.Sanction, rulesBroken []string) bool {
+	return c.appeal
+}
+
+func (c *mockAppealClient) ReviewSanctionAppeal(appeal shared.SanctionAppeal) map[string]bool {
+	return c.verdicts
+}
+
+func TestHearAppeals(t *testing.T) {
+	cases := []struct {
+		name              string
+		appeal            bool
+		appellantFunds    shared.Resources
+		panelVerdicts     map[shared.ClientID]map[string]bool
+		sanctionLadder    []config.SanctionTierConfig
+		expectedSanctions []shared.Sanction
+		expectedOutcome   shared.AppealOutcome
+		expectedVerdicts  map[string]bool
+		expectedRecord    bool
+		expectedFunds     shared.Resources
+	}{
+		{
+			name:              "No appeal",
+			appeal:            false,
+			appellantFunds:    50,
+			expectedSanctions: []shared.Sanction{{ClientID: shared.Team1, SanctionTier: shared.SanctionTier2, TurnsLeft: 2}},
+			expectedFunds:     50,
+		},
+		{
+			name:              "Cannot afford the fee",
+			appeal:            true,
+			appellantFunds:    5,
+			expectedSanctions: []shared.Sanction{{ClientID: shared.Team1, SanctionTier: shared.SanctionTier2, TurnsLeft: 2}},
+			expectedFunds:     5,
+		},
+		{
+			name:           "Sanction upheld",
+			appeal:         true,
+			appellantFunds: 50,
+			panelVerdicts: map[shared.ClientID]map[string]bool{
+				shared.Team2: {"rule_a": true, "rule_b": true},
+				shared.Team3: {"rule_a": true, "rule_b": false},
+			},
+			expectedSanctions: []shared.Sanction{{ClientID: shared.Team1, SanctionTier: shared.SanctionTier2, TurnsLeft: 2}},
+			expectedOutcome:   shared.AppealUpheld,
+			expectedVerdicts:  map[string]bool{"rule_a": true, "rule_b": true},
+			expectedRecord:    true,
+			expectedFunds:     40,
+		},
+		{
+			name:           "Sanction reduced",
+			appeal:         true,
+			appellantFunds: 50,
+			panelVerdicts: map[shared.ClientID]map[string]bool{
+				shared.Team2: {"rule_a": true, "rule_b": false},
+				shared.Team3: {"rule_a": true, "rule_b": false},
+			},
+			expectedSanctions: []shared.Sanction{{ClientID: shared.Team1, SanctionTier: shared.SanctionTier1, TurnsLeft: 2}},
+			expectedOutcome:   shared.AppealReduced,
+			expectedVerdicts:  map[string]bool{"rule_a": true, "rule_b": false},
+			expectedRecord:    true,
+			expectedFunds:     40,
+		},
+		{
+			name:           "Sanction reduced to a shorter tier",
+			appeal:         true,
+			appellantFunds: 50,
+			panelVerdicts: map[shared.ClientID]map[string]bool{
+				shared.Team2: {"rule_a": true, "rule_b": false},
+				shared.Team3: {"rule_a": true, "rule_b": false},
+			},
+			sanctionLadder: []config.SanctionTierConfig{
+				{Threshold: 1, Duration: 1},
+				{Threshold: 5, Duration: 4},
+			},
+			expectedSanctions: []shared.Sanction{{ClientID: shared.Team1, SanctionTier: shared.SanctionTier1, TurnsLeft: 1}},
+			expectedOutcome:   shared.AppealReduced,
+			expectedVerdicts:  map[string]bool{"rule_a": true, "rule_b": false},
+			expectedRecord:    true,
+			expectedFunds:     40,
+		},
+		{
+			name:           "Sanction voided",
+			appeal:         true,
+			appellantFunds: 50,
+			panelVerdicts: map[shared.ClientID]map[string]bool{
+				shared.Team2: {"rule_a": false, "rule_b": false},
+				shared.Team3: {},
+			},
+			expectedSanctions: []shared.Sanction{},
+			expectedOutcome:   shared.AppealVoided,
+			expectedVerdicts:  map[string]bool{"rule_a": false, "rule_b": false},
+			expectedRecord:    true,
+			expectedFunds:     50,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			aliveClientIDs := []shared.ClientID{shared.Team1, shared.Team2, shared.Team3, shared.Team4}
+			clients := map[shared.ClientID]baseclient.Client{}
+			for _, clientID := range aliveClientIDs {
+				clients[clientID] = &mockAppealClient{BaseClient: baseclient.NewClient(clientID), verdicts: tc.panelVerdicts[clientID]}
+			}
+			clients[shared.Team1].(*mockAppealClient).appeal = tc.appeal
+
+			fakeGameState := &gamestate.GameState{
+				Turn:       1,
+				CommonPool: 100,
+				ClientInfos: map[shared.ClientID]gamestate.ClientInfo{
+					shared.Team1: {Resources: tc.appellantFunds},
+				},
+				IIGOHistory: map[uint][]shared.Accountability{
+					0: {
+						{
+							ClientID: shared.Team1,
+							Pairs:    []rules.VariableValuePair{rules.MakeVariableValuePair(rules.IslandTaxContribution, []float64{0})},
+						},
+					},
+				},
+				IIGOSanctionCache: map[int][]shared.Sanction{
+					0: {{ClientID: shared.Team1, SanctionTier: shared.SanctionTier2, TurnsLeft: 2}},
+				},
+				RulesBrokenByIslands: map[shared.ClientID][]string{
+					shared.Team1: {"rule_a", "rule_b"},
+				},
+				IIGOSanctionAppeals: []gamestate.AppealRecord{},
+			}
+			judicialBranch := judiciary{
+				gameState: fakeGameState,
+				gameConf:  &config.IIGOConfig{AppealFee: 10, AppealPanelSize: 3, SanctionLength: 2, SanctionLadder: tc.sanctionLadder},
+				JudgeID:   shared.Team4,
+				evaluationResults: map[shared.ClientID]shared.EvaluationReturn{
+					shared.Team1: {
+						Rules:       []rules.RuleMatrix{{RuleName: "rule_a"}, {RuleName: "rule_b"}},
+						Evaluations: []bool{false, false},
+					},
+				},
+				sanctionThresholds:    getDefaultSanctionThresholds(),
+				ruleViolationSeverity: map[string]shared.IIGOSanctionsScore{"rule_a": 3, "rule_b": 3},
+				iigoClients:           clients,
+				logger:                func(format string, a ...interface{}) {},
+			}
+
+			judicialBranch.hearAppeals(aliveClientIDs)
+
+			if !reflect.DeepEqual(tc.expectedSanctions, fakeGameState.IIGOSanctionCache[0]) {
+				t.Errorf("Expected sanctions %v got %v", tc.expectedSanctions, fakeGameState.IIGOSanctionCache[0])
+			}
+			if fakeGameState.ClientInfos[shared.Team1].Resources != tc.expectedFunds {
+				t.Errorf("Expected appellant resources %v got %v", tc.expectedFunds, fakeGameState.ClientInfos[shared.Team1].Resources)
+			}
+			if !tc.expectedRecord {
+				if len(fakeGameState.IIGOSanctionAppeals) != 0 {
+					t.Errorf("Expected no appeal record got %v", fakeGameState.IIGOSanctionAppeals)
+				}
+				return
+			}
+			if len(fakeGameState.IIGOSanctionAppeals) != 1 {
+				t.Fatalf("Expected one appeal record got %v", fakeGameState.IIGOSanctionAppeals)
+			}
+			record := fakeGameState.IIGOSanctionAppeals[0]
+			if record.Outcome != tc.expectedOutcome {
+				t.Errorf("Expected outcome %v got %v", tc.expectedOutcome, record.Outcome)
+			}
+			if !reflect.DeepEqual(tc.expectedVerdicts, record.RuleVerdicts) {
+				t.Errorf("Expected verdicts %v got %v", tc.expectedVerdicts, record.RuleVerdicts)
+			}
+			if !reflect.DeepEqual([]shared.ClientID{shared.Team2, shared.Team3}, record.Panel) {
+				t.Errorf("Expected panel of Team2 and Team3 got %v", record.Panel)
+			}
+			if len(record.Reasoning) == 0 {
+				t.Errorf("Expected the panel's reasoning to be recorded")
+			}
+		})
+	}
+}
+
+func TestSelectAppealPanel(t *testing.T) {
+	aliveClientIDs := []shared.ClientID{shared.Team1, shared.Team2, shared.Team3, shared.Team4, shared.Team5}
+	excluded := []shared.ClientID{shared.Team1, shared.Team5}
+	for i := 0; i < 10; i++ {
+		panel := selectAppealPanel(aliveClientIDs, excluded, 2)
+		if len(panel) != 2 {
+			t.Fatalf("Expected panel of 2 got %v", panel)
+		}
+		for _, island := range panel {
+			if Contains(excluded, island) {
+				t.Errorf("Excluded island %v selected for panel %v", island, panel)
+			}
+		}
+	}
+	if panel := selectAppealPanel(aliveClientIDs, excluded, 10); len(panel) != 3 {
+		t.Errorf("Expected every eligible island on the panel got %v", panel)
+	}
+}
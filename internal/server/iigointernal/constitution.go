@@ -0,0 +1,181 @@
+package iigointernal
+
+import (
+	"math"
+	"sort"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/gamestate"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/rules"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+// governedParameter binds an IIGO config parameter to the rule holding its value. The value is the constant of
+// the first row of the rule matrix, in the same way as the increment_budget rules.
+type governedParameter struct {
+	get   func(c *config.IIGOConfig) float64
+	set   func(c *config.IIGOConfig, value float64)
+	valid func(value float64) bool
+}
+
+// governedParameters are the IIGO config parameters placed under self-governance, by rule name
+var governedParameters = governedIIGOParameters()
+
+// actionCosts binds each IIGO action in rules.IIGOActions to its cost in the config
+var actionCosts = map[string]func(c *config.IIGOConfig) *shared.Resources{
+	"get_rule_for_speaker":       func(c *config.IIGOConfig) *shared.Resources { return &c.GetRuleForSpeakerActionCost },
+	"broadcast_taxation":         func(c *config.IIGOConfig) *shared.Resources { return &c.BroadcastTaxationActionCost },
+	"reply_allocation_requests":  func(c *config.IIGOConfig) *shared.Resources { return &c.ReplyAllocationRequestsActionCost },
+	"request_allocation_request": func(c *config.IIGOConfig) *shared.Resources { return &c.RequestAllocationRequestActionCost },
+	"request_rule_proposal":      func(c *config.IIGOConfig) *shared.Resources { return &c.RequestRuleProposalActionCost },
+	"appoint_next_speaker":       func(c *config.IIGOConfig) *shared.Resources { return &c.AppointNextSpeakerActionCost },
+	"inspect_history":            func(c *config.IIGOConfig) *shared.Resources { return &c.InspectHistoryActionCost },
+	"historical_retribution":     func(c *config.IIGOConfig) *shared.Resources { return &c.HistoricalRetributionActionCost },
+	"inspect_ballot":             func(c *config.IIGOConfig) *shared.Resources { return &c.InspectBallotActionCost },
+	"inspect_allocation":         func(c *config.IIGOConfig) *shared.Resources { return &c.InspectAllocationActionCost },
+	"appoint_next_president":     func(c *config.IIGOConfig) *shared.Resources { return &c.AppointNextPresidentActionCost },
+	"set_voting_result":          func(c *config.IIGOConfig) *shared.Resources { return &c.SetVotingResultActionCost },
+	"set_rule_to_vote":           func(c *config.IIGOConfig) *shared.Resources { return &c.SetRuleToVoteActionCost },
+	"announce_voting_result":     func(c *config.IIGOConfig) *shared.Resources { return &c.AnnounceVotingResultActionCost },
+	"update_rules":               func(c *config.IIGOConfig) *shared.Resources { return &c.UpdateRulesActionCost },
+	"appoint_next_judge":         func(c *config.IIGOConfig) *shared.Resources { return &c.AppointNextJudgeActionCost },
+}
+
+func governedIIGOParameters() map[string]governedParameter {
+	parameters := map[string]governedParameter{
+		"iigo_term_length_president":            termLengthParameter(shared.President),
+		"iigo_term_length_speaker":              termLengthParameter(shared.Speaker),
+		"iigo_term_length_judge":                termLengthParameter(shared.Judge),
+		"iigo_election_voting_method_president": votingMethodParameter(shared.President),
+		"iigo_election_voting_method_speaker":   votingMethodParameter(shared.Speaker),
+		"iigo_election_voting_method_judge":     votingMethodParameter(shared.Judge),
+		"iigo_rule_vote_majority": {
+			get: func(c *config.IIGOConfig) float64 { return c.RuleVoteMajority },
+			set: func(c *config.IIGOConfig, value float64) { c.RuleVoteMajority = value },
+			valid: func(value float64) bool {
+				return value >= 0 && value <= 1
+			},
+		},
+	}
+	for _, action := range rules.IIGOActions {
+		if cost, ok := actionCosts[action]; ok {
+			parameters[rules.ActionCostRuleName(action)] = actionCostParameter(cost)
+		}
+	}
+	return parameters
+}
+
+func actionCostParameter(cost func(c *config.IIGOConfig) *shared.Resources) governedParameter {
+	return governedParameter{
+		get: func(c *config.IIGOConfig) float64 { return float64(*cost(c)) },
+		set: func(c *config.IIGOConfig, value float64) { *cost(c) = shared.Resources(value) },
+		valid: func(value float64) bool {
+			return value >= 0
+		},
+	}
+}
+
+// votingMethodParameter holds the voting method of the elections for role, or -1 if the role running them chooses it
+func votingMethodParameter(role shared.Role) governedParameter {
+	return governedParameter{
+		get: func(c *config.IIGOConfig) float64 {
+			if method, ok := c.ElectionVotingMethods[role]; ok {
+				return float64(method)
+			}
+			return -1
+		},
+		set: func(c *config.IIGOConfig, value float64) {
+			// the map may be shared with other copies of the config
+			methods := make(map[shared.Role]shared.ElectionVotingMethod, len(c.ElectionVotingMethods))
+			for r, method := range c.ElectionVotingMethods {
+				methods[r] = method
+			}
+			if value < 0 {
+				delete(methods, role)
+			} else {
+				methods[role] = shared.ElectionVotingMethod(value)
+			}
+			c.ElectionVotingMethods = methods
+		},
+		valid: func(value float64) bool {
+			return value >= -1 && value <= float64(shared.Approval) && value == math.Trunc(value)
+		},
+	}
+}
+
+func termLengthParameter(role shared.Role) governedParameter {
+	return governedParameter{
+		get: func(c *config.IIGOConfig) float64 { return float64(c.IIGOTermLengths[role]) },
+		set: func(c *config.IIGOConfig, value float64) {
+			// the map may be shared with other copies of the config
+			termLengths := make(map[shared.Role]uint, len(c.IIGOTermLengths))
+			for r, length := range c.IIGOTermLengths {
+				termLengths[r] = length
+			}
+			termLengths[role] = uint(value)
+			c.IIGOTermLengths = termLengths
+		},
+		valid: func(value float64) bool {
+			return value >= 0 && value == math.Trunc(value)
+		},
+	}
+}
+
+// SetGovernedRules writes the initial values of the governed IIGO config parameters into the rules holding them
+func SetGovernedRules(availableRules map[string]rules.RuleMatrix, rulesInPlay map[string]rules.RuleMatrix, conf *config.IIGOConfig) {
+	for ruleName, parameter := range governedParameters {
+		value := parameter.get(conf)
+		if ruleMatrix, ok := availableRules[ruleName]; ok {
+			availableRules[ruleName] = withRuleParameter(ruleMatrix, value)
+		}
+		if ruleMatrix, ok := rulesInPlay[ruleName]; ok {
+			rulesInPlay[ruleName] = withRuleParameter(ruleMatrix, value)
+		}
+	}
+}
+
+// applyGovernedParameters updates the IIGO config with the values of the governed rules in play. Parameters whose
+// rule is out of play keep their last value.
+func applyGovernedParameters(g *gamestate.GameState, conf *config.IIGOConfig, logger shared.Logger) {
+	ruleNames := make([]string, 0, len(governedParameters))
+	for ruleName := range governedParameters {
+		ruleNames = append(ruleNames, ruleName)
+	}
+	sort.Strings(ruleNames)
+
+	for _, ruleName := range ruleNames {
+		ruleMatrix, ok := g.RulesInfo.CurrentRulesInPlay[ruleName]
+		if !ok {
+			continue
+		}
+		parameter := governedParameters[ruleName]
+		value := ruleParameter(ruleMatrix)
+		if !parameter.valid(value) {
+			logger("Ignoring invalid value %v of governed rule %v", value, ruleName)
+			continue
+		}
+		if value != parameter.get(conf) {
+			logger("Rule %v changes its IIGO parameter from %v to %v", ruleName, parameter.get(conf), value)
+			parameter.set(conf, value)
+		}
+	}
+}
+
+// ruleParameter returns the constant of the first row of the rule matrix
+func ruleParameter(ruleMatrix rules.RuleMatrix) float64 {
+	rows, cols := ruleMatrix.ApplicableMatrix.Dims()
+	if rows == 0 || cols == 0 {
+		return math.NaN()
+	}
+	return ruleMatrix.ApplicableMatrix.At(0, cols-1)
+}
+
+// withRuleParameter returns a copy of the rule with value as the constant of its first row
+func withRuleParameter(ruleMatrix rules.RuleMatrix, value float64) rules.RuleMatrix {
+	ret := rules.CopyRuleMatrices([]rules.RuleMatrix{ruleMatrix})[0]
+	rows, cols := ret.ApplicableMatrix.Dims()
+	if rows > 0 && cols > 0 {
+		ret.ApplicableMatrix.Set(0, cols-1, value)
+	}
+	return ret
+}
@@ -0,0 +1,163 @@
+package iigointernal
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/gamestate"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/rules"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+func TestApplyGovernedParameters(t *testing.T) {
+	initialConf := config.IIGOConfig{
+		IIGOTermLengths: map[shared.Role]uint{
+			shared.President: 4,
+			shared.Speaker:   4,
+			shared.Judge:     4,
+		},
+		ElectionVotingMethods: map[shared.Role]shared.ElectionVotingMethod{
+			shared.Judge: shared.Runoff,
+		},
+		BroadcastTaxationActionCost: 10,
+		RuleVoteMajority:            0.5,
+	}
+	cases := []struct {
+		name       string
+		ruleValues map[string]float64
+		outOfPlay  []string
+		expected   config.IIGOConfig
+	}{
+		{
+			name:     "Initial values unchanged",
+			expected: initialConf,
+		},
+		{
+			name: "Voted values applied",
+			ruleValues: map[string]float64{
+				"iigo_term_length_president": 6,
+				"iigo_rule_vote_majority":    0.75,
+			},
+			expected: config.IIGOConfig{
+				IIGOTermLengths: map[shared.Role]uint{
+					shared.President: 6,
+					shared.Speaker:   4,
+					shared.Judge:     4,
+				},
+				ElectionVotingMethods:       initialConf.ElectionVotingMethods,
+				BroadcastTaxationActionCost: 10,
+				RuleVoteMajority:            0.75,
+			},
+		},
+		{
+			name: "Cost of government reformed",
+			ruleValues: map[string]float64{
+				"iigo_action_cost_broadcast_taxation": 2,
+			},
+			expected: config.IIGOConfig{
+				IIGOTermLengths:             initialConf.IIGOTermLengths,
+				ElectionVotingMethods:       initialConf.ElectionVotingMethods,
+				BroadcastTaxationActionCost: 2,
+				RuleVoteMajority:            0.5,
+			},
+		},
+		{
+			name: "Election voting methods fixed and released",
+			ruleValues: map[string]float64{
+				"iigo_election_voting_method_speaker": float64(shared.Approval),
+				"iigo_election_voting_method_judge":   -1,
+			},
+			expected: config.IIGOConfig{
+				IIGOTermLengths: initialConf.IIGOTermLengths,
+				ElectionVotingMethods: map[shared.Role]shared.ElectionVotingMethod{
+					shared.Speaker: shared.Approval,
+				},
+				BroadcastTaxationActionCost: 10,
+				RuleVoteMajority:            0.5,
+			},
+		},
+		{
+			name: "Invalid values ignored",
+			ruleValues: map[string]float64{
+				"iigo_term_length_judge":                2.5,
+				"iigo_rule_vote_majority":               1.5,
+				"iigo_election_voting_method_president": 7,
+			},
+			expected: initialConf,
+		},
+		{
+			name: "Rules out of play ignored",
+			ruleValues: map[string]float64{
+				"iigo_term_length_speaker": 1,
+			},
+			outOfPlay: []string{"iigo_term_length_speaker"},
+			expected:  initialConf,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			conf := initialConf
+			availableRules, rulesInPlay := rules.InitialRuleRegistration(true)
+			SetGovernedRules(availableRules, rulesInPlay, &conf)
+			for ruleName, value := range tc.ruleValues {
+				rulesInPlay[ruleName] = withRuleParameter(rulesInPlay[ruleName], value)
+			}
+			for _, ruleName := range tc.outOfPlay {
+				delete(rulesInPlay, ruleName)
+			}
+			fakeGameState := &gamestate.GameState{
+				RulesInfo: gamestate.RulesContext{
+					AvailableRules:     availableRules,
+					CurrentRulesInPlay: rulesInPlay,
+				},
+			}
+
+			applyGovernedParameters(fakeGameState, &conf, func(format string, a ...interface{}) {})
+
+			if !reflect.DeepEqual(tc.expected, conf) {
+				t.Errorf("Expected config %v got %v", tc.expected, conf)
+			}
+			// Clients see the current values
+			clientConf := config.Config{IIGOConfig: conf}.GetClientConfig().IIGOClientConfig
+			if !reflect.DeepEqual(tc.expected, clientConf) {
+				t.Errorf("Expected client config %v got %v", tc.expected, clientConf)
+			}
+			// The initial config must not be changed through the shared maps
+			if initialConf.IIGOTermLengths[shared.President] != 4 || initialConf.ElectionVotingMethods[shared.Judge] != shared.Runoff {
+				t.Errorf("Initial config was modified: %v", initialConf)
+			}
+		})
+	}
+}
+
+func TestSetGovernedRules(t *testing.T) {
+	conf := config.IIGOConfig{
+		IIGOTermLengths:          map[shared.Role]uint{shared.President: 7, shared.Speaker: 3, shared.Judge: 5},
+		ElectionVotingMethods:    map[shared.Role]shared.ElectionVotingMethod{shared.Speaker: shared.InstantRunoff},
+		InspectHistoryActionCost: 4,
+		RuleVoteMajority:         0.6,
+	}
+	availableRules, rulesInPlay := rules.InitialRuleRegistration(true)
+	SetGovernedRules(availableRules, rulesInPlay, &conf)
+
+	expected := map[string]float64{
+		"iigo_term_length_president":       7,
+		"iigo_term_length_speaker":         3,
+		"iigo_term_length_judge":           5,
+		"iigo_rule_vote_majority":          0.6,
+		"iigo_action_cost_inspect_history": 4,
+		"iigo_action_cost_update_rules":    0,
+		// The President's election is left to the Judge
+		"iigo_election_voting_method_president": -1,
+		"iigo_election_voting_method_speaker":   float64(shared.InstantRunoff),
+	}
+	for ruleName, value := range expected {
+		if got := ruleParameter(availableRules[ruleName]); got != value {
+			t.Errorf("Expected available %v to hold %v got %v", ruleName, value, got)
+		}
+		if got := ruleParameter(rulesInPlay[ruleName]); got != value {
+			t.Errorf("Expected %v in play to hold %v got %v", ruleName, value, got)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package iigointernal
+
+import (
+	"github.com/SOMAS2020/SOMAS2020/internal/common/baseclient"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/gamestate"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+// delegableVotes are the kinds of votes islands can delegate
+var delegableVotes = []shared.VoteKind{shared.RuleVotes, shared.ElectionVotes}
+
+// updateVoteDelegations asks every alive island who it delegates its votes to. Delegations stay in place
+// from turn to turn until the island revokes them, and the delegations of dead islands are dropped.
+func updateVoteDelegations(g *gamestate.GameState, clients map[shared.ClientID]baseclient.Client, aliveClientIDs []shared.ClientID) {
+	updated := map[shared.VoteKind]map[shared.ClientID]shared.ClientID{}
+	for _, kind := range delegableVotes {
+		updated[kind] = map[shared.ClientID]shared.ClientID{}
+	}
+	for _, island := range aliveClientIDs {
+		client, ok := clients[island]
+		if !ok {
+			continue
+		}
+		current := map[shared.VoteKind]shared.ClientID{}
+		for kind, graph := range g.IIGOVoteDelegations {
+			if delegate, delegated := graph[island]; delegated {
+				current[kind] = delegate
+			}
+		}
+		for kind, delegate := range client.DelegateVotes(current) {
+			if graph, delegable := updated[kind]; delegable && delegate != island {
+				graph[island] = delegate
+			}
+		}
+	}
+	g.IIGOVoteDelegations = updated
+}
+
+// voteDelegations returns a copy of the delegation graph for a kind of vote
+func voteDelegations(g *gamestate.GameState, kind shared.VoteKind) map[shared.ClientID]shared.ClientID {
+	ret := map[shared.ClientID]shared.ClientID{}
+	for island, delegate := range g.IIGOVoteDelegations[kind] {
+		ret[island] = delegate
+	}
+	return ret
+}
@@ -0,0 +1,59 @@
+package iigointernal
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/baseclient"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/gamestate"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+type mockDelegatingClient struct {
+	*baseclient.BaseClient
+	delegations map[shared.VoteKind]shared.ClientID
+	keep        bool
+}
+
+func (c *mockDelegatingClient) DelegateVotes(current map[shared.VoteKind]shared.ClientID) map[shared.VoteKind]shared.ClientID {
+	if c.keep {
+		return current
+	}
+	return c.delegations
+}
+
+func TestUpdateVoteDelegations(t *testing.T) {
+	fakeGameState := &gamestate.GameState{
+		IIGOVoteDelegations: map[shared.VoteKind]map[shared.ClientID]shared.ClientID{
+			shared.RuleVotes:     {shared.Team1: shared.Team2, shared.Team2: shared.Team3, shared.Team6: shared.Team1},
+			shared.ElectionVotes: {shared.Team1: shared.Team3},
+		},
+	}
+	clients := map[shared.ClientID]baseclient.Client{
+		// Team1 keeps its delegations
+		shared.Team1: &mockDelegatingClient{BaseClient: baseclient.NewClient(shared.Team1), keep: true},
+		// Team2 revokes its delegation
+		shared.Team2: &mockDelegatingClient{BaseClient: baseclient.NewClient(shared.Team2)},
+		// Team3 delegates its election votes, self delegations are ignored
+		shared.Team3: &mockDelegatingClient{
+			BaseClient:  baseclient.NewClient(shared.Team3),
+			delegations: map[shared.VoteKind]shared.ClientID{shared.ElectionVotes: shared.Team4, shared.RuleVotes: shared.Team3},
+		},
+		shared.Team4: &mockDelegatingClient{BaseClient: baseclient.NewClient(shared.Team4)},
+	}
+	// Team6 is dead so its delegation is dropped
+	aliveClientIDs := []shared.ClientID{shared.Team1, shared.Team2, shared.Team3, shared.Team4}
+
+	updateVoteDelegations(fakeGameState, clients, aliveClientIDs)
+
+	expected := map[shared.VoteKind]map[shared.ClientID]shared.ClientID{
+		shared.RuleVotes:     {shared.Team1: shared.Team2},
+		shared.ElectionVotes: {shared.Team1: shared.Team3, shared.Team3: shared.Team4},
+	}
+	if !reflect.DeepEqual(expected, fakeGameState.IIGOVoteDelegations) {
+		t.Errorf("Expected delegations %v got %v", expected, fakeGameState.IIGOVoteDelegations)
+	}
+	if got := voteDelegations(fakeGameState, shared.ElectionVotes); !reflect.DeepEqual(expected[shared.ElectionVotes], got) {
+		t.Errorf("Expected election delegations %v got %v", expected[shared.ElectionVotes], got)
+	}
+}
@@ -163,10 +163,19 @@ func (e *executive) replyAllocationRequest(commonPool shared.Resources) (bool, e
 			e.gameState.IIGOAllocationMade = false
 			return false, errors.Errorf("Insufficient Budget in common Pool: replyAllocationRequest")
 		}
-		e.Logf("Resource Allocation: %v", returnContent.ResourceMap)
-		allocationsMade = true
-		e.gameState.IIGOAllocationMap = returnContent.ResourceMap
+		allocationMap := map[shared.ClientID]shared.Resources{}
 		for islandID, amount := range returnContent.ResourceMap {
+			// Islands whose sanction excludes them from the common pool are allocated nothing
+			if voting.HasSanctionConsequence(islandID, shared.ExcludedFromAllocation, e.gameState, e.gameConf) {
+				e.Logf("%v is excluded from common pool allocations by its sanction", islandID)
+				amount = 0
+			}
+			allocationMap[islandID] = amount
+		}
+		e.Logf("Resource Allocation: %v", allocationMap)
+		allocationsMade = true
+		e.gameState.IIGOAllocationMap = allocationMap
+		for islandID, amount := range allocationMap {
 			e.sendDecision(islandID, amount, shared.IIGOAllocationDecision)
 		}
 	} else {
@@ -197,24 +206,27 @@ func (e *executive) appointNextSpeaker(monitoring shared.MonitorResult, currentS
 		if !e.incurServiceCharge(e.gameConf.AppointNextSpeakerActionCost) {
 			return e.gameState.SpeakerID, errors.Errorf("Insufficient Budget in common Pool: appointNextSpeaker")
 		}
-		election.ProposeElection(shared.Speaker, electionSettings.VotingMethod)
+		election.ProposeElection(shared.Speaker, electionVotingMethod(e.gameConf, shared.Speaker, electionSettings.VotingMethod))
+		election.SetEligibility(e.gameState, e.gameConf)
+		election.SetDelegations(voteDelegations(e.gameState, shared.ElectionVotes))
 		allIslandsCopy2 := copyClientList(allIslands)
 		election.OpenBallot(electionSettings.IslandsToVote, allIslandsCopy2)
 		election.Vote(e.iigoClients)
 		e.gameState.IIGOTurnsInPower[shared.Speaker] = 0
 		electedSpeaker := election.CloseBallot(e.iigoClients)
 		appointedSpeaker = e.clientPresident.DecideNextSpeaker(electedSpeaker)
+		appointmentEligible := checkAppointmentEligibility(e.gameState, e.gameConf, election.GetVotingInfo(), appointedSpeaker)
 
 		//Log rule: Must appoint elected role
 		appointmentMatchesVote := appointedSpeaker == electedSpeaker
-		variablesToCache := []rules.VariableFieldName{rules.AppointmentMatchesVote}
-		valuesToCache := [][]float64{{boolToFloat(appointmentMatchesVote)}}
+		variablesToCache := []rules.VariableFieldName{rules.AppointmentMatchesVote, rules.AppointmentEligible}
+		valuesToCache := [][]float64{{boolToFloat(appointmentMatchesVote)}, {boolToFloat(appointmentEligible)}}
 		e.monitoring.addToCache(e.PresidentID, variablesToCache, valuesToCache)
 		e.Logf("Result of election for new Speaker: %v", appointedSpeaker)
 	} else {
 		appointedSpeaker = currentSpeaker
 	}
-	e.gameState.IIGOElection = append(e.gameState.IIGOElection, election.GetVotingInfo())
+	recordElection(e.gameState, e.gameConf, election.GetVotingInfo(), appointedSpeaker, e.PresidentID)
 	return appointedSpeaker, nil
 }
 
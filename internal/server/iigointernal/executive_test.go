@@ -616,6 +616,8 @@ func TestReplyAllocationRequest(t *testing.T) {
 		bPresident     executive // base
 		commonPool     shared.Resources
 		clientRequests map[shared.ClientID]shared.Resources
+		sanctions      []shared.Sanction
+		ladder         []config.SanctionTierConfig
 		expected       map[shared.ClientID]shared.Resources
 	}{
 		{
@@ -699,6 +701,35 @@ func TestReplyAllocationRequest(t *testing.T) {
 				shared.Team6: 0,
 			},
 		},
+		{
+			name: "Sanctioned island excluded from allocations",
+			bPresident: executive{
+				PresidentID:     2,
+				clientPresident: &baseclient.BasePresident{},
+				gameConf:        &config.IIGOConfig{},
+				logger:          logging,
+				iigoClients:     fakeClientMap,
+			},
+			clientRequests: map[shared.ClientID]shared.Resources{
+				shared.Team1: 5,
+				shared.Team2: 10,
+				shared.Team3: 15,
+			},
+			sanctions: []shared.Sanction{
+				{ClientID: shared.Team1, SanctionTier: shared.SanctionTier1, TurnsLeft: 2},
+				{ClientID: shared.Team2, SanctionTier: shared.SanctionTier2, TurnsLeft: 2},
+			},
+			ladder: []config.SanctionTierConfig{
+				{Threshold: 1},
+				{Threshold: 5, Consequences: []shared.SanctionConsequence{shared.ExcludedFromAllocation}},
+			},
+			commonPool: 150,
+			expected: map[shared.ClientID]shared.Resources{
+				shared.Team1: 5,
+				shared.Team2: 0,
+				shared.Team3: 15,
+			},
+		},
 	}
 
 	rulesInPlay := map[string]rules.RuleMatrix{}
@@ -723,9 +754,11 @@ func TestReplyAllocationRequest(t *testing.T) {
 					shared.Speaker:   10,
 					shared.Judge:     10,
 				},
+				IIGOSanctionCache: map[int][]shared.Sanction{0: tc.sanctions},
 			}
 			fakeGameConfig := config.IIGOConfig{
 				ReplyAllocationRequestsActionCost: 1,
+				SanctionLadder:                    tc.ladder,
 			}
 			fakeServer := fakeServerHandle{
 				PresidentID: tc.bPresident.PresidentID,
@@ -1030,3 +1063,7 @@ func (s fakeServerHandle) GetGameState() gamestate.ClientGameState {
 func (s fakeServerHandle) GetGameConfig() config.ClientConfig {
 	return config.ClientConfig{}
 }
+
+func (s fakeServerHandle) GetReputationLedger() gamestate.ReputationLedger {
+	return gamestate.ReputationLedger{}
+}
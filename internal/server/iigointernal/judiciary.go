@@ -33,11 +33,19 @@ func (j *judiciary) Logf(format string, a ...interface{}) {
 
 // Loads ruleViolationSeverity and sanction thresholds
 func (j *judiciary) loadSanctionConfig() {
-	j.sanctionThresholds = softMergeSanctionThresholds(j.clientJudge.GetSanctionThresholds())
+	j.sanctionThresholds = softMergeSanctionThresholds(j.clientJudge.GetSanctionThresholds(), j.sanctionLadder())
 	j.ruleViolationSeverity = j.clientJudge.GetRuleViolationSeverity()
 	j.broadcastSanctionConfig()
 }
 
+// sanctionLadder returns the sanction ladder in play
+func (j *judiciary) sanctionLadder() []config.SanctionTierConfig {
+	if j.gameConf == nil {
+		return config.DefaultSanctionLadder()
+	}
+	return j.gameConf.GetSanctionLadder()
+}
+
 func (j *judiciary) syncWithGame(gameState *gamestate.GameState, gameConf *config.IIGOConfig) {
 	j.gameState = gameState
 	j.gameConf = gameConf
@@ -156,6 +164,50 @@ func (j *judiciary) inspectHistory(iigoHistory []shared.Accountability) (map[sha
 	return j.evaluationResults, actionTakenByClient
 }
 
+// recountElections re-tallies the recorded ballots of the elections held at the end of the previous
+// turn. Any appointment that did not match the true winner is flagged as a breach by the appointing role.
+func (j *judiciary) recountElections() error {
+	electionsHeld := []shared.Role{}
+	for _, votingInfo := range j.gameState.IIGOElection {
+		if electionHeld(votingInfo) {
+			electionsHeld = append(electionsHeld, votingInfo.RoleToElect)
+		}
+	}
+	if len(electionsHeld) == 0 || !j.clientJudge.CallElectionRecount(electionsHeld) {
+		return nil
+	}
+	if !j.incurServiceCharge(j.gameConf.InspectBallotActionCost) {
+		return errors.Errorf("Insufficient Budget in common Pool: recountElections")
+	}
+
+	for _, votingInfo := range j.gameState.IIGOElection {
+		if !electionHeld(votingInfo) {
+			continue
+		}
+		recountedWinner, trace := voting.Recount(votingInfo, j.logger)
+		recount := gamestate.ElectionRecount{
+			RoleToElect:           votingInfo.RoleToElect,
+			RecountedWinner:       recountedWinner,
+			ElectedWinner:         votingInfo.ElectedWinner,
+			AppointedWinner:       votingInfo.AppointedWinner,
+			AppointedBy:           votingInfo.AppointedBy,
+			TallyMatches:          recountedWinner == votingInfo.ElectedWinner,
+			AppointmentOverridden: recountedWinner != votingInfo.AppointedWinner,
+			CountingTrace:         trace,
+		}
+		j.gameState.IIGOElectionRecounts = append(j.gameState.IIGOElectionRecounts, recount)
+
+		if recount.AppointmentOverridden {
+			//Log rule: Must appoint elected role, checked when the appointing island is monitored
+			variablesToCache := []rules.VariableFieldName{rules.AppointmentMatchesVote}
+			valuesToCache := [][]float64{{boolToFloat(false)}}
+			j.monitoring.addToCache(votingInfo.AppointedBy, variablesToCache, valuesToCache)
+			j.Logf("Recount for %v: %v won but %v appointed %v", votingInfo.RoleToElect, recountedWinner, votingInfo.AppointedBy, votingInfo.AppointedWinner)
+		}
+	}
+	return nil
+}
+
 // searchForRule searches for a given rule in the RuleMatrix
 func searchForRule(ruleName string, listOfRuleMatrices []rules.RuleMatrix) (int, bool) {
 	for i, v := range listOfRuleMatrices {
@@ -185,24 +237,27 @@ func (j *judiciary) appointNextPresident(monitoring shared.MonitorResult, curren
 		if !j.incurServiceCharge(j.gameConf.InspectHistoryActionCost) {
 			return j.gameState.PresidentID, errors.Errorf("Insufficient Budget in common Pool: appointNextPresident")
 		}
-		election.ProposeElection(shared.President, electionSettings.VotingMethod)
+		election.ProposeElection(shared.President, electionVotingMethod(j.gameConf, shared.President, electionSettings.VotingMethod))
+		election.SetEligibility(j.gameState, j.gameConf)
+		election.SetDelegations(voteDelegations(j.gameState, shared.ElectionVotes))
 		allIslandsCopy2 := copyClientList(allIslands)
 		election.OpenBallot(electionSettings.IslandsToVote, allIslandsCopy2)
 		election.Vote(j.iigoClients)
 		j.gameState.IIGOTurnsInPower[shared.President] = 0
 		electedPresident := election.CloseBallot(j.iigoClients)
 		appointedPresident = j.clientJudge.DecideNextPresident(electedPresident)
+		appointmentEligible := checkAppointmentEligibility(j.gameState, j.gameConf, election.GetVotingInfo(), appointedPresident)
 
 		//Log rule: Must appoint elected role
 		appointmentMatchesVote := appointedPresident == electedPresident
-		variablesToCache := []rules.VariableFieldName{rules.AppointmentMatchesVote}
-		valuesToCache := [][]float64{{boolToFloat(appointmentMatchesVote)}}
+		variablesToCache := []rules.VariableFieldName{rules.AppointmentMatchesVote, rules.AppointmentEligible}
+		valuesToCache := [][]float64{{boolToFloat(appointmentMatchesVote)}, {boolToFloat(appointmentEligible)}}
 		j.monitoring.addToCache(j.JudgeID, variablesToCache, valuesToCache)
 		j.Logf("Result of election for new President: %v", appointedPresident)
 	} else {
 		appointedPresident = currentPresident
 	}
-	j.gameState.IIGOElection = append(j.gameState.IIGOElection, election.GetVotingInfo())
+	recordElection(j.gameState, j.gameConf, election.GetVotingInfo(), appointedPresident, j.JudgeID)
 	return appointedPresident, nil
 }
 
@@ -236,18 +291,22 @@ func (j *judiciary) scoreIslandTransgressions(transgressions map[shared.ClientID
 	for islandID, rulesBroken := range transgressions {
 		totalIslandTurnScore := shared.IIGOSanctionsScore(0)
 		for _, ruleBroken := range rulesBroken {
-			if score, ok := j.ruleViolationSeverity[ruleBroken]; ok {
-				totalIslandTurnScore += score
-			} else {
-				totalIslandTurnScore += j.gameConf.DefaultSanctionScore
-			}
+			totalIslandTurnScore += j.ruleScore(ruleBroken)
 			j.Logf("Rule: %v, broken by: %v", ruleBroken, islandID)
 		}
 		j.sanctionRecord[islandID] += totalIslandTurnScore
 	}
 }
 
-// applySanctions uses RulesInPlay and it's versions of the sanction rules to work out how much to sanction an island
+// ruleScore returns the sanction score for breaking rule, using the DefaultSanctionScore if the Judge has not set one
+func (j *judiciary) ruleScore(rule string) shared.IIGOSanctionsScore {
+	if score, ok := j.ruleViolationSeverity[rule]; ok {
+		return score
+	}
+	return j.gameConf.DefaultSanctionScore
+}
+
+// applySanctions places each island in a tier of the sanction ladder, which sets how long its sanction lasts
 func (j *judiciary) applySanctions() {
 	j.cycleSanctionCache(int(j.gameConf.SanctionCacheDepth))
 	var currentSanctions []shared.Sanction
@@ -256,7 +315,7 @@ func (j *judiciary) applySanctions() {
 		sanctionEntry := shared.Sanction{
 			ClientID:     islandID,
 			SanctionTier: islandSanctionTier,
-			TurnsLeft:    int(j.gameConf.SanctionLength),
+			TurnsLeft:    int(j.sanctionDuration(islandSanctionTier)),
 		}
 		currentSanctions = append(currentSanctions, sanctionEntry)
 		broadcastToAllIslands(j.iigoClients, j.JudgeID, createBroadcastForSanction(islandID, islandSanctionTier), *j.gameState)
@@ -264,6 +323,14 @@ func (j *judiciary) applySanctions() {
 	j.gameState.IIGOSanctionCache[0] = currentSanctions
 }
 
+// sanctionDuration returns the number of turns a sanction of the given tier lasts
+func (j *judiciary) sanctionDuration(tier shared.IIGOSanctionsTier) uint {
+	if tierConfig, ok := j.gameConf.GetSanctionTier(tier); ok && tierConfig.Duration > 0 {
+		return tierConfig.Duration
+	}
+	return j.gameConf.SanctionLength
+}
+
 // sanctionEvaluate allows the clients to effectively pardon islands, levy and communicate sanctions
 func (j *judiciary) sanctionEvaluate(reportedIslandResources map[shared.ClientID]shared.ResourcesReport) {
 	pardons := j.clientJudge.GetPardonedIslands(j.gameState.IIGOSanctionCache)
@@ -272,7 +339,7 @@ func (j *judiciary) sanctionEvaluate(reportedIslandResources map[shared.ClientID
 		broadcastPardonCommunications(j.iigoClients, j.JudgeID, communications, *j.gameState)
 	}
 	j.gameState.IIGOSanctionCache = newSanctionMap
-	totalSanctionPerAgent := runEvaluationRulesOnSanctions(j.gameState.IIGOSanctionCache, reportedIslandResources, j.gameState.RulesInfo.CurrentRulesInPlay, j.gameConf.AssumedResourcesNoReport)
+	totalSanctionPerAgent := runEvaluationRulesOnSanctions(j.gameState.IIGOSanctionCache, reportedIslandResources, j.gameState.RulesInfo.CurrentRulesInPlay, j.gameConf.AssumedResourcesNoReport, j.sanctionLadder())
 	j.gameState.IIGOSanctionMap = totalSanctionPerAgent
 	for clientID, sanctionedResources := range totalSanctionPerAgent {
 		communicateWithIslands(j.iigoClients, clientID, j.JudgeID, map[shared.CommunicationFieldName]shared.CommunicationContent{
@@ -364,17 +431,24 @@ func createBroadcastsForRuleViolationPenalties(penalties map[string]shared.IIGOS
 	return outputBroadcast
 }
 
-// runEvaluationRulesOnSanctions uses the custom sanction evaluator calculate how much each island should be paying in sanctions
-func runEvaluationRulesOnSanctions(localSanctionCache map[int][]shared.Sanction, reportedIslandResources map[shared.ClientID]shared.ResourcesReport, rulesCache map[string]rules.RuleMatrix, maxNoReport shared.Resources) map[shared.ClientID]shared.Resources {
+// runEvaluationRulesOnSanctions works out how much each island should be paying in sanctions. Tiers of the sanction
+// ladder with a penalty rule use the custom sanction evaluator on the rule, if it is in play, and the others use their
+// penalty formula.
+func runEvaluationRulesOnSanctions(localSanctionCache map[int][]shared.Sanction, reportedIslandResources map[shared.ClientID]shared.ResourcesReport, rulesCache map[string]rules.RuleMatrix, maxNoReport shared.Resources, ladder []config.SanctionTierConfig) map[shared.ClientID]shared.Resources {
 	totalSanctionPerAgent := map[shared.ClientID]shared.Resources{}
 	for _, sanctionList := range localSanctionCache {
 		for _, sanction := range sanctionList {
-			ruleName := getTierSanctionMap()[sanction.SanctionTier]
-			if ruleMat, ok := rulesCache[ruleName]; ok {
-				resources := maxNoReport
-				if reportedIslandResources[sanction.ClientID].Reported {
-					resources = reportedIslandResources[sanction.ClientID].ReportedAmount
-				}
+			if sanction.SanctionTier < 0 || int(sanction.SanctionTier) >= len(ladder) {
+				continue
+			}
+			tierConfig := ladder[sanction.SanctionTier]
+			resources := maxNoReport
+			if reportedIslandResources[sanction.ClientID].Reported {
+				resources = reportedIslandResources[sanction.ClientID].ReportedAmount
+			}
+			if tierConfig.PenaltyRule == "" {
+				totalSanctionPerAgent[sanction.ClientID] += tierConfig.Penalty(resources, sanction.TurnsLeft)
+			} else if ruleMat, ok := rulesCache[tierConfig.PenaltyRule]; ok {
 				sanctionVal := evaluateSanction(ruleMat, map[rules.VariableFieldName]rules.VariableValuePair{
 					rules.IslandReportedResources: {
 						VariableName: rules.IslandReportedResources,
@@ -382,7 +456,7 @@ func runEvaluationRulesOnSanctions(localSanctionCache map[int][]shared.Sanction,
 					},
 					rules.ConstSanctionAmount: {
 						VariableName: rules.ConstSanctionAmount,
-						Values:       []float64{0},
+						Values:       []float64{float64(tierConfig.PenaltyConstant)},
 					},
 					rules.TurnsLeftOnSanction: {
 						VariableName: rules.TurnsLeftOnSanction,
@@ -413,20 +487,23 @@ func decrementSanctionTime(sanctions map[int][]shared.Sanction) (updatedSanction
 	return sanctions
 }
 
-// getDefaultSanctionThresholds provides default thresholds for sanctions
+// getDefaultSanctionThresholds provides the thresholds of the default sanction ladder
 func getDefaultSanctionThresholds() map[shared.IIGOSanctionsTier]shared.IIGOSanctionsScore {
-	return map[shared.IIGOSanctionsTier]shared.IIGOSanctionsScore{
-		shared.SanctionTier1: 1,
-		shared.SanctionTier2: 5,
-		shared.SanctionTier3: 10,
-		shared.SanctionTier4: 20,
-		shared.SanctionTier5: 30,
+	return getLadderSanctionThresholds(config.DefaultSanctionLadder())
+}
+
+// getLadderSanctionThresholds provides the thresholds set for each tier of a sanction ladder
+func getLadderSanctionThresholds(ladder []config.SanctionTierConfig) map[shared.IIGOSanctionsTier]shared.IIGOSanctionsScore {
+	thresholds := map[shared.IIGOSanctionsTier]shared.IIGOSanctionsScore{}
+	for i, tierConfig := range ladder {
+		thresholds[shared.IIGOSanctionsTier(i)] = tierConfig.Threshold
 	}
+	return thresholds
 }
 
-// softMergeSanctionThresholds merges the default sanction thresholds with a (preferred) client version
-func softMergeSanctionThresholds(clientSanctionMap map[shared.IIGOSanctionsTier]shared.IIGOSanctionsScore) map[shared.IIGOSanctionsTier]shared.IIGOSanctionsScore {
-	outputMap := getDefaultSanctionThresholds()
+// softMergeSanctionThresholds merges the thresholds of the sanction ladder with a (preferred) client version
+func softMergeSanctionThresholds(clientSanctionMap map[shared.IIGOSanctionsTier]shared.IIGOSanctionsScore, ladder []config.SanctionTierConfig) map[shared.IIGOSanctionsTier]shared.IIGOSanctionsScore {
+	outputMap := getLadderSanctionThresholds(ladder)
 	for k := range outputMap {
 		if clientVal, ok := clientSanctionMap[k]; ok {
 			outputMap[k] = clientVal
@@ -435,24 +512,17 @@ func softMergeSanctionThresholds(clientSanctionMap map[shared.IIGOSanctionsTier]
 	if checkMonotonicityOfSanctionThresholds(outputMap) {
 		return outputMap
 	} else {
-		return getDefaultSanctionThresholds()
+		return getLadderSanctionThresholds(ladder)
 	}
 }
 
 func checkMonotonicityOfSanctionThresholds(clientSanctionMap map[shared.IIGOSanctionsTier]shared.IIGOSanctionsScore) bool {
-	sanctionsInOrder := []shared.IIGOSanctionsTier{
-		shared.SanctionTier1,
-		shared.SanctionTier2,
-		shared.SanctionTier3,
-		shared.SanctionTier4,
-		shared.SanctionTier5,
-	}
 	hold := shared.IIGOSanctionsScore(0)
-	for _, v := range sanctionsInOrder {
-		if clientSanctionMap[v] < hold {
+	for tier := shared.SanctionTier1; int(tier) < len(clientSanctionMap); tier++ {
+		if clientSanctionMap[tier] < hold {
 			return false
 		}
-		hold = clientSanctionMap[v]
+		hold = clientSanctionMap[tier]
 	}
 	return true
 }
@@ -468,32 +538,16 @@ func unpackSingleIslandTransgressions(evaluationReturn shared.EvaluationReturn)
 	return transgressions
 }
 
-// getIslandSanctionTier if statement based evaluator for which sanction tier a particular score is in
+// getIslandSanctionTier returns the highest tier whose threshold a particular score reaches
 func getIslandSanctionTier(islandScore shared.IIGOSanctionsScore, scoreMap map[shared.IIGOSanctionsTier]shared.IIGOSanctionsScore) shared.IIGOSanctionsTier {
-	if islandScore < scoreMap[shared.SanctionTier1] {
-		return shared.NoSanction
-	} else if islandScore < scoreMap[shared.SanctionTier2] {
-		return shared.SanctionTier1
-	} else if islandScore < scoreMap[shared.SanctionTier3] {
-		return shared.SanctionTier2
-	} else if islandScore < scoreMap[shared.SanctionTier4] {
-		return shared.SanctionTier3
-	} else if islandScore < scoreMap[shared.SanctionTier5] {
-		return shared.SanctionTier4
-	} else {
-		return shared.SanctionTier5
-	}
-}
-
-// getTierSanctionMap basic mapping between sanction tier and rule that governs it
-func getTierSanctionMap() map[shared.IIGOSanctionsTier]string {
-	return map[shared.IIGOSanctionsTier]string{
-		shared.SanctionTier1: "iigo_economic_sanction_1",
-		shared.SanctionTier2: "iigo_economic_sanction_2",
-		shared.SanctionTier3: "iigo_economic_sanction_3",
-		shared.SanctionTier4: "iigo_economic_sanction_4",
-		shared.SanctionTier5: "iigo_economic_sanction_5",
+	islandTier := shared.NoSanction
+	for tier := shared.SanctionTier1; int(tier) < len(scoreMap); tier++ {
+		if islandScore < scoreMap[tier] {
+			break
+		}
+		islandTier = tier
 	}
+	return islandTier
 }
 
 func implementPardons(sanctionCache map[int][]shared.Sanction, pardons map[int][]bool, allTeamIds [len(shared.TeamIDs)]shared.ClientID) (bool, map[int][]shared.Sanction, map[shared.ClientID][]map[shared.CommunicationFieldName]shared.CommunicationContent) {
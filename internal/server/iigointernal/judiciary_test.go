@@ -422,6 +422,7 @@ func TestApplySanctions(t *testing.T) {
 		sanctionThresholds map[shared.IIGOSanctionsTier]shared.IIGOSanctionsScore
 		expectedSanctions  []shared.Sanction
 		sanctionLength     int
+		ladder             []config.SanctionTierConfig
 	}{
 		{
 			name: "Basic sanction scenario",
@@ -498,6 +499,28 @@ func TestApplySanctions(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Configured sanction ladder",
+			sanctionRecord: map[shared.ClientID]shared.IIGOSanctionsScore{
+				shared.Team1: 60,
+				shared.Team2: 10,
+			},
+			sanctionThresholds: getLadderSanctionThresholds(testSanctionLadder()),
+			sanctionLength:     3,
+			ladder:             testSanctionLadder(),
+			expectedSanctions: []shared.Sanction{
+				{
+					ClientID:     shared.Team1,
+					SanctionTier: shared.SanctionTier5 + 1,
+					TurnsLeft:    8,
+				},
+				{
+					ClientID:     shared.Team2,
+					SanctionTier: shared.SanctionTier3,
+					TurnsLeft:    3,
+				},
+			},
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -505,6 +528,7 @@ func TestApplySanctions(t *testing.T) {
 			judiciaryInst.sanctionRecord = tc.sanctionRecord
 			judiciaryInst.sanctionThresholds = tc.sanctionThresholds
 			judiciaryInst.gameConf.SanctionLength = uint(tc.sanctionLength)
+			judiciaryInst.gameConf.SanctionLadder = tc.ladder
 			judiciaryInst.applySanctions()
 			if !checkListOfSanctionEquals(tc.expectedSanctions, judiciaryInst.gameState.IIGOSanctionCache[0]) {
 				t.Errorf("Expected %v got %v", tc.expectedSanctions, judiciaryInst.gameState.IIGOSanctionCache[0])
@@ -626,6 +650,7 @@ func TestRunEvaluationRulesOnSanctions(t *testing.T) {
 		localSanctionCache      map[int][]shared.Sanction
 		reportedIslandResources map[shared.ClientID]shared.ResourcesReport
 		rulesCache              map[string]rules.RuleMatrix
+		ladder                  []config.SanctionTierConfig
 		expectedSanctions       map[shared.ClientID]shared.Resources
 	}{
 		{
@@ -800,10 +825,78 @@ func TestRunEvaluationRulesOnSanctions(t *testing.T) {
 				shared.Team6: 0,
 			},
 		},
+		{
+			name: "Evaluations: configured ladder",
+			localSanctionCache: augmentBasicSanctionCache(0, []shared.Sanction{
+				{
+					ClientID:     shared.Team1,
+					SanctionTier: shared.SanctionTier1,
+					TurnsLeft:    2,
+				},
+				{
+					ClientID:     shared.Team2,
+					SanctionTier: shared.SanctionTier2,
+					TurnsLeft:    2,
+				},
+				{
+					ClientID:     shared.Team3,
+					SanctionTier: shared.SanctionTier2,
+					TurnsLeft:    0,
+				},
+				{
+					ClientID:     shared.Team4,
+					SanctionTier: shared.SanctionTier3,
+					TurnsLeft:    2,
+				},
+				{
+					ClientID:     shared.Team5,
+					SanctionTier: shared.NoSanction,
+					TurnsLeft:    2,
+				},
+				{
+					ClientID:     shared.Team6,
+					SanctionTier: shared.SanctionTier2,
+					TurnsLeft:    1,
+				},
+			}),
+			reportedIslandResources: map[shared.ClientID]shared.ResourcesReport{
+				shared.Team1: {
+					ReportedAmount: 50,
+					Reported:       true,
+				},
+				shared.Team2: {
+					ReportedAmount: 40,
+					Reported:       true,
+				},
+				shared.Team3: {
+					ReportedAmount: 40,
+					Reported:       true,
+				},
+				shared.Team4: {
+					ReportedAmount: 40,
+					Reported:       true,
+				},
+			},
+			rulesCache: generateRuleStore(),
+			ladder: []config.SanctionTierConfig{
+				{Threshold: 1, PenaltyRule: "iigo_economic_sanction_2"},
+				{Threshold: 5, PenaltyConstant: 10, PenaltyRate: 0.5},
+			},
+			expectedSanctions: map[shared.ClientID]shared.Resources{
+				shared.Team1: 5,
+				shared.Team2: 30,
+				shared.Team3: 0,
+				shared.Team6: 260,
+			},
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			res := runEvaluationRulesOnSanctions(tc.localSanctionCache, tc.reportedIslandResources, tc.rulesCache, 500)
+			ladder := tc.ladder
+			if ladder == nil {
+				ladder = config.DefaultSanctionLadder()
+			}
+			res := runEvaluationRulesOnSanctions(tc.localSanctionCache, tc.reportedIslandResources, tc.rulesCache, 500, ladder)
 			if !reflect.DeepEqual(res, tc.expectedSanctions) {
 				t.Errorf("Expected %v got %v", tc.expectedSanctions, res)
 			}
@@ -1559,6 +1652,7 @@ func TestSoftMergeSanctionThreshold(t *testing.T) {
 	cases := []struct {
 		name              string
 		clientSanctionMap map[shared.IIGOSanctionsTier]shared.IIGOSanctionsScore
+		ladder            []config.SanctionTierConfig
 		expectedVal       map[shared.IIGOSanctionsTier]shared.IIGOSanctionsScore
 	}{
 		{
@@ -1600,10 +1694,30 @@ func TestSoftMergeSanctionThreshold(t *testing.T) {
 				shared.SanctionTier5: 400,
 			},
 		},
+		{
+			name: "Merge with a configured ladder",
+			clientSanctionMap: map[shared.IIGOSanctionsTier]shared.IIGOSanctionsScore{
+				shared.SanctionTier1:     3,
+				shared.SanctionTier5 + 1: 45,
+			},
+			ladder: testSanctionLadder(),
+			expectedVal: map[shared.IIGOSanctionsTier]shared.IIGOSanctionsScore{
+				shared.SanctionTier1:     3,
+				shared.SanctionTier2:     5,
+				shared.SanctionTier3:     10,
+				shared.SanctionTier4:     20,
+				shared.SanctionTier5:     30,
+				shared.SanctionTier5 + 1: 45,
+			},
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			res := softMergeSanctionThresholds(tc.clientSanctionMap)
+			ladder := tc.ladder
+			if ladder == nil {
+				ladder = config.DefaultSanctionLadder()
+			}
+			res := softMergeSanctionThresholds(tc.clientSanctionMap, ladder)
 			if !reflect.DeepEqual(res, tc.expectedVal) {
 				t.Errorf("Expected final transgressions to be %v got %v", tc.expectedVal, res)
 			}
@@ -1613,6 +1727,16 @@ func TestSoftMergeSanctionThreshold(t *testing.T) {
 
 // Utility functions for testing //
 
+// testSanctionLadder extends the default sanction ladder with a sixth tier
+func testSanctionLadder() []config.SanctionTierConfig {
+	return append(config.DefaultSanctionLadder(), config.SanctionTierConfig{
+		Threshold:    50,
+		PenaltyRate:  0.9,
+		Duration:     8,
+		Consequences: []shared.SanctionConsequence{shared.LoseVotingRight, shared.IneligibleForOffice},
+	})
+}
+
 func generateRuleStore() map[string]rules.RuleMatrix {
 	returnMap := map[string]rules.RuleMatrix{}
 	availableRules := generateDummyRuleMatrices()
@@ -1959,6 +2083,135 @@ func TestJudgeIncureServiceCharge(t *testing.T) {
 	}
 }
 
+// TestRecountElections checks that a recount flags appointments which overrode the winner of the recorded ballots
+func TestRecountElections(t *testing.T) {
+	ballots := [][]shared.ClientID{{shared.Team2, shared.Team1}, {shared.Team2, shared.Team1}, {shared.Team1, shared.Team2}}
+	cases := []struct {
+		name                string
+		clientJudge         roles.Judge
+		elections           []gamestate.VotingInfo
+		expectedRecounts    []gamestate.ElectionRecount
+		expectedCache       []shared.Accountability
+		expectedJudgeBudget shared.Resources
+	}{
+		{
+			name:                "No elections held",
+			clientJudge:         &mockJudge{callElectionRecount: true},
+			elections:           []gamestate.VotingInfo{{}},
+			expectedRecounts:    []gamestate.ElectionRecount{},
+			expectedCache:       []shared.Accountability{},
+			expectedJudgeBudget: 10,
+		},
+		{
+			name:        "Judge declines recount",
+			clientJudge: &mockJudge{callElectionRecount: false},
+			elections: []gamestate.VotingInfo{
+				{
+					RoleToElect:     shared.Judge,
+					VotingMethod:    shared.BordaCount,
+					CandidateList:   []shared.ClientID{shared.Team1, shared.Team2},
+					VoterList:       []shared.ClientID{shared.Team1, shared.Team2, shared.Team3},
+					Votes:           ballots,
+					ElectedWinner:   shared.Team2,
+					AppointedWinner: shared.Team1,
+					AppointedBy:     shared.Team3,
+				},
+			},
+			expectedRecounts:    []gamestate.ElectionRecount{},
+			expectedCache:       []shared.Accountability{},
+			expectedJudgeBudget: 10,
+		},
+		{
+			name:        "Overridden appointment flagged",
+			clientJudge: &mockJudge{callElectionRecount: true},
+			elections: []gamestate.VotingInfo{
+				{
+					RoleToElect:     shared.Judge,
+					VotingMethod:    shared.BordaCount,
+					CandidateList:   []shared.ClientID{shared.Team1, shared.Team2},
+					VoterList:       []shared.ClientID{shared.Team1, shared.Team2, shared.Team3},
+					Votes:           ballots,
+					ElectedWinner:   shared.Team2,
+					AppointedWinner: shared.Team1,
+					AppointedBy:     shared.Team3,
+				},
+				{
+					RoleToElect:     shared.President,
+					VotingMethod:    shared.Approval,
+					CandidateList:   []shared.ClientID{shared.Team1, shared.Team2},
+					VoterList:       []shared.ClientID{shared.Team1, shared.Team2, shared.Team3},
+					Votes:           [][]shared.ClientID{{shared.Team1}, {shared.Team1}, {shared.Team2}},
+					ElectedWinner:   shared.Team1,
+					AppointedWinner: shared.Team1,
+					AppointedBy:     shared.Team2,
+				},
+			},
+			expectedRecounts: []gamestate.ElectionRecount{
+				{
+					RoleToElect:           shared.Judge,
+					RecountedWinner:       shared.Team2,
+					ElectedWinner:         shared.Team2,
+					AppointedWinner:       shared.Team1,
+					AppointedBy:           shared.Team3,
+					TallyMatches:          true,
+					AppointmentOverridden: true,
+				},
+				{
+					RoleToElect:           shared.President,
+					RecountedWinner:       shared.Team1,
+					ElectedWinner:         shared.Team1,
+					AppointedWinner:       shared.Team1,
+					AppointedBy:           shared.Team2,
+					TallyMatches:          true,
+					AppointmentOverridden: false,
+				},
+			},
+			// The breach is blamed on the island which made the appointment
+			expectedCache: []shared.Accountability{
+				{
+					ClientID: shared.Team1,
+					Pairs: []rules.VariableValuePair{
+						{VariableName: rules.JudgeLeftoverBudget, Values: []float64{8}},
+					},
+				},
+				{
+					ClientID: shared.Team3,
+					Pairs: []rules.VariableValuePair{
+						{VariableName: rules.AppointmentMatchesVote, Values: []float64{0}},
+					},
+				},
+			},
+			expectedJudgeBudget: 8,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			judiciaryInst := defaultInitJudiciary()
+			judiciaryInst.clientJudge = tc.clientJudge
+			judiciaryInst.gameConf.InspectBallotActionCost = 2
+			judiciaryInst.gameState.IIGOElection = tc.elections
+			judiciaryInst.gameState.IIGOElectionRecounts = []gamestate.ElectionRecount{}
+			err := judiciaryInst.recountElections()
+			if err != nil {
+				t.Errorf("Unexpected error %v", err)
+			}
+			recounts := judiciaryInst.gameState.IIGOElectionRecounts
+			for i := range recounts {
+				recounts[i].CountingTrace = nil
+			}
+			if !reflect.DeepEqual(tc.expectedRecounts, recounts) {
+				t.Errorf("Expected recounts %v got %v", tc.expectedRecounts, recounts)
+			}
+			if !reflect.DeepEqual(tc.expectedCache, judiciaryInst.gameState.IIGORoleMonitoringCache) {
+				t.Errorf("Expected monitoring cache %v got %v", tc.expectedCache, judiciaryInst.gameState.IIGORoleMonitoringCache)
+			}
+			if judiciaryInst.gameState.IIGORolesBudget[shared.Judge] != tc.expectedJudgeBudget {
+				t.Errorf("Expected judge budget %v got %v", tc.expectedJudgeBudget, judiciaryInst.gameState.IIGORolesBudget[shared.Judge])
+			}
+		})
+	}
+}
+
 func defaultInitJudiciary() judiciary {
 	var logging shared.Logger = func(format string, a ...interface{}) {}
 	gamestate := gamestate.GameState{
@@ -20,6 +20,8 @@ type legislature struct {
 	gameConf      *config.IIGOConfig
 	SpeakerID     shared.ClientID
 	ruleToVote    rules.RuleMatrix
+	bundledRules  []rules.RuleMatrix
+	votingIslands []shared.ClientID
 	ballotBox     voting.BallotBox
 	votingResult  bool
 	clientSpeaker roles.Speaker
@@ -70,33 +72,28 @@ func (l *legislature) sendJudgeSalary() error {
 	return errors.Errorf("Cannot perform sendJudgeSalary")
 }
 
-// Receive a rule to call a vote on
-func (l *legislature) setRuleToVote(ruleMatrix rules.RuleMatrix) error {
-	if !CheckEnoughInCommonPool(l.gameConf.SetRuleToVoteActionCost, l.gameState) {
-		return errors.Errorf("Insufficient Budget in common Pool: setRuleToVote")
-	}
-
+// Receive the President's rule and let the Speaker decide what to queue on the legislative agenda
+func (l *legislature) setRuleToVote(ruleMatrix rules.RuleMatrix) {
 	agendaReturn := l.clientSpeaker.DecideAgenda(ruleMatrix)
 	if agendaReturn.ActionTaken && agendaReturn.ContentType == shared.SpeakerAgenda {
-		if !l.incurServiceCharge(l.gameConf.SetRuleToVoteActionCost) {
-			return errors.Errorf("Insufficient Budget in common Pool: setRuleToVote")
-		}
 		l.ruleToVote = agendaReturn.RuleMatrix
+		l.queueAgendaItem(agendaReturn.RuleMatrix)
 	}
-	return nil
 }
 
-//Asks islands to vote on a rule
+//Asks islands to vote on a rule, which passes if the share of votes in favour reaches majority
 //Called by orchestration
-func (l *legislature) setVotingResult(clientIDs []shared.ClientID) (bool, error) {
+func (l *legislature) setVotingResult(clientIDs []shared.ClientID, majority float64) (bool, error) {
 	voteCalled := false
 	if !CheckEnoughInCommonPool(l.gameConf.SetVotingResultActionCost, l.gameState) {
 		return voteCalled, errors.Errorf("Insufficient Budget in common Pool: announceVotingResult")
 	}
 
-	returnVote := l.clientSpeaker.DecideVote(l.ruleToVote, clientIDs)
-	returnedIslands := make([]shared.ClientID, len(returnVote.ParticipatingIslands))
-	copy(returnedIslands, returnVote.ParticipatingIslands)
+	// Islands whose sanction took away their right to vote are left out of rule votes
+	consequences := voting.SanctionConsequencesInForce(l.gameState, l.gameConf)
+	clientIDs = voting.WithoutSanctionConsequence(clientIDs, shared.LoseVotingRight, consequences)
+	returnVote := l.clientSpeaker.DecideVote(l.ruleToVote, copyClientList(clientIDs))
+	returnedIslands := voting.WithoutSanctionConsequence(returnVote.ParticipatingIslands, shared.LoseVotingRight, consequences)
 	sort.Sort(shared.SortClientByID(returnedIslands))
 	sort.Sort(shared.SortClientByID(clientIDs))
 
@@ -109,16 +106,18 @@ func (l *legislature) setVotingResult(clientIDs []shared.ClientID) (bool, error)
 		if !l.incurServiceCharge(l.gameConf.SetVotingResultActionCost) {
 			return voteCalled, errors.Errorf("Insufficient Budget in common Pool: setVotingResult")
 		}
-		l.ballotBox = l.RunVote(returnVote.RuleMatrix, returnVote.ParticipatingIslands)
+		l.votingIslands = returnedIslands
+		l.ballotBox = l.RunVote(returnVote.RuleMatrix, returnedIslands)
 
-		l.votingResult = l.ballotBox.CountVotesMajority()
+		l.votingResult = l.ballotBox.CountVotesSupermajority(majority)
 		voteCalled = true
 	}
 	return voteCalled, nil
 }
 
 //RunVote creates the voting object, returns votes by category (for, against) in BallotBox.
-//Passing in empty ruleID or empty clientIDs results in no vote occurring
+//Passing in empty ruleID or empty clientIDs results in no vote occurring.
+//A bundle is always voted on as it is on the agenda, so a rule changed by the Speaker is ignored in a bundled vote.
 func (l *legislature) RunVote(ruleMatrix rules.RuleMatrix, clientIDs []shared.ClientID) voting.BallotBox {
 
 	if ruleMatrix.RuleMatrixIsEmpty() || len(clientIDs) == 0 {
@@ -129,23 +128,29 @@ func (l *legislature) RunVote(ruleMatrix rules.RuleMatrix, clientIDs []shared.Cl
 		Logger: l.logger,
 	}
 
+	rulesEqual := false
+	if reflect.DeepEqual(ruleMatrix, l.ruleToVote) {
+		rulesEqual = true
+	}
+	if !rulesEqual && len(l.bundledRules) > 0 {
+		l.Logf("Speaker changed %v to %v in a bundled vote, the bundle is voted on as it is", l.ruleToVote.RuleName, ruleMatrix.RuleName)
+		ruleMatrix = l.ruleToVote
+	}
+
 	//TODO: check if rule is valid, otherwise return empty ballot, raise error?
-	ruleVote.SetRule(ruleMatrix)
+	ruleVote.SetBundle(append([]rules.RuleMatrix{ruleMatrix}, l.bundledRules...))
 
 	//TODO: intersection of islands alive and islands chosen to vote in case of client error
 	//TODO: check if remaining slice is >0, otherwise return empty ballot, raise error?
 	ruleVote.SetVotingIslands(clientIDs)
+	ruleVote.SetDelegations(voteDelegations(l.gameState, shared.RuleVotes))
 
 	ruleVote.GatherBallots(l.iigoClients)
+	l.recordRuleBallots(ruleVote.GetVoterBallots(), append([]rules.RuleMatrix{ruleMatrix}, l.bundledRules...))
 	//TODO: log of vote occurring with ruleMatrix, clientIDs
 	//TODO: log of clientIDs vs islandsAllowedToVote
 	//TODO: log of ruleMatrix vs s.RuleToVote
 
-	rulesEqual := false
-	if reflect.DeepEqual(ruleMatrix, l.ruleToVote) {
-		rulesEqual = true
-	}
-
 	variablesToCache := []rules.VariableFieldName{rules.SpeakerProposedPresidentRule}
 	valuesToCache := [][]float64{{boolToFloat(rulesEqual)}}
 
@@ -154,6 +159,20 @@ func (l *legislature) RunVote(ruleMatrix rules.RuleMatrix, clientIDs []shared.Cl
 	return ruleVote.GetBallotBox()
 }
 
+// recordRuleBallots keeps the ballot of each voter on the rules voted on, for the server to resolve escrowed gifts
+func (l *legislature) recordRuleBallots(voterBallots map[shared.ClientID]shared.RuleVoteType, ruleMatrices []rules.RuleMatrix) {
+	if l.gameState.IIGORuleBallots == nil {
+		l.gameState.IIGORuleBallots = map[string]map[shared.ClientID]shared.RuleVoteType{}
+	}
+	for _, ruleMatrix := range ruleMatrices {
+		ballots := make(map[shared.ClientID]shared.RuleVoteType, len(voterBallots))
+		for voter, ballot := range voterBallots {
+			ballots[voter] = ballot
+		}
+		l.gameState.IIGORuleBallots[ruleMatrix.RuleName] = ballots
+	}
+}
+
 //Speaker declares a result of a vote (see spec to see conditions on what this means for a rule-abiding speaker)
 //Called by orchestration
 func (l *legislature) announceVotingResult() (bool, error) {
@@ -255,24 +274,27 @@ func (l *legislature) appointNextJudge(monitoring shared.MonitorResult, currentJ
 		if !l.incurServiceCharge(l.gameConf.AppointNextJudgeActionCost) {
 			return l.gameState.JudgeID, errors.Errorf("Insufficient Budget in common Pool: appointNextJudge")
 		}
-		election.ProposeElection(shared.Judge, electionSettings.VotingMethod)
+		election.ProposeElection(shared.Judge, electionVotingMethod(l.gameConf, shared.Judge, electionSettings.VotingMethod))
+		election.SetEligibility(l.gameState, l.gameConf)
+		election.SetDelegations(voteDelegations(l.gameState, shared.ElectionVotes))
 		allIslandsCopy2 := copyClientList(allIslands)
 		election.OpenBallot(electionSettings.IslandsToVote, allIslandsCopy2)
 		election.Vote(l.iigoClients)
 		l.gameState.IIGOTurnsInPower[shared.Judge] = 0
 		electedJudge := election.CloseBallot(l.iigoClients)
 		appointedJudge = l.clientSpeaker.DecideNextJudge(electedJudge)
+		appointmentEligible := checkAppointmentEligibility(l.gameState, l.gameConf, election.GetVotingInfo(), appointedJudge)
 
 		//Log rule: Must appoint elected role
 		appointmentMatchesVote := appointedJudge == electedJudge
-		variablesToCache := []rules.VariableFieldName{rules.AppointmentMatchesVote}
-		valuesToCache := [][]float64{{boolToFloat(appointmentMatchesVote)}}
+		variablesToCache := []rules.VariableFieldName{rules.AppointmentMatchesVote, rules.AppointmentEligible}
+		valuesToCache := [][]float64{{boolToFloat(appointmentMatchesVote)}, {boolToFloat(appointmentEligible)}}
 		l.monitoring.addToCache(l.SpeakerID, variablesToCache, valuesToCache)
 		l.Logf("Result of election for new Judge: %v", appointedJudge)
 	} else {
 		appointedJudge = currentJudge
 	}
-	l.gameState.IIGOElection = append(l.gameState.IIGOElection, election.GetVotingInfo())
+	recordElection(l.gameState, l.gameConf, election.GetVotingInfo(), appointedJudge, l.SpeakerID)
 	return appointedJudge, nil
 }
 
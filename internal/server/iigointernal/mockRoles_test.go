@@ -15,6 +15,7 @@ type mockJudge struct {
 	historicalRetribution bool
 	callPresidentElection shared.ElectionSettings
 	decidePresidentResult shared.ClientID
+	callElectionRecount   bool
 }
 
 // GetRuleViolationSeverity returns a custom map of named rules and how severe the sanction should be for transgressing them
@@ -62,3 +63,8 @@ func (j *mockJudge) CallPresidentElection(monitoring shared.MonitorResult, turns
 func (j *mockJudge) DecideNextPresident(winner shared.ClientID) shared.ClientID {
 	return j.decidePresidentResult
 }
+
+// CallElectionRecount decides whether to recount the elections of the previous turn
+func (j *mockJudge) CallElectionRecount(electionsHeld []shared.Role) bool {
+	return j.callElectionRecount
+}
@@ -35,43 +35,68 @@ func (m *monitor) addToCache(roleToMonitorID shared.ClientID, variables []rules.
 	}
 }
 
-func (m *monitor) monitorRole(roleAccountable baseclient.Client) shared.MonitorResult {
-	roleToMonitor, roleName, err := m.findRoleToMonitor(roleAccountable.GetID())
-	if err == nil {
-		decideToMonitor := roleAccountable.MonitorIIGORole(roleName)
-		evaluationResult := true
-		if decideToMonitor {
-			evaluationResult = m.evaluateCache(roleToMonitor, roleName, m.gameState.RulesInfo.CurrentRulesInPlay)
+// monitorRoles has every role in the monitoring graph monitor the role it points to.
+// It returns the monitoring result for each monitored role.
+func (m *monitor) monitorRoles() map[shared.Role]shared.MonitorResult {
+	results := map[shared.Role]shared.MonitorResult{}
+	graph := m.monitoringGraph()
+	for _, monitoringRole := range m.roles() {
+		monitoredRole, ok := graph[monitoringRole]
+		if !ok {
+			continue
 		}
+		result := m.monitorRole(monitoringRole, monitoredRole)
+		// A role monitored by more than one role fails if any monitoring fails
+		if previous, ok := results[monitoredRole]; ok {
+			result = shared.MonitorResult{
+				Performed: previous.Performed || result.Performed,
+				Result:    previous.Result && result.Result,
+			}
+		}
+		results[monitoredRole] = result
+	}
+	return results
+}
 
-		m.Logf("Monitoring of %v result %v ", roleToMonitor, evaluationResult)
+func (m *monitor) monitorRole(monitoringRole shared.Role, roleName shared.Role) shared.MonitorResult {
+	accountableID, accountableInPlay := roleHolderID(m.gameState, monitoringRole)
+	roleToMonitor, monitoredInPlay := roleHolderID(m.gameState, roleName)
+	roleAccountable, clientFound := m.iigoClients[accountableID]
+	if !accountableInPlay || !monitoredInPlay || !clientFound {
+		return shared.MonitorResult{Performed: false, Result: false}
+	}
 
-		evaluationResultAnnounce, announce := roleAccountable.DecideIIGOMonitoringAnnouncement(evaluationResult)
+	decideToMonitor := roleAccountable.MonitorIIGORole(roleName)
+	evaluationResult := true
+	if decideToMonitor {
+		evaluationResult = m.evaluateCache(roleToMonitor, roleName, m.gameState.RulesInfo.CurrentRulesInPlay)
+	}
 
-		//announce == decideToMonitor
-		variablesToCache := []rules.VariableFieldName{rules.MonitorRoleAnnounce, rules.MonitorRoleDecideToMonitor}
-		valuesToCache := [][]float64{{boolToFloat(decideToMonitor)}, {boolToFloat(announce)}}
-		m.addToCache(roleAccountable.GetID(), variablesToCache, valuesToCache)
+	m.Logf("Monitoring of %v result %v ", roleToMonitor, evaluationResult)
 
-		if announce {
-			//check if evalResult = o.g. evalResult
-			variablesToCache := []rules.VariableFieldName{rules.MonitorRoleEvalResult, rules.MonitorRoleEvalResultDecide}
-			valuesToCache := [][]float64{{boolToFloat(evaluationResult)}, {boolToFloat(evaluationResultAnnounce)}}
-			m.addToCache(roleAccountable.GetID(), variablesToCache, valuesToCache)
+	evaluationResultAnnounce, announce := roleAccountable.DecideIIGOMonitoringAnnouncement(evaluationResult)
 
-			message := generateMonitoringMessage(roleName, evaluationResultAnnounce)
-			broadcastToAllIslands(m.iigoClients, roleAccountable.GetID(), message, *m.gameState)
+	//announce == decideToMonitor
+	variablesToCache := []rules.VariableFieldName{rules.MonitorRoleAnnounce, rules.MonitorRoleDecideToMonitor}
+	valuesToCache := [][]float64{{boolToFloat(decideToMonitor)}, {boolToFloat(announce)}}
+	m.addToCache(accountableID, variablesToCache, valuesToCache)
 
-			if !evaluationResult {
-				m.gameState.IIGOTurnsInPower[roleName] = m.config.IIGOConfig.IIGOTermLengths[roleName] + 1
-			}
+	if announce {
+		//check if evalResult = o.g. evalResult
+		variablesToCache := []rules.VariableFieldName{rules.MonitorRoleEvalResult, rules.MonitorRoleEvalResultDecide}
+		valuesToCache := [][]float64{{boolToFloat(evaluationResult)}, {boolToFloat(evaluationResultAnnounce)}}
+		m.addToCache(accountableID, variablesToCache, valuesToCache)
 
+		message := generateMonitoringMessage(roleName, evaluationResultAnnounce)
+		broadcastToAllIslands(m.iigoClients, accountableID, message, *m.gameState)
+
+		if !evaluationResult {
+			m.gameState.IIGOTurnsInPower[roleName] = m.config.IIGOConfig.IIGOTermLengths[roleName] + 1
 		}
 
-		result := shared.MonitorResult{Performed: decideToMonitor, Result: evaluationResult}
-		return result
 	}
-	result := shared.MonitorResult{Performed: false, Result: false}
+
+	result := shared.MonitorResult{Performed: decideToMonitor, Result: evaluationResult}
 	return result
 }
 
@@ -103,17 +128,36 @@ func (m *monitor) evaluateCache(roleToMonitorID shared.ClientID, roleName shared
 	return performedRoleCorrectly
 }
 
+// findRoleToMonitor returns the role monitored by the island roleAccountable according to the monitoring graph
 func (m *monitor) findRoleToMonitor(roleAccountable shared.ClientID) (shared.ClientID, shared.Role, error) {
-	switch roleAccountable {
-	case m.gameState.SpeakerID:
-		return m.gameState.PresidentID, shared.President, nil
-	case m.gameState.PresidentID:
-		return m.gameState.JudgeID, shared.Judge, nil
-	case m.gameState.JudgeID:
-		return m.gameState.SpeakerID, shared.Speaker, nil
-	default:
-		return shared.ClientID(-1), shared.Speaker, errors.Errorf("Monitoring by island that is not an IIGO Role")
+	graph := m.monitoringGraph()
+	for _, role := range m.roles() {
+		holder, inPlay := roleHolderID(m.gameState, role)
+		monitoredRole, monitors := graph[role]
+		if !inPlay || !monitors || holder != roleAccountable {
+			continue
+		}
+		if monitoredID, ok := roleHolderID(m.gameState, monitoredRole); ok {
+			return monitoredID, monitoredRole, nil
+		}
+	}
+	return shared.ClientID(-1), shared.Speaker, errors.Errorf("Monitoring by island that is not an IIGO Role")
+}
+
+// roles returns the IIGO roles in play
+func (m *monitor) roles() []shared.Role {
+	if m.config == nil {
+		return config.IIGOConfig{}.GetRoles()
+	}
+	return m.config.IIGOConfig.GetRoles()
+}
+
+// monitoringGraph returns which role monitors which, mapping monitoring roles to monitored roles
+func (m *monitor) monitoringGraph() map[shared.Role]shared.Role {
+	if m.config == nil {
+		return config.DefaultMonitoringGraph()
 	}
+	return m.config.IIGOConfig.GetMonitoringGraph()
 }
 
 func generateMonitoringMessage(role shared.Role, result bool) map[shared.CommunicationFieldName]shared.CommunicationContent {
@@ -4,6 +4,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/gamestate"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/rules"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
@@ -128,6 +129,52 @@ func TestEvaluateCache(t *testing.T) {
 	avail = tempCache
 }
 
+func TestEvaluateCacheAppointmentEligibility(t *testing.T) {
+	cases := []struct {
+		name          string
+		eligible      bool
+		expectedVal   bool
+		expectedRules map[shared.Role][]string
+	}{
+		{
+			name:          "Eligible island appointed",
+			eligible:      true,
+			expectedVal:   true,
+			expectedRules: map[shared.Role][]string{},
+		},
+		{
+			name:          "Barred island appointed",
+			eligible:      false,
+			expectedVal:   false,
+			expectedRules: map[shared.Role][]string{shared.Judge: {"must_appoint_eligible_island"}},
+		},
+	}
+	availableRules, rulesInPlay := rules.InitialRuleRegistration(true)
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			monitoring := &monitor{
+				logger: func(format string, a ...interface{}) {},
+				gameState: &gamestate.GameState{
+					RulesInfo: gamestate.RulesContext{
+						VariableMap:        rules.InitialVarRegistration(),
+						AvailableRules:     availableRules,
+						CurrentRulesInPlay: rulesInPlay,
+					},
+					IIGORulesBrokenByRoles: map[shared.Role][]string{},
+				},
+			}
+			monitoring.addToCache(shared.Team1, []rules.VariableFieldName{rules.AppointmentEligible}, [][]float64{{boolToFloat(tc.eligible)}})
+			res := monitoring.evaluateCache(shared.Team1, shared.Judge, rulesInPlay)
+			if res != tc.expectedVal {
+				t.Errorf("Expected evaluation of internalIIGOCache to be %v got %v", tc.expectedVal, res)
+			}
+			if !reflect.DeepEqual(tc.expectedRules, monitoring.gameState.IIGORulesBrokenByRoles) {
+				t.Errorf("Expected rules broken %v got %v", tc.expectedRules, monitoring.gameState.IIGORulesBrokenByRoles)
+			}
+		})
+	}
+}
+
 func TestFindRoleToMonitor(t *testing.T) {
 	cases := []struct {
 		name            string
@@ -203,3 +250,74 @@ func registerMonitoringTestRule() map[string]rules.RuleMatrix {
 	rulesStore[name] = rm
 	return rulesStore
 }
+
+func TestFindRoleToMonitorConfiguredGraph(t *testing.T) {
+	cases := []struct {
+		name            string
+		roleAccountable shared.ClientID
+		expectedRoleID  shared.ClientID
+		expectedRole    shared.Role
+		expectError     bool
+	}{
+		{
+			name:            "Speaker monitors Treasurer",
+			roleAccountable: shared.ClientID(1),
+			expectedRoleID:  shared.ClientID(4),
+			expectedRole:    shared.Treasurer,
+		},
+		{
+			name:            "Treasurer monitors Auditor",
+			roleAccountable: shared.ClientID(4),
+			expectedRoleID:  shared.ClientID(5),
+			expectedRole:    shared.Auditor,
+		},
+		{
+			name:            "Auditor monitors President",
+			roleAccountable: shared.ClientID(5),
+			expectedRoleID:  shared.ClientID(2),
+			expectedRole:    shared.President,
+		},
+		{
+			name:            "Judge monitors nobody",
+			roleAccountable: shared.ClientID(3),
+			expectedRoleID:  shared.ClientID(-1),
+			expectedRole:    shared.Speaker,
+			expectError:     true,
+		},
+	}
+	monitoring := &monitor{
+		gameState: &gamestate.GameState{
+			SpeakerID:   1,
+			PresidentID: 2,
+			JudgeID:     3,
+			IIGOAdditionalRoleIDs: map[shared.Role]shared.ClientID{
+				shared.Treasurer: 4,
+				shared.Auditor:   5,
+			},
+		},
+		config: &config.Config{
+			IIGOConfig: config.IIGOConfig{
+				AdditionalRoles: map[shared.Role]config.IIGORoleConfig{
+					shared.Treasurer: {AppointedBy: shared.President},
+					shared.Auditor:   {AppointedBy: shared.Judge},
+				},
+				MonitoringGraph: map[shared.Role]shared.Role{
+					shared.Speaker:   shared.Treasurer,
+					shared.Treasurer: shared.Auditor,
+					shared.Auditor:   shared.President,
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			id, role, err := monitoring.findRoleToMonitor(tc.roleAccountable)
+			if id != tc.expectedRoleID || role != tc.expectedRole {
+				t.Errorf("Expected role to monitor to be %v %v got %v %v", tc.expectedRole, tc.expectedRoleID, role, id)
+			}
+			if (err != nil) != tc.expectError {
+				t.Errorf("Expected error %v got %v", tc.expectError, err)
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package iigointernal
 
 import (
+	"fmt"
+
 	"github.com/SOMAS2020/SOMAS2020/internal/common/baseclient"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/gamestate"
@@ -16,6 +18,9 @@ func RunIIGO(logger shared.Logger, g *gamestate.GameState, clientMap *map[shared
 
 	removeDeadBodiesFromOffice(g)
 
+	// Governed rules voted on in previous turns set the IIGO config parameters they hold
+	applyGovernedParameters(g, &gameConf.IIGOConfig, logger)
+
 	var monitoring = monitor{
 		gameState:   g,
 		iigoClients: iIGOClients,
@@ -81,11 +86,35 @@ func RunIIGO(logger shared.Logger, g *gamestate.GameState, clientMap *map[shared
 	g.IIGOTurnsInPower[shared.Speaker]++
 	g.IIGOTurnsInPower[shared.Judge]++
 
+	// Additional roles enabled in the config get a fixed budget increment
+	rolesInPlay := gameConf.IIGOConfig.GetRoles()
+	additionalRoles := map[shared.Role]*additionalRole{}
+	for _, role := range rolesInPlay[3:] {
+		g.IIGORolesBudget[role] += gameConf.IIGOConfig.AdditionalRoles[role].BudgetIncrement
+		g.IIGOTurnsInPower[role]++
+		additionalRoles[role] = &additionalRole{
+			gameState:   g,
+			gameConf:    &gameConf.IIGOConfig,
+			Role:        role,
+			HolderID:    g.IIGOAdditionalRoleIDs[role],
+			iigoClients: iIGOClients,
+			monitoring:  &monitoring,
+			logger:      logger,
+		}
+	}
+
 	//Reset allocation and tax in gamestate in case of IIGO not running (for Vis)
 	g.IIGOTaxAmount = make(map[shared.ClientID]shared.Resources)
 	g.IIGOAllocationMade = false
 	g.RulesBrokenByIslands = make(map[shared.ClientID][]string)
 	g.IIGORulesBrokenByRoles = make(map[shared.Role][]string)
+	g.IIGOElectionRecounts = make([]gamestate.ElectionRecount, 0)
+	g.IIGORecallPetitions = make([]gamestate.RecallRecord, 0)
+	g.IIGOSanctionAppeals = make([]gamestate.AppealRecord, 0)
+	g.IIGOLegislativeSession = make([]gamestate.MotionRecord, 0)
+	g.IIGORuleBallots = make(map[string]map[shared.ClientID]shared.RuleVoteType)
+	g.IIGOCommonPoolReserve = 0
+	g.IIGOAllocationAudit = make(map[shared.ClientID]bool)
 
 	// Pass in gamestate and IIGO configs
 	// So that we don't have to pass gamestate as arguments in every function in roles
@@ -117,6 +146,11 @@ func RunIIGO(logger shared.Logger, g *gamestate.GameState, clientMap *map[shared
 		judicialBranch.inspectHistory(g.IIGOHistory[g.Turn-1])
 		judicialBranch.updateSanctionScore()
 		judicialBranch.applySanctions()
+		// g.IIGOElection still holds the elections from the end of the previous turn
+		err := judicialBranch.recountElections()
+		if err != nil {
+			logger("Error recounting elections: %v", err)
+		}
 	}
 
 	// 2 President actions
@@ -149,10 +183,16 @@ func RunIIGO(logger shared.Logger, g *gamestate.GameState, clientMap *map[shared
 		}
 	}
 
+	// Islands delegate or revoke their votes before any vote is held
+	updateVoteDelegations(g, iIGOClients, aliveClientIds)
+
 	// Judge uses resourceReports
 	if g.Turn > 0 {
+		// Sanctioned islands can appeal before sanctions are levied
+		judicialBranch.hearAppeals(aliveClientIds)
 		judicialBranch.sanctionEvaluate(resourceReports)
 	}
+	g.IIGOSanctionConsequences = voting.SanctionConsequencesInForce(g, &gameConf.IIGOConfig)
 
 	// Throw error if any of the actions returns error
 	insufficientBudget := executiveBranch.broadcastTaxation(resourceReports, aliveClientIds)
@@ -165,11 +205,27 @@ func RunIIGO(logger shared.Logger, g *gamestate.GameState, clientMap *map[shared
 		return false, "Common pool resources insufficient for executiveBranch requestAllocationRequest"
 	}
 
-	allocationsMade, insufficientBudget := executiveBranch.replyAllocationRequest(g.CommonPool)
+	// The Treasurer keeps part of the common pool back from the President's allocations
+	if treasurer, ok := additionalRoles[shared.Treasurer]; ok {
+		insufficientBudget = treasurer.setCommonPoolReserve()
+		if insufficientBudget != nil {
+			return false, "Common pool resources insufficient for treasurer setCommonPoolReserve"
+		}
+	}
+
+	allocationsMade, insufficientBudget := executiveBranch.replyAllocationRequest(g.CommonPool - g.IIGOCommonPoolReserve)
 	if insufficientBudget != nil {
 		return false, "Common pool resources insufficient for executiveBranch replyAllocationRequest"
 	}
 
+	// The Auditor independently checks the allocations against the requests
+	if auditor, ok := additionalRoles[shared.Auditor]; ok {
+		insufficientBudget = auditor.auditAllocations(executiveBranch.ResourceRequests, allocationsMade)
+		if insufficientBudget != nil {
+			return false, "Common pool resources insufficient for auditor auditAllocations"
+		}
+	}
+
 	insufficientBudget = executiveBranch.requestRuleProposal()
 	if insufficientBudget != nil {
 		return false, "Common pool resources insufficient for executiveBranch requestRuleProposal"
@@ -180,34 +236,21 @@ func RunIIGO(logger shared.Logger, g *gamestate.GameState, clientMap *map[shared
 		return false, "Common pool resources insufficient for executiveBranch getRuleForSpeaker"
 	}
 
-	ruleSelected := false
-	if !ruleToVoteReturn.ProposedRuleMatrix.RuleMatrixIsEmpty() {
-		ruleSelected = true
-	}
-
 	variablesToCache := []rules.VariableFieldName{rules.AllocationMade}
 	valuesToCache := [][]float64{{boolToFloat(allocationsMade)}}
 	monitoring.addToCache(g.PresidentID, variablesToCache, valuesToCache)
 
 	// 3 Speaker actions
 
-	//TODO:- shouldn't updateRules be called somewhere?
-	insufficientBudget = legislativeBranch.setRuleToVote(ruleToVoteReturn.ProposedRuleMatrix)
+	legislativeBranch.setRuleToVote(ruleToVoteReturn.ProposedRuleMatrix)
 
+	// A vote is expected if the agenda holds an item and the session budget covers at least one vote
+	ruleSelected := len(g.IIGOLegislativeAgenda) > 0 &&
+		gameConf.IIGOConfig.LegislativeSessionBudget >= gameConf.IIGOConfig.SetRuleToVoteActionCost
+
+	voteCalled, resultAnnounced, insufficientBudget := legislativeBranch.runLegislativeSession(aliveClientIds)
 	if insufficientBudget != nil {
-		return false, "Common pool resources insufficient for legislativeBranch setRuleToVote"
-	}
-	voteCalled, insufficientBudget := legislativeBranch.setVotingResult(aliveClientIds)
-	if insufficientBudget != nil {
-		return false, "Common pool resources insufficient for legislativeBranch setVotingResult"
-	}
-	err := legislativeBranch.updateRules(legislativeBranch.ruleToVote, legislativeBranch.votingResult)
-	if err != nil {
-		logger("Error updating rules with result: %v", err)
-	}
-	resultAnnounced, insufficientBudget := legislativeBranch.announceVotingResult()
-	if insufficientBudget != nil {
-		return false, "Common pool resources insufficient for legislativeBranch announceVotingResult"
+		return false, "Common pool resources insufficient for legislativeBranch runLegislativeSession"
 	}
 
 	variablesToCache = []rules.VariableFieldName{rules.RuleSelected, rules.VoteCalled}
@@ -227,9 +270,11 @@ func RunIIGO(logger shared.Logger, g *gamestate.GameState, clientMap *map[shared
 		return false, "Cannot pay IIGO salary"
 	}
 
-	presidentMonitored := monitoring.monitorRole(iIGOClients[g.SpeakerID])
-	judgeMonitored := monitoring.monitorRole(iIGOClients[g.PresidentID])
-	speakerMonitored := monitoring.monitorRole(iIGOClients[g.JudgeID])
+	// Every role monitors the role it points to in the monitoring graph
+	monitorResults := monitoring.monitorRoles()
+	presidentMonitored := monitorResults[shared.President]
+	judgeMonitored := monitorResults[shared.Judge]
+	speakerMonitored := monitorResults[shared.Speaker]
 	// Clear cache ahead of elections
 	monitoring.clearCache()
 
@@ -259,5 +304,35 @@ func RunIIGO(logger shared.Logger, g *gamestate.GameState, clientMap *map[shared
 		return false, "President was not apointed by the Judge. Insufficient budget"
 	}
 
+	runners := map[shared.Role]electionRunner{
+		shared.President: {ID: executiveBranch.PresidentID, charge: executiveBranch.incurServiceCharge},
+		shared.Speaker:   {ID: legislativeBranch.SpeakerID, charge: legislativeBranch.incurServiceCharge},
+		shared.Judge:     {ID: judicialBranch.JudgeID, charge: judicialBranch.incurServiceCharge},
+	}
+	for role, holder := range additionalRoles {
+		runners[role] = electionRunner{ID: holder.HolderID, charge: holder.incurServiceCharge}
+	}
+
+	// Additional roles are elected by the role appointing them
+	for _, role := range rolesInPlay[3:] {
+		appointer := appointingRole(&gameConf.IIGOConfig, role)
+		newHolder, appointError := additionalRoles[role].appointNextHolder(monitorResults[role], runners[appointer], aliveClientIds)
+		if appointError != nil {
+			return false, fmt.Sprintf("%v was not appointed by the %v. Insufficient budget", role, appointer)
+		}
+		g.IIGOAdditionalRoleIDs[role] = newHolder
+	}
+
+	// Recall petitions can force an election run by a different branch
+	var recallSession = recall{
+		gameState:   g,
+		gameConf:    &gameConf.IIGOConfig,
+		iigoClients: iIGOClients,
+		runners:     runners,
+		monitoring:  &monitoring,
+		logger:      logger,
+	}
+	recallSession.runPetitions(aliveClientIds)
+
 	return true, "IIGO Run Successful"
 }
\ No newline at end of file
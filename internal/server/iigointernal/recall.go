@@ -0,0 +1,150 @@
+package iigointernal
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/baseclient"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/gamestate"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/rules"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/voting"
+)
+
+// recallVotingMethod is the voting method used by all forced recall elections
+const recallVotingMethod = shared.Runoff
+
+type recall struct {
+	gameState   *gamestate.GameState
+	gameConf    *config.IIGOConfig
+	iigoClients map[shared.ClientID]baseclient.Client
+	runners     map[shared.Role]electionRunner
+	monitoring  *monitor
+	logger      shared.Logger
+}
+
+func (r *recall) Logf(format string, a ...interface{}) {
+	r.logger("[RECALL]: %v", fmt.Sprintf(format, a...))
+}
+
+// roleHolders returns the island currently holding each IIGO role
+func (r *recall) roleHolders() map[shared.Role]shared.ClientID {
+	return map[shared.Role]shared.ClientID{
+		shared.President: r.gameState.PresidentID,
+		shared.Speaker:   r.gameState.SpeakerID,
+		shared.Judge:     r.gameState.JudgeID,
+	}
+}
+
+// collectPetitions asks every alive island whether it wants to petition a recall. Only one petition is
+// kept per role: the island with the lowest ID files it and any other island petitioning the same role co-signs it.
+func (r *recall) collectPetitions(aliveClientIDs []shared.ClientID) map[shared.Role]*shared.RecallPetition {
+	islands := copyClientList(aliveClientIDs)
+	sort.Slice(islands, func(i, j int) bool { return islands[i] < islands[j] })
+
+	holders := r.roleHolders()
+	petitions := map[shared.Role]*shared.RecallPetition{}
+	for _, island := range islands {
+		role, filed := r.iigoClients[island].FileRecallPetition(r.roleHolders())
+		holder, validRole := holders[role]
+		if !filed || !validRole || holder == island {
+			continue
+		}
+		if petition, ok := petitions[role]; ok {
+			petition.CoSigners = append(petition.CoSigners, island)
+			continue
+		}
+		petitions[role] = &shared.RecallPetition{
+			Role:       role,
+			RoleHolder: holder,
+			Petitioner: island,
+			CoSigners:  []shared.ClientID{},
+		}
+	}
+	return petitions
+}
+
+// gatherCoSigners offers the petition to every alive island which has not signed it yet.
+// The role holder cannot co-sign its own recall.
+func (r *recall) gatherCoSigners(petition *shared.RecallPetition, aliveClientIDs []shared.ClientID) {
+	signed := map[shared.ClientID]bool{petition.Petitioner: true, petition.RoleHolder: true}
+	for _, island := range petition.CoSigners {
+		signed[island] = true
+	}
+	for _, island := range aliveClientIDs {
+		if signed[island] {
+			continue
+		}
+		if r.iigoClients[island].CoSignRecallPetition(*petition) {
+			petition.CoSigners = append(petition.CoSigners, island)
+		}
+	}
+	sort.Slice(petition.CoSigners, func(i, j int) bool { return petition.CoSigners[i] < petition.CoSigners[j] })
+}
+
+// forceElection runs an election for the petitioned role. The recalled island may not stand as a candidate
+// unless it is the only island left alive. The winner is appointed directly as the running branch has no say.
+func (r *recall) forceElection(petition shared.RecallPetition, runner electionRunner, aliveClientIDs []shared.ClientID) (shared.ClientID, bool) {
+	if !runner.charge(appointActionCost(r.gameConf, petition.Role)) {
+		return petition.RoleHolder, false
+	}
+
+	candidates := []shared.ClientID{}
+	for _, island := range aliveClientIDs {
+		if island != petition.RoleHolder {
+			candidates = append(candidates, island)
+		}
+	}
+	if len(candidates) == 0 {
+		candidates = copyClientList(aliveClientIDs)
+	}
+
+	var election = voting.Election{
+		Logger: r.logger,
+	}
+	election.ProposeElection(petition.Role, recallVotingMethod)
+	election.SetEligibility(r.gameState, r.gameConf)
+	election.SetDelegations(voteDelegations(r.gameState, shared.ElectionVotes))
+	election.OpenBallot(copyClientList(aliveClientIDs), candidates)
+	election.Vote(r.iigoClients)
+	winner := election.CloseBallot(r.iigoClients)
+	recordElection(r.gameState, r.gameConf, election.GetVotingInfo(), winner, runner.ID)
+
+	setRoleHolder(r.gameState, petition.Role, winner)
+	r.gameState.IIGOTurnsInPower[petition.Role] = 0
+	return winner, true
+}
+
+// runPetitions collects recall petitions and forces an election for every petition with enough co-signers.
+// Forced elections are run by a different branch than the one normally appointing the role.
+func (r *recall) runPetitions(aliveClientIDs []shared.ClientID) {
+	petitions := r.collectPetitions(aliveClientIDs)
+	for _, role := range []shared.Role{shared.President, shared.Speaker, shared.Judge} {
+		petition, ok := petitions[role]
+		if !ok {
+			continue
+		}
+		r.gatherCoSigners(petition, aliveClientIDs)
+
+		runBy := recallElectionRunner(role)
+		runner := r.runners[runBy]
+		record := gamestate.RecallRecord{
+			Petition:       *petition,
+			ElectionForced: uint(len(petition.CoSigners)) >= r.gameConf.RecallCoSignersRequired,
+			RunBy:          runBy,
+			Winner:         petition.RoleHolder,
+		}
+		if record.ElectionForced {
+			record.Winner, record.ElectionHeld = r.forceElection(*petition, runner, aliveClientIDs)
+			r.Logf("Petition to recall %v %v forced an election run by the %v, won by %v", role, petition.RoleHolder, runBy, record.Winner)
+		}
+
+		//Log rule: Must hold an election when a recall petition succeeds
+		variablesToCache := []rules.VariableFieldName{rules.RecallPetitionSucceeded, rules.RecallElectionHeld}
+		valuesToCache := [][]float64{{boolToFloat(record.ElectionForced)}, {boolToFloat(record.ElectionHeld)}}
+		r.monitoring.addToCache(runner.ID, variablesToCache, valuesToCache)
+
+		r.gameState.IIGORecallPetitions = append(r.gameState.IIGORecallPetitions, record)
+	}
+}
@@ -0,0 +1,170 @@
+package iigointernal
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/baseclient"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/gamestate"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+type mockRecallClient struct {
+	*baseclient.BaseClient
+	petitionRole shared.Role
+	petition     bool
+	coSign       bool
+}
+
+func (c *mockRecallClient) FileRecallPetition(roleHolders map[shared.Role]shared.ClientID) (shared.Role, bool) {
+	return c.petitionRole, c.petition
+}
+
+func (c *mockRecallClient) CoSignRecallPetition(petition shared.RecallPetition) bool {
+	return c.coSign
+}
+
+// VoteForElection ranks the candidates in the order they are offered
+func (c *mockRecallClient) VoteForElection(roleToElect shared.Role, candidateList []shared.ClientID) []shared.ClientID {
+	return candidateList
+}
+
+func TestRunRecallPetitions(t *testing.T) {
+	cases := []struct {
+		name              string
+		petitioners       []shared.ClientID
+		coSigners         []shared.ClientID
+		coSignersRequired uint
+		commonPool        shared.Resources
+		expectedRecords   []gamestate.RecallRecord
+		expectedPresident shared.ClientID
+	}{
+		{
+			name:              "No petition",
+			coSignersRequired: 2,
+			commonPool:        100,
+			expectedRecords:   []gamestate.RecallRecord{},
+			expectedPresident: shared.Team1,
+		},
+		{
+			name:              "Not enough co-signers",
+			petitioners:       []shared.ClientID{shared.Team2},
+			coSigners:         []shared.ClientID{shared.Team3},
+			coSignersRequired: 2,
+			commonPool:        100,
+			expectedRecords: []gamestate.RecallRecord{
+				{
+					Petition: shared.RecallPetition{
+						Role:       shared.President,
+						RoleHolder: shared.Team1,
+						Petitioner: shared.Team2,
+						CoSigners:  []shared.ClientID{shared.Team3},
+					},
+					ElectionForced: false,
+					RunBy:          shared.Speaker,
+					Winner:         shared.Team1,
+				},
+			},
+			expectedPresident: shared.Team1,
+		},
+		{
+			name:              "Petition forces election",
+			petitioners:       []shared.ClientID{shared.Team3, shared.Team2},
+			coSigners:         []shared.ClientID{shared.Team1, shared.Team4},
+			coSignersRequired: 2,
+			commonPool:        100,
+			expectedRecords: []gamestate.RecallRecord{
+				{
+					Petition: shared.RecallPetition{
+						Role:       shared.President,
+						RoleHolder: shared.Team1,
+						Petitioner: shared.Team2,
+						CoSigners:  []shared.ClientID{shared.Team3, shared.Team4},
+					},
+					ElectionForced: true,
+					ElectionHeld:   true,
+					RunBy:          shared.Speaker,
+					Winner:         shared.Team2,
+				},
+			},
+			expectedPresident: shared.Team2,
+		},
+		{
+			name:              "Forced election without budget",
+			petitioners:       []shared.ClientID{shared.Team2},
+			coSigners:         []shared.ClientID{shared.Team3, shared.Team4},
+			coSignersRequired: 2,
+			commonPool:        1,
+			expectedRecords: []gamestate.RecallRecord{
+				{
+					Petition: shared.RecallPetition{
+						Role:       shared.President,
+						RoleHolder: shared.Team1,
+						Petitioner: shared.Team2,
+						CoSigners:  []shared.ClientID{shared.Team3, shared.Team4},
+					},
+					ElectionForced: true,
+					ElectionHeld:   false,
+					RunBy:          shared.Speaker,
+					Winner:         shared.Team1,
+				},
+			},
+			expectedPresident: shared.Team1,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			aliveClientIDs := []shared.ClientID{shared.Team1, shared.Team2, shared.Team3, shared.Team4}
+			clients := map[shared.ClientID]baseclient.Client{}
+			for _, clientID := range aliveClientIDs {
+				clients[clientID] = &mockRecallClient{BaseClient: baseclient.NewClient(clientID)}
+			}
+			for _, clientID := range tc.petitioners {
+				clients[clientID].(*mockRecallClient).petition = true
+			}
+			for _, clientID := range tc.coSigners {
+				clients[clientID].(*mockRecallClient).coSign = true
+			}
+			fakeGameState := &gamestate.GameState{
+				CommonPool:  tc.commonPool,
+				PresidentID: shared.Team1,
+				SpeakerID:   shared.Team2,
+				JudgeID:     shared.Team3,
+				IIGORolesBudget: map[shared.Role]shared.Resources{
+					shared.President: 10,
+					shared.Speaker:   10,
+					shared.Judge:     10,
+				},
+				IIGOTurnsInPower:    map[shared.Role]uint{},
+				IIGORecallPetitions: []gamestate.RecallRecord{},
+			}
+			gameConf := &config.IIGOConfig{
+				RecallCoSignersRequired:        tc.coSignersRequired,
+				AppointNextPresidentActionCost: 5,
+			}
+			fakeMonitoring := &monitor{gameState: fakeGameState}
+			legislativeBranch := legislature{gameState: fakeGameState, SpeakerID: shared.Team2, monitoring: fakeMonitoring}
+			recallSession := recall{
+				gameState:   fakeGameState,
+				gameConf:    gameConf,
+				iigoClients: clients,
+				runners: map[shared.Role]electionRunner{
+					shared.Speaker: {ID: shared.Team2, charge: legislativeBranch.incurServiceCharge},
+				},
+				monitoring: fakeMonitoring,
+				logger:     func(format string, a ...interface{}) {},
+			}
+			recallSession.runPetitions(aliveClientIDs)
+			if !reflect.DeepEqual(tc.expectedRecords, fakeGameState.IIGORecallPetitions) {
+				t.Errorf("Expected records %v got %v", tc.expectedRecords, fakeGameState.IIGORecallPetitions)
+			}
+			if fakeGameState.PresidentID != tc.expectedPresident {
+				t.Errorf("Expected President %v got %v", tc.expectedPresident, fakeGameState.PresidentID)
+			}
+			if len(tc.expectedRecords) > 0 && len(fakeGameState.IIGORoleMonitoringCache) == 0 {
+				t.Errorf("Expected recall outcome to be cached for monitoring")
+			}
+		})
+	}
+}
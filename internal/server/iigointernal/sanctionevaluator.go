@@ -25,6 +25,26 @@ func DefaultInitLocalHistoryCache(depth int) map[int][]shared.Accountability {
 	return returnMap
 }
 
+// EvaluateCollectiveSanctions evaluates the collective sanction rules in play against the variables of every island
+// and returns the surcharge owed by each island that triggered at least one of them
+func EvaluateCollectiveSanctions(ruleNames []string, rulesInPlay map[string]rules.RuleMatrix, islandVariables map[shared.ClientID]map[rules.VariableFieldName]rules.VariableValuePair) map[shared.ClientID]shared.Resources {
+	surcharges := map[shared.ClientID]shared.Resources{}
+	for clientID, variables := range islandVariables {
+		var surcharge shared.Resources
+		for _, ruleName := range ruleNames {
+			if rule, ok := rulesInPlay[ruleName]; ok {
+				if amount := evaluateSanction(rule, variables); amount > 0 {
+					surcharge += amount
+				}
+			}
+		}
+		if surcharge > 0 {
+			surcharges[clientID] = surcharge
+		}
+	}
+	return surcharges
+}
+
 func evaluateSanction(sanction rules.RuleMatrix, localVariableCache map[rules.VariableFieldName]rules.VariableValuePair) shared.Resources {
 	reqVar := sanction.RequiredVariables
 	if checkAllRequiredVariablesAreAvailable(reqVar, localVariableCache) {
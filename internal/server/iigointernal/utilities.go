@@ -4,9 +4,11 @@ import (
 	"math/rand"
 
 	"github.com/SOMAS2020/SOMAS2020/internal/common/baseclient"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/gamestate"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/rules"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/voting"
 )
 
 func broadcastToAllIslands(clients map[shared.ClientID]baseclient.Client, sender shared.ClientID, data map[shared.CommunicationFieldName]shared.CommunicationContent, gameState gamestate.GameState) {
@@ -47,6 +49,16 @@ func depositIntoClientPrivatePool(value shared.Resources, id shared.ClientID, st
 	state.ClientInfos[id] = participantInfo
 }
 
+func withdrawFromClientPrivatePool(value shared.Resources, id shared.ClientID, state *gamestate.GameState) bool {
+	participantInfo := state.ClientInfos[id]
+	if participantInfo.Resources < value {
+		return false
+	}
+	participantInfo.Resources -= value
+	state.ClientInfos[id] = participantInfo
+	return true
+}
+
 func depositIntoCommonPool(value shared.Resources, state *gamestate.GameState) {
 	state.CommonPool += value
 }
@@ -73,6 +85,142 @@ func copyClientList(in []shared.ClientID) []shared.ClientID {
 	return ret
 }
 
+// electionRunner is an IIGO role able to run and pay for an election
+type electionRunner struct {
+	ID     shared.ClientID
+	charge func(shared.Resources) bool
+}
+
+// roleHolderID returns the island holding role. The bool is false if the role is not in play
+func roleHolderID(g *gamestate.GameState, role shared.Role) (shared.ClientID, bool) {
+	switch role {
+	case shared.President:
+		return g.PresidentID, true
+	case shared.Speaker:
+		return g.SpeakerID, true
+	case shared.Judge:
+		return g.JudgeID, true
+	default:
+		id, ok := g.IIGOAdditionalRoleIDs[role]
+		return id, ok
+	}
+}
+
+// setRoleHolder appoints island to role
+func setRoleHolder(g *gamestate.GameState, role shared.Role, island shared.ClientID) {
+	switch role {
+	case shared.President:
+		g.PresidentID = island
+	case shared.Speaker:
+		g.SpeakerID = island
+	case shared.Judge:
+		g.JudgeID = island
+	default:
+		if g.IIGOAdditionalRoleIDs == nil {
+			g.IIGOAdditionalRoleIDs = map[shared.Role]shared.ClientID{}
+		}
+		g.IIGOAdditionalRoleIDs[role] = island
+	}
+}
+
+// recordElection adds an election and the appointment that followed it to the election audit trail
+func recordElection(g *gamestate.GameState, gameConf *config.IIGOConfig, votingInfo gamestate.VotingInfo, appointed shared.ClientID, appointedBy shared.ClientID) {
+	if gameConf.AnonymiseElectionBallots {
+		votingInfo = voting.AnonymiseVotingInfo(votingInfo)
+	}
+	votingInfo.AppointedWinner = appointed
+	votingInfo.AppointedBy = appointedBy
+	g.IIGOElection = append(g.IIGOElection, votingInfo)
+	if electionHeld(votingInfo) {
+		updateTenure(g, votingInfo.RoleToElect, appointed)
+	}
+}
+
+// updateTenure records the island appointed to role after an election, counting consecutive terms
+func updateTenure(g *gamestate.GameState, role shared.Role, appointed shared.ClientID) {
+	if g.IIGOTenures == nil {
+		g.IIGOTenures = map[shared.Role]gamestate.IIGOTenure{}
+	}
+	tenure := g.IIGOTenures[role]
+	if tenure.LeftOffice == nil {
+		tenure.LeftOffice = map[shared.ClientID]uint{}
+	}
+	if tenure.ConsecutiveTerms > 0 && tenure.Holder == appointed {
+		tenure.ConsecutiveTerms++
+	} else {
+		if tenure.ConsecutiveTerms > 0 {
+			tenure.LeftOffice[tenure.Holder] = g.Turn
+		}
+		tenure.Holder = appointed
+		tenure.ConsecutiveTerms = 1
+	}
+	g.IIGOTenures[role] = tenure
+}
+
+// checkAppointmentEligibility returns false if the island appointed to role is barred by the term limits
+// or eligibility criteria, which breaks the must_appoint_eligible_island rule. The criteria do not apply
+// if they had to be waived because no island was eligible.
+func checkAppointmentEligibility(g *gamestate.GameState, gameConf *config.IIGOConfig, votingInfo gamestate.VotingInfo, appointed shared.ClientID) bool {
+	return votingInfo.EligibilityWaived || voting.IsEligible(appointed, votingInfo.RoleToElect, g, gameConf)
+}
+
+// electionHeld returns true if an election audit record contains ballots that can be counted
+func electionHeld(votingInfo gamestate.VotingInfo) bool {
+	return len(votingInfo.Votes) > 0 && len(votingInfo.CandidateList) > 0
+}
+
+// appointingRole returns the role responsible for appointing the winner of an election for role
+func appointingRole(gameConf *config.IIGOConfig, role shared.Role) shared.Role {
+	if roleConf, ok := gameConf.AdditionalRoles[role]; ok {
+		return roleConf.AppointedBy
+	}
+	switch role {
+	case shared.President:
+		return shared.Judge
+	case shared.Speaker:
+		return shared.President
+	default:
+		return shared.Speaker
+	}
+}
+
+// electionVotingMethod returns the voting method of the election for role: the one fixed in the config by the
+// iigo_election_voting_method rules, or else the one chosen by the role running the election
+func electionVotingMethod(gameConf *config.IIGOConfig, role shared.Role, chosen shared.ElectionVotingMethod) shared.ElectionVotingMethod {
+	if method, ok := gameConf.ElectionVotingMethods[role]; ok {
+		return method
+	}
+	return chosen
+}
+
+// recallElectionRunner returns the role that runs a forced recall election for role.
+// This is never the recalled role itself nor the role that normally appoints it.
+func recallElectionRunner(role shared.Role) shared.Role {
+	switch role {
+	case shared.President:
+		return shared.Speaker
+	case shared.Speaker:
+		return shared.Judge
+	default:
+		return shared.President
+	}
+}
+
+// appointActionCost returns the cost of running an election for role
+func appointActionCost(gameConf *config.IIGOConfig, role shared.Role) shared.Resources {
+	if roleConf, ok := gameConf.AdditionalRoles[role]; ok {
+		return roleConf.AppointActionCost
+	}
+	switch role {
+	case shared.President:
+		return gameConf.AppointNextPresidentActionCost
+	case shared.Speaker:
+		return gameConf.AppointNextSpeakerActionCost
+	default:
+		return gameConf.AppointNextJudgeActionCost
+	}
+}
+
 // if an IIGO role is dead, it is replaced with a random living island
 func removeDeadBodiesFromOffice(g *gamestate.GameState) {
 	aliveClientIds := []shared.ClientID{}
@@ -90,4 +238,9 @@ func removeDeadBodiesFromOffice(g *gamestate.GameState) {
 	if g.ClientInfos[g.SpeakerID].LifeStatus == shared.Dead {
 		g.SpeakerID = aliveClientIds[rand.Intn(len(aliveClientIds))]
 	}
+	for role, holder := range g.IIGOAdditionalRoleIDs {
+		if g.ClientInfos[holder].LifeStatus == shared.Dead {
+			g.IIGOAdditionalRoleIDs[role] = aliveClientIds[rand.Intn(len(aliveClientIds))]
+		}
+	}
 }
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/SOMAS2020/SOMAS2020/internal/common/baseclient"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/gamestate"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
 )
@@ -382,3 +383,85 @@ func TestRemoveDead(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateTenure(t *testing.T) {
+	cases := []struct {
+		name      string
+		tenures   map[shared.Role]gamestate.IIGOTenure
+		appointed shared.ClientID
+		expected  gamestate.IIGOTenure
+	}{
+		{
+			name:      "First recorded term",
+			tenures:   nil,
+			appointed: shared.Team2,
+			expected: gamestate.IIGOTenure{
+				Holder:           shared.Team2,
+				ConsecutiveTerms: 1,
+				LeftOffice:       map[shared.ClientID]uint{},
+			},
+		},
+		{
+			name: "Re-elected holder",
+			tenures: map[shared.Role]gamestate.IIGOTenure{
+				shared.Speaker: {Holder: shared.Team2, ConsecutiveTerms: 1, LeftOffice: map[shared.ClientID]uint{}},
+			},
+			appointed: shared.Team2,
+			expected: gamestate.IIGOTenure{
+				Holder:           shared.Team2,
+				ConsecutiveTerms: 2,
+				LeftOffice:       map[shared.ClientID]uint{},
+			},
+		},
+		{
+			name: "New holder",
+			tenures: map[shared.Role]gamestate.IIGOTenure{
+				shared.Speaker: {Holder: shared.Team2, ConsecutiveTerms: 3, LeftOffice: map[shared.ClientID]uint{}},
+			},
+			appointed: shared.Team4,
+			expected: gamestate.IIGOTenure{
+				Holder:           shared.Team4,
+				ConsecutiveTerms: 1,
+				LeftOffice:       map[shared.ClientID]uint{shared.Team2: 7},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeGameState := gamestate.GameState{Turn: 7, IIGOTenures: tc.tenures}
+			updateTenure(&fakeGameState, shared.Speaker, tc.appointed)
+			if !reflect.DeepEqual(fakeGameState.IIGOTenures[shared.Speaker], tc.expected) {
+				t.Errorf("Expected tenure %v got %v", tc.expected, fakeGameState.IIGOTenures[shared.Speaker])
+			}
+		})
+	}
+}
+
+func TestElectionVotingMethod(t *testing.T) {
+	gameConf := &config.IIGOConfig{
+		ElectionVotingMethods: map[shared.Role]shared.ElectionVotingMethod{shared.Speaker: shared.Approval},
+	}
+	cases := []struct {
+		name     string
+		role     shared.Role
+		expected shared.ElectionVotingMethod
+	}{
+		{
+			name:     "Method fixed by the config",
+			role:     shared.Speaker,
+			expected: shared.Approval,
+		},
+		{
+			name:     "Method chosen by the role running the election",
+			role:     shared.Judge,
+			expected: shared.InstantRunoff,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := electionVotingMethod(gameConf, tc.role, shared.InstantRunoff); got != tc.expected {
+				t.Errorf("Expected voting method %v got %v", tc.expected, got)
+			}
+		})
+	}
+}
@@ -2,8 +2,12 @@ package server
 
 import (
 	"fmt"
+	"sort"
 
+	"github.com/SOMAS2020/SOMAS2020/internal/common/rules"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/voting"
+	"github.com/pkg/errors"
 )
 
 // runIITO : IITO makes recommendations about the optimal (and fairest) contributions this term
@@ -13,8 +17,23 @@ func (s *SOMASServer) runIITO() error {
 	defer s.logf("finish runIITO")
 	s.gameState.IITOTransactions = s.runGiftSession()
 
+	// Islands propose and sign contracts binding them over several turns
+	s.runContractSession()
+
+	// Islands lend each other resources to be repaid with interest
+	s.runLoanSession()
+
+	// Islands make gifts held in escrow until a condition is met
+	s.runEscrowSession()
+
+	// Islands trade foraging shares and IOUs on the market
+	s.runMarketSession()
+
 	// This is for sharing an island's intended contributions to the common pool
 	s.runIntendedContributionSession()
+
+	// Islands form, join and leave coalitions with shared treasuries
+	s.runCoalitionSession()
 	// TODO:- IITO team
 	return nil
 }
@@ -23,6 +42,12 @@ func (s *SOMASServer) runIITOEndOfTurn() error {
 	s.logf("start runIITOEndOfTurn")
 	defer s.logf("finish runIITOEndOfTurn")
 	s.executeTransactions(s.gameState.IITOTransactions)
+	s.executeContracts()
+	s.executeLoanRepayments()
+	s.settleMarketTrades()
+	if err := s.runCoalitionEndOfTurn(); err != nil {
+		return errors.Errorf("Failed to run coalitions at end of turn: %v", err)
+	}
 	return nil
 }
 
@@ -31,7 +56,7 @@ func (s *SOMASServer) runGiftSession() map[shared.ClientID]shared.GiftResponseDi
 	defer s.logf("finish runGiftSession")
 
 	requests := s.getGiftRequests()
-	offers := s.getGiftOffers(requests)
+	offers := s.negotiateGiftOffers(s.getGiftOffers(requests))
 	responses := s.getGiftResponses(offers)
 
 	// Clean all rejected / ignored responses so the remaining are only the transactions
@@ -48,9 +73,19 @@ func (s *SOMASServer) runGiftSession() map[shared.ClientID]shared.GiftResponseDi
 	return transactions
 }
 
+// getGiftSessionClientIDs returns the alive islands which are not barred from the gift session by a sanction
+func (s *SOMASServer) getGiftSessionClientIDs() []shared.ClientID {
+	return voting.WithoutSanctionConsequence(getNonDeadClientIDs(s.gameState.ClientInfos), shared.ExcludedFromGifts, s.gameState.IIGOSanctionConsequences)
+}
+
+func (s *SOMASServer) excludedFromGifts(id shared.ClientID) bool {
+	_, excluded := s.gameState.IIGOSanctionConsequences[id][shared.ExcludedFromGifts]
+	return excluded
+}
+
 func (s *SOMASServer) sanitiseTeamGiftRequests(requests shared.GiftRequestDict, thisTeam shared.ClientID) shared.GiftRequestDict {
 	for team, request := range requests {
-		if s.gameState.ClientInfos[team].LifeStatus == shared.Dead || team == thisTeam || request == 0 {
+		if s.gameState.ClientInfos[team].LifeStatus == shared.Dead || s.excludedFromGifts(team) || team == thisTeam || request == 0 {
 			delete(requests, team)
 			// s.logf("%v violated request conventions. To %v, requested %v", thisTeam, team, request)
 		}
@@ -61,7 +96,7 @@ func (s *SOMASServer) sanitiseTeamGiftRequests(requests shared.GiftRequestDict,
 // GetGiftRequests collects a map of gift requests from an individual client, for all clients, in a map
 func (s *SOMASServer) getGiftRequests() map[shared.ClientID]shared.GiftRequestDict {
 	totalRequests := map[shared.ClientID]shared.GiftRequestDict{}
-	for _, id := range getNonDeadClientIDs(s.gameState.ClientInfos) {
+	for _, id := range s.getGiftSessionClientIDs() {
 		totalRequests[id] = s.sanitiseTeamGiftRequests(s.clientMap[id].GetGiftRequests(), id)
 
 		if len(totalRequests[id]) == 0 {
@@ -71,60 +106,62 @@ func (s *SOMASServer) getGiftRequests() map[shared.ClientID]shared.GiftRequestDi
 	return totalRequests
 }
 
+// offersKnapsackSolver finds the combination of offers with the largest total not exceeding the capacity.
+// It solves the 0/1 knapsack exactly by branch and bound over the offers, largest first, which is quick for
+// the handful of islands making offers to one island.
 func offersKnapsackSolver(capacity shared.GiftOffer, offers shared.GiftOfferDict) (shared.GiftOffer, []shared.ClientID) {
-	// Base case
-	if len(offers) == 1 {
-		for team, offer := range offers {
-			if offer <= capacity {
-				return offer, []shared.ClientID{team}
-			}
-			return 0, []shared.ClientID{}
-		}
+	if !(capacity > 0) {
+		return 0, []shared.ClientID{}
 	}
 
-	bestOffer := shared.GiftOffer(0)
-	bestCombination := []shared.ClientID{}
-
+	// Go through the offers in a fixed order so the same combination is picked every time
+	teams := []shared.ClientID{}
 	for team, offer := range offers {
-		currentOffer := shared.GiftOffer(0)
-		var currentCombi []shared.ClientID
-
-		lessThisOffer := shared.GiftOfferDict{}
-		for team, offer := range offers {
-			lessThisOffer[team] = offer
+		if offer > 0 && offer <= capacity {
+			teams = append(teams, team)
 		}
-		delete(lessThisOffer, team)
-
-		// You might need this if you're debugging <3
-		// fmt.Printf("Looping with: %v \n", lessThisOffer)
+	}
+	sort.Slice(teams, func(i, j int) bool {
+		if offers[teams[i]] != offers[teams[j]] {
+			return offers[teams[i]] > offers[teams[j]]
+		}
+		return teams[i] < teams[j]
+	})
 
-		if offer <= capacity {
-			// Pack and find optimal of remaining capacity
-			optimalWithThisOffer, remainingCombination := offersKnapsackSolver(capacity-offer, lessThisOffer)
-			currentOffer += (offer + optimalWithThisOffer)
-			currentCombi = append(remainingCombination, team)
+	// remaining[i] is the total of the offers from the i-th on, bounding what the rest of a combination can add
+	remaining := make([]shared.GiftOffer, len(teams)+1)
+	for i := len(teams) - 1; i >= 0; i-- {
+		remaining[i] = remaining[i+1] + offers[teams[i]]
+	}
 
-		} else {
-			// Find optimal of remaining capacity
-			optimalWithoutThisOffer, remainingCombination := offersKnapsackSolver(capacity, lessThisOffer)
-			currentOffer += optimalWithoutThisOffer
-			currentCombi = remainingCombination
+	best := shared.GiftOffer(0)
+	bestCombination := []shared.ClientID{}
+	combination := []shared.ClientID{}
+	var search func(i int, total shared.GiftOffer)
+	search = func(i int, total shared.GiftOffer) {
+		if total > best {
+			best = total
+			bestCombination = append([]shared.ClientID{}, combination...)
 		}
-
-		if currentOffer > bestOffer {
-			bestOffer = currentOffer
-			bestCombination = currentCombi
+		if i == len(teams) || best == capacity || total+remaining[i] <= best {
+			return
+		}
+		if offer := offers[teams[i]]; total+offer <= capacity {
+			combination = append(combination, teams[i])
+			search(i+1, total+offer)
+			combination = combination[:len(combination)-1]
 		}
+		search(i+1, total)
 	}
-
-	return bestOffer, bestCombination
+	search(0, 0)
+	return best, bestCombination
 }
 
 func (s *SOMASServer) sanitiseTeamGiftOffers(offers shared.GiftOfferDict, thisTeam shared.ClientID) shared.GiftOfferDict {
 	totalOffers := shared.GiftOffer(0)
 	for team, offer := range offers {
 		totalOffers += offer
-		if s.gameState.ClientInfos[team].LifeStatus == shared.Dead || team == thisTeam || offer == 0 {
+		if s.gameState.ClientInfos[team].LifeStatus == shared.Dead || s.excludedFromGifts(team) || team == thisTeam || offer == 0 {
 			delete(offers, team)
 			// s.logf("%v made an invalid offer", thisTeam)
 		}
@@ -149,7 +186,7 @@ func (s *SOMASServer) sanitiseTeamGiftOffers(offers shared.GiftOfferDict, thisTe
 // getGiftOffers collects all responses from clients to their requests in a map
 func (s *SOMASServer) getGiftOffers(totalRequests map[shared.ClientID]shared.GiftRequestDict) map[shared.ClientID]shared.GiftOfferDict {
 	totalOffers := map[shared.ClientID]shared.GiftOfferDict{}
-	for _, thisTeam := range getNonDeadClientIDs(s.gameState.ClientInfos) {
+	for _, thisTeam := range s.getGiftSessionClientIDs() {
 		// Gather all the requests made to this team
 		requestsToThisTeam := shared.GiftRequestDict{}
 		for fromTeam, indivRequests := range totalRequests {
@@ -166,6 +203,124 @@ func (s *SOMASServer) getGiftOffers(totalRequests map[shared.ClientID]shared.Gif
 	return totalOffers
 }
 
+// negotiateGiftOffers runs the configured number of negotiation rounds over the gift offers. In each round,
+// recipients can counter the offers made to them, and givers can revise or withdraw the offers countered.
+// Every move is recorded in the negotiation transcript of the game state.
+func (s *SOMASServer) negotiateGiftOffers(totalOffers map[shared.ClientID]shared.GiftOfferDict) map[shared.ClientID]shared.GiftOfferDict {
+	s.gameState.IITOGiftNegotiation = []shared.GiftNegotiationEntry{}
+	rounds := s.gameConfig.IITOConfig.GiftNegotiationRounds
+	if rounds == 0 {
+		return totalOffers
+	}
+
+	s.logf("start negotiateGiftOffers")
+	defer s.logf("finish negotiateGiftOffers")
+
+	for _, from := range shared.TeamIDs {
+		for _, to := range shared.TeamIDs {
+			if offer, ok := totalOffers[from][to]; ok {
+				s.recordGiftNegotiationMove(0, from, to, shared.GiftNegotiationMessage{Kind: shared.GiftOpeningOffer, Amount: shared.Resources(offer)})
+			}
+		}
+	}
+
+	for round := uint(1); round <= rounds; round++ {
+		counterOffers := s.getGiftCounterOffers(totalOffers, round)
+		if len(counterOffers) == 0 {
+			break
+		}
+		s.getGiftOfferRevisions(totalOffers, counterOffers, round)
+	}
+
+	// Revised offers may exceed the giver's resources
+	for giver, offers := range totalOffers {
+		totalOffers[giver] = s.sanitiseTeamGiftOffers(offers, giver)
+		if len(totalOffers[giver]) == 0 {
+			delete(totalOffers, giver)
+		}
+	}
+	return totalOffers
+}
+
+// getGiftCounterOffers asks every recipient to counter the offers standing to it, and returns the valid
+// counter-offers keyed by giver then recipient
+func (s *SOMASServer) getGiftCounterOffers(totalOffers map[shared.ClientID]shared.GiftOfferDict, round uint) map[shared.ClientID]map[shared.ClientID]shared.GiftNegotiationMessage {
+	counterOffers := map[shared.ClientID]map[shared.ClientID]shared.GiftNegotiationMessage{}
+	for _, recipient := range shared.TeamIDs {
+		offersToThisTeam := shared.GiftOfferDict{}
+		for giver, offers := range totalOffers {
+			if offer, ok := offers[recipient]; ok {
+				offersToThisTeam[giver] = offer
+			}
+		}
+		if len(offersToThisTeam) == 0 {
+			continue
+		}
+
+		moves := s.clientMap[recipient].NegotiateGiftOffers(offersToThisTeam, round)
+		for _, giver := range shared.TeamIDs {
+			move, ok := moves[giver]
+			if !ok {
+				continue
+			}
+			if _, offered := offersToThisTeam[giver]; !offered || move.Kind != shared.GiftCounterOffer || !(move.Amount > 0) {
+				s.logf("[IITO]: %v made an invalid counter-offer to %v: %v", recipient, giver, move)
+				continue
+			}
+			if counterOffers[giver] == nil {
+				counterOffers[giver] = map[shared.ClientID]shared.GiftNegotiationMessage{}
+			}
+			counterOffers[giver][recipient] = move
+			s.recordGiftNegotiationMove(round, recipient, giver, move)
+		}
+	}
+	return counterOffers
+}
+
+// getGiftOfferRevisions asks every giver to respond to the counter-offers made to it, and applies the valid
+// revisions and withdrawals to the standing offers
+func (s *SOMASServer) getGiftOfferRevisions(totalOffers map[shared.ClientID]shared.GiftOfferDict, counterOffers map[shared.ClientID]map[shared.ClientID]shared.GiftNegotiationMessage, round uint) {
+	for _, giver := range shared.TeamIDs {
+		countersToThisTeam, ok := counterOffers[giver]
+		if !ok {
+			continue
+		}
+
+		moves := s.clientMap[giver].RespondToCounterOffers(countersToThisTeam, round)
+		for _, recipient := range shared.TeamIDs {
+			move, ok := moves[recipient]
+			if !ok {
+				continue
+			}
+			if _, countered := countersToThisTeam[recipient]; !countered {
+				s.logf("[IITO]: %v responded to a counter-offer %v did not make: %v", giver, recipient, move)
+				continue
+			}
+			switch {
+			case move.Kind == shared.GiftOfferRevised && move.Amount > 0:
+				totalOffers[giver][recipient] = shared.GiftOffer(move.Amount)
+			case move.Kind == shared.GiftOfferWithdrawn:
+				move.Amount = 0
+				delete(totalOffers[giver], recipient)
+			default:
+				s.logf("[IITO]: %v made an invalid revision of its offer to %v: %v", giver, recipient, move)
+				continue
+			}
+			s.recordGiftNegotiationMove(round, giver, recipient, move)
+		}
+	}
+}
+
+func (s *SOMASServer) recordGiftNegotiationMove(round uint, from shared.ClientID, to shared.ClientID, move shared.GiftNegotiationMessage) {
+	s.logf("[IITO]: Gift negotiation round %v, %v to %v: %v %v %q", round, from, to, move.Kind, move.Amount, move.Message)
+	s.gameState.IITOGiftNegotiation = append(s.gameState.IITOGiftNegotiation, shared.GiftNegotiationEntry{
+		Round:                  round,
+		From:                   from,
+		To:                     to,
+		GiftNegotiationMessage: move,
+	})
+}
+
 func (s *SOMASServer) sanitiseTeamGiftResponses(responses shared.GiftResponseDict, offers shared.GiftOfferDict, thisTeam shared.ClientID) shared.GiftResponseDict {
 	for team, response := range responses {
 		// If the reason isn't "Accept", the accepted amount should be 0. Otherwise,
@@ -197,7 +352,7 @@ func (s *SOMASServer) sanitiseTeamGiftResponses(responses shared.GiftResponseDic
 func (s *SOMASServer) getGiftResponses(totalOffers map[shared.ClientID]shared.GiftOfferDict) map[shared.ClientID]shared.GiftResponseDict {
 	totalResponses := map[shared.ClientID]shared.GiftResponseDict{}
 
-	for _, id := range getNonDeadClientIDs(s.gameState.ClientInfos) {
+	for _, id := range s.getGiftSessionClientIDs() {
 		offersToThisTeam := shared.GiftOfferDict{}
 		for fromTeam, indivOffers := range totalOffers {
 			if offer, ok := indivOffers[id]; ok {
@@ -235,6 +390,7 @@ func (s *SOMASServer) executeTransactions(transactions map[shared.ClientID]share
 	for fromTeam, responses := range transactions {
 		for toTeam, indivResponse := range responses {
 			giftAmount := s.clientMap[fromTeam].DecideGiftAmount(toTeam, indivResponse.AcceptedAmount)
+			s.reputationRecord(fromTeam).GiftsPromised += indivResponse.AcceptedAmount
 			if giftAmount < 0 {
 				s.logf("[IITO]: Negative resources received in executeTransactions() from %v. Nice Try", fromTeam)
 				continue
@@ -248,6 +404,7 @@ func (s *SOMASServer) executeTransactions(transactions map[shared.ClientID]share
 				if err != nil {
 					s.logf("Ignoring failure to give resources in executeTransactions: %v", err)
 				}
+				s.reputationRecord(fromTeam).GiftsGiven += giftAmount
 				s.clientMap[toTeam].ReceivedGift(giftAmount, fromTeam)
 				s.clientMap[fromTeam].SentGift(giftAmount, toTeam)
 			}
@@ -255,6 +412,375 @@ func (s *SOMASServer) executeTransactions(transactions map[shared.ClientID]share
 	}
 }
 
+// runContractSession collects the contracts islands propose to each other and records those the counterparty signs
+func (s *SOMASServer) runContractSession() {
+	s.logf("start runContractSession")
+	defer s.logf("finish runContractSession")
+
+	for _, proposer := range s.getGiftSessionClientIDs() {
+		for _, proposal := range s.clientMap[proposer].ProposeContracts() {
+			contract, ok := s.sanitiseContractProposal(proposal, proposer)
+			if !ok {
+				s.logf("[IITO]: %v proposed an invalid contract: %+v", proposer, proposal)
+				continue
+			}
+			contract.ID = s.gameState.IITONextContractID
+			s.gameState.IITONextContractID++
+
+			if s.clientMap[contract.Counterparty].RespondToContract(contract.Copy()) {
+				s.logf("[IITO]: %v signed contract %v proposed by %v: %+v", contract.Counterparty, contract.ID, proposer, contract.Obligations)
+				s.gameState.IITOContracts = append(s.gameState.IITOContracts, contract)
+			}
+		}
+	}
+}
+
+// sanitiseContractProposal checks the proposal is between two islands taking part in the gift session,
+// and that each of its obligations is a positive payment from one party to the other
+func (s *SOMASServer) sanitiseContractProposal(proposal shared.Contract, proposer shared.ClientID) (shared.Contract, bool) {
+	counterparty := proposal.Counterparty
+	if _, ok := s.clientMap[counterparty]; !ok || counterparty == proposer {
+		return shared.Contract{}, false
+	}
+	if s.gameState.ClientInfos[counterparty].LifeStatus == shared.Dead || s.excludedFromGifts(counterparty) {
+		return shared.Contract{}, false
+	}
+	if len(proposal.Obligations) == 0 {
+		return shared.Contract{}, false
+	}
+
+	contract := proposal.Copy()
+	for i, obligation := range contract.Obligations {
+		if obligation.From != proposer && obligation.From != counterparty {
+			return shared.Contract{}, false
+		}
+		if !(obligation.Amount > 0) || obligation.Turns == 0 {
+			return shared.Contract{}, false
+		}
+		contract.Obligations[i].PaymentsMade = 0
+	}
+	contract.Proposer = proposer
+	contract.SignedTurn = s.gameState.Turn
+	contract.SignedSeason = s.gameState.Season
+	contract.Status = shared.ContractActive
+	return contract, true
+}
+
+// executeContracts makes the payments due this turn under the signed contracts. An island failing to make
+// a payment breaks the contract, which ends it.
+func (s *SOMASServer) executeContracts() {
+	s.logf("start executeContracts")
+	defer s.logf("finish executeContracts")
+
+	brokenAgreements := map[shared.ClientID]uint{}
+	for i := range s.gameState.IITOContracts {
+		contract := &s.gameState.IITOContracts[i]
+		if contract.Status != shared.ContractActive {
+			continue
+		}
+		if s.gameState.ClientInfos[contract.Proposer].LifeStatus == shared.Dead ||
+			s.gameState.ClientInfos[contract.Counterparty].LifeStatus == shared.Dead {
+			s.logf("[IITO]: Contract %v between %v and %v is void", contract.ID, contract.Proposer, contract.Counterparty)
+			contract.Status = shared.ContractVoid
+			continue
+		}
+
+		fulfilled := true
+		for j := range contract.Obligations {
+			obligation := &contract.Obligations[j]
+			if obligation.IsDue(contract.SignedSeason, s.gameState.Season) {
+				if !s.payContractObligation(*contract, *obligation) {
+					s.logf("[IITO]: %v broke contract %v with %v", obligation.From, contract.ID, contract.OtherParty(obligation.From))
+					contract.Status = shared.ContractBreached
+					contract.BrokenBy = obligation.From
+					brokenAgreements[obligation.From]++
+					break
+				}
+				obligation.PaymentsMade++
+			}
+			fulfilled = fulfilled && obligation.PaymentsMade >= obligation.Turns
+		}
+		if contract.Status == shared.ContractActive && fulfilled {
+			contract.Status = shared.ContractFulfilled
+		}
+	}
+	s.recordBrokenAgreements(brokenAgreements)
+}
+
+// payContractObligation asks the island owing the payment how much it pays and transfers the amount due.
+// It returns false if the island did not pay in full.
+func (s *SOMASServer) payContractObligation(contract shared.Contract, obligation shared.ContractObligation) bool {
+	from := obligation.From
+	to := contract.OtherParty(from)
+	payment := s.clientMap[from].DecideContractPayment(contract.Copy(), obligation)
+	if !(payment >= obligation.Amount) {
+		s.logf("[IITO]: %v paid %v of the %v due to %v under contract %v", from, payment, obligation.Amount, to, contract.ID)
+		return false
+	}
+
+	transactionMsg := fmt.Sprintf("[IITO]: %v received payment from %v under contract %v: %v", to, from, contract.ID, obligation.Amount)
+	if err := s.takeResources(from, obligation.Amount, "TAKE: "+transactionMsg); err != nil {
+		s.logf("[IITO]: Error deducting contract payment: %v", err)
+		return false
+	}
+	if err := s.giveResources(to, obligation.Amount, "GIVE: "+transactionMsg); err != nil {
+		s.logf("Ignoring failure to give resources in payContractObligation: %v", err)
+	}
+	return true
+}
+
+// recordBrokenAgreements adds the contracts broken this turn to each island's count and reports them
+// in the IIGO history for the Judge
+func (s *SOMASServer) recordBrokenAgreements(brokenAgreements map[shared.ClientID]uint) {
+	for _, clientID := range shared.TeamIDs {
+		broken, ok := brokenAgreements[clientID]
+		if !ok {
+			continue
+		}
+		if s.gameState.IITOBrokenAgreements == nil {
+			s.gameState.IITOBrokenAgreements = map[shared.ClientID]uint{}
+		}
+		s.gameState.IITOBrokenAgreements[clientID] += broken
+		s.reputationRecord(clientID).AgreementsBroken += broken
+		s.updateIIGOHistoryAndRules(clientID, []rules.VariableValuePair{
+			{
+				VariableName: rules.NumberOfBrokenAgreements,
+				Values:       []float64{float64(broken)},
+			},
+		})
+	}
+}
+
+// runLoanSession collects the loans islands offer to each other and lends the principal of those accepted
+func (s *SOMASServer) runLoanSession() {
+	s.logf("start runLoanSession")
+	defer s.logf("finish runLoanSession")
+
+	for _, lender := range s.getGiftSessionClientIDs() {
+		for _, offer := range s.clientMap[lender].GetLoanOffers() {
+			if !s.validLoanOffer(offer, lender) {
+				s.logf("[IITO]: %v made an invalid loan offer: %+v", lender, offer)
+				continue
+			}
+			loan := shared.Loan{
+				LoanOffer:   offer,
+				ID:          s.gameState.IITONextLoanID,
+				Lender:      lender,
+				TurnGranted: s.gameState.Turn,
+				Status:      shared.LoanActive,
+			}
+			s.gameState.IITONextLoanID++
+
+			if !s.clientMap[offer.Borrower].RespondToLoanOffer(loan) {
+				continue
+			}
+			transactionMsg := fmt.Sprintf("[IITO]: %v received loan %v from %v: %v", offer.Borrower, loan.ID, lender, offer.Principal)
+			if err := s.takeResources(lender, offer.Principal, "TAKE: "+transactionMsg); err != nil {
+				s.logf("[IITO]: Error lending resources: %v", err)
+				continue
+			}
+			if err := s.giveResources(offer.Borrower, offer.Principal, "GIVE: "+transactionMsg); err != nil {
+				s.logf("Ignoring failure to give resources in runLoanSession: %v", err)
+			}
+			s.gameState.IITOLoans = append(s.gameState.IITOLoans, loan)
+		}
+	}
+}
+
+// validLoanOffer checks the offer is made to an island taking part in the gift session, and that the loan
+// has a positive principal, a non-negative interest rate and at least one instalment
+func (s *SOMASServer) validLoanOffer(offer shared.LoanOffer, lender shared.ClientID) bool {
+	borrower := offer.Borrower
+	if _, ok := s.clientMap[borrower]; !ok || borrower == lender {
+		return false
+	}
+	if s.gameState.ClientInfos[borrower].LifeStatus == shared.Dead || s.excludedFromGifts(borrower) {
+		return false
+	}
+	return offer.Principal > 0 && offer.InterestRate >= 0 && offer.Instalments > 0
+}
+
+// executeLoanRepayments takes the instalments due this turn from the borrowers and gives them to the lenders.
+// A borrower unable to pay an instalment defaults on the loan, which ends it.
+func (s *SOMASServer) executeLoanRepayments() {
+	s.logf("start executeLoanRepayments")
+	defer s.logf("finish executeLoanRepayments")
+
+	defaults := map[shared.ClientID]uint{}
+	petitions := map[shared.ClientID]uint{}
+	for i := range s.gameState.IITOLoans {
+		loan := &s.gameState.IITOLoans[i]
+		if loan.Status != shared.LoanActive || loan.TurnGranted >= s.gameState.Turn {
+			continue
+		}
+		if s.gameState.ClientInfos[loan.Lender].LifeStatus == shared.Dead {
+			s.logf("[IITO]: Loan %v from %v to %v is written off", loan.ID, loan.Lender, loan.Borrower)
+			loan.Status = shared.LoanWrittenOff
+			continue
+		}
+
+		if err := s.repayLoanInstalment(*loan); err != nil {
+			s.logf("[IITO]: %v defaulted on loan %v from %v, owing %v: %v", loan.Borrower, loan.ID, loan.Lender, loan.Outstanding(), err)
+			loan.Status = shared.LoanDefaulted
+			defaults[loan.Borrower]++
+			if s.gameState.ClientInfos[loan.Borrower].LifeStatus != shared.Dead {
+				loan.Petitioned = s.clientMap[loan.Lender].PetitionJudgeOverDefault(*loan)
+			}
+			if loan.Petitioned {
+				petitions[loan.Borrower]++
+			}
+			continue
+		}
+		loan.InstalmentsPaid++
+		if loan.InstalmentsPaid >= loan.Instalments {
+			loan.Status = shared.LoanRepaid
+		}
+	}
+	s.recordLoanDefaults(defaults, petitions)
+}
+
+func (s *SOMASServer) repayLoanInstalment(loan shared.Loan) error {
+	if s.gameState.ClientInfos[loan.Borrower].LifeStatus == shared.Dead {
+		return errors.Errorf("Borrower %v is dead", loan.Borrower)
+	}
+	instalment := loan.Instalment()
+	transactionMsg := fmt.Sprintf("[IITO]: %v received repayment of loan %v from %v: %v", loan.Lender, loan.ID, loan.Borrower, instalment)
+	if err := s.takeResources(loan.Borrower, instalment, "TAKE: "+transactionMsg); err != nil {
+		return err
+	}
+	if err := s.giveResources(loan.Lender, instalment, "GIVE: "+transactionMsg); err != nil {
+		s.logf("Ignoring failure to give resources in repayLoanInstalment: %v", err)
+	}
+	return nil
+}
+
+// recordLoanDefaults adds the defaults of this turn to each island's count and reports them in the IIGO
+// history. The defaults the lenders petitioned the Judge over are reported separately.
+func (s *SOMASServer) recordLoanDefaults(defaults map[shared.ClientID]uint, petitions map[shared.ClientID]uint) {
+	for _, clientID := range shared.TeamIDs {
+		defaulted, ok := defaults[clientID]
+		if !ok {
+			continue
+		}
+		if s.gameState.IITOLoanDefaults == nil {
+			s.gameState.IITOLoanDefaults = map[shared.ClientID]uint{}
+		}
+		s.gameState.IITOLoanDefaults[clientID] += defaulted
+		s.reputationRecord(clientID).LoanDefaults += defaulted
+		s.updateIIGOHistoryAndRules(clientID, []rules.VariableValuePair{
+			{
+				VariableName: rules.NumberOfLoanDefaults,
+				Values:       []float64{float64(defaulted)},
+			},
+			{
+				VariableName: rules.NumberOfPetitionedLoanDefaults,
+				Values:       []float64{float64(petitions[clientID])},
+			},
+		})
+	}
+}
+
+// runEscrowSession collects the escrowed gifts islands make and takes their amount from the givers
+func (s *SOMASServer) runEscrowSession() {
+	s.logf("start runEscrowSession")
+	defer s.logf("finish runEscrowSession")
+
+	for _, from := range s.getGiftSessionClientIDs() {
+		for _, offer := range s.clientMap[from].GetEscrowedGiftOffers() {
+			if !s.validEscrowedGiftOffer(offer, from) {
+				s.logf("[IITO]: %v made an invalid escrowed gift: %+v", from, offer)
+				continue
+			}
+			gift := shared.EscrowedGift{
+				EscrowedGiftOffer: offer,
+				ID:                s.gameState.IITONextEscrowedGiftID,
+				From:              from,
+				TurnDeposited:     s.gameState.Turn,
+				Status:            shared.EscrowHeld,
+			}
+			s.gameState.IITONextEscrowedGiftID++
+
+			if err := s.takeResources(from, offer.Amount, fmt.Sprintf("[IITO]: escrowed gift %v to %v", gift.ID, offer.To)); err != nil {
+				s.logf("[IITO]: Error escrowing gift: %v", err)
+				continue
+			}
+			s.gameState.IITOEscrowedGifts = append(s.gameState.IITOEscrowedGifts, gift)
+		}
+	}
+}
+
+// validEscrowedGiftOffer checks the gift is made to an island taking part in the gift session, for a positive
+// amount, and that its condition is well formed
+func (s *SOMASServer) validEscrowedGiftOffer(offer shared.EscrowedGiftOffer, from shared.ClientID) bool {
+	to := offer.To
+	if _, ok := s.clientMap[to]; !ok || to == from {
+		return false
+	}
+	if s.gameState.ClientInfos[to].LifeStatus == shared.Dead || s.excludedFromGifts(to) {
+		return false
+	}
+	if !(offer.Amount > 0) || offer.ExpiryTurns == 0 {
+		return false
+	}
+	switch offer.Condition {
+	case shared.RecipientHitByDisaster, shared.RecipientCritical:
+		return true
+	case shared.RecipientVotedOnRule:
+		return offer.RuleName != "" && offer.Vote >= shared.Approve && offer.Vote <= shared.Abstain
+	default:
+		return false
+	}
+}
+
+// resolveEscrowedGifts releases the escrowed gifts whose condition is met to their recipient, and returns those
+// which expire this turn to their giver. disasterEffects are the effects of this turn's disaster, if any.
+func (s *SOMASServer) resolveEscrowedGifts(disasterEffects map[shared.ClientID]shared.Magnitude) {
+	s.logf("start resolveEscrowedGifts")
+	defer s.logf("finish resolveEscrowedGifts")
+
+	for i := range s.gameState.IITOEscrowedGifts {
+		gift := &s.gameState.IITOEscrowedGifts[i]
+		if gift.Status != shared.EscrowHeld {
+			continue
+		}
+		recipientAlive := s.gameState.ClientInfos[gift.To].LifeStatus != shared.Dead
+		if recipientAlive && s.escrowConditionMet(*gift, disasterEffects) {
+			transactionMsg := fmt.Sprintf("[IITO]: %v received escrowed gift %v from %v: %v", gift.To, gift.ID, gift.From, gift.Amount)
+			if err := s.giveResources(gift.To, gift.Amount, transactionMsg); err != nil {
+				s.logf("Ignoring failure to give resources in resolveEscrowedGifts: %v", err)
+			}
+			gift.Status = shared.EscrowReleased
+			gift.TurnResolved = s.gameState.Turn
+			s.clientMap[gift.To].ReceivedGift(gift.Amount, gift.From)
+			if s.gameState.ClientInfos[gift.From].LifeStatus != shared.Dead {
+				s.clientMap[gift.From].SentGift(gift.Amount, gift.To)
+			}
+			continue
+		}
+		if !recipientAlive || s.gameState.Turn+1 >= gift.TurnDeposited+gift.ExpiryTurns {
+			if err := s.giveResources(gift.From, gift.Amount, fmt.Sprintf("[IITO]: escrowed gift %v to %v returned", gift.ID, gift.To)); err != nil {
+				s.logf("Ignoring failure to give resources in resolveEscrowedGifts: %v", err)
+			}
+			gift.Status = shared.EscrowReturned
+			gift.TurnResolved = s.gameState.Turn
+		}
+	}
+}
+
+func (s *SOMASServer) escrowConditionMet(gift shared.EscrowedGift, disasterEffects map[shared.ClientID]shared.Magnitude) bool {
+	switch gift.Condition {
+	case shared.RecipientHitByDisaster:
+		return disasterEffects[gift.To] > 0
+	case shared.RecipientCritical:
+		return s.gameState.ClientInfos[gift.To].LifeStatus == shared.Critical
+	case shared.RecipientVotedOnRule:
+		vote, voted := s.gameState.IIGORuleBallots[gift.RuleName][gift.To]
+		return voted && vote == gift.Vote
+	default:
+		return false
+	}
+}
+
 func (s *SOMASServer) runIntendedContributionSession() {
 	s.logf("start runIntendedContributionSession")
 	defer s.logf("finish runIntendedContributionSession")
@@ -266,10 +792,24 @@ func (s *SOMASServer) getIntendedContribution() shared.IntendedContributionDict
 	islandPredictionsDict := shared.IntendedContributionDict{}
 	for _, id := range getNonDeadClientIDs(s.gameState.ClientInfos) {
 		islandPredictionsDict[id] = s.clientMap[id].ShareIntendedContribution()
+		s.recordIntendedContribution(id, islandPredictionsDict[id])
 	}
 	return islandPredictionsDict
 }
 
+// recordIntendedContribution records the contribution in the island's reputation record if it was announced to
+// another island
+func (s *SOMASServer) recordIntendedContribution(id shared.ClientID, contribution shared.IntendedContribution) {
+	for _, sharedWith := range contribution.TeamsOfferedTo {
+		if sharedWith != id {
+			record := s.reputationRecord(id)
+			record.ContributionAnnounced = true
+			record.IntendedContribution = contribution.Contribution
+			return
+		}
+	}
+}
+
 func (s *SOMASServer) distributeIntendedContributions(islandPredictionDict shared.IntendedContributionDict) {
 	reorderDictionary := make(map[shared.ClientID]shared.ReceivedIntendedContributionDict)
 	// Add the predictions/sources to the dict containing which predictions each island should receive
@@ -6,7 +6,9 @@ import (
 	"testing"
 
 	"github.com/SOMAS2020/SOMAS2020/internal/common/baseclient"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/gamestate"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/rules"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
 )
 
@@ -19,6 +21,20 @@ type mockClientIITO struct {
 	responses               shared.GiftResponseDict
 	receivedResponses       shared.GiftResponseDict
 	otherIslandContribution shared.ReceivedIntendedContributionDict
+	contractProposals       []shared.Contract
+	signContracts           bool
+	withholdPayments        bool
+	loanOffers              []shared.LoanOffer
+	acceptLoans             bool
+	petitionDefaults        bool
+	escrowedGiftOffers      []shared.EscrowedGiftOffer
+	giftsReceived           map[shared.ClientID]shared.Resources
+	giftsSent               map[shared.ClientID]shared.Resources
+	counterOffers           map[uint]map[shared.ClientID]shared.GiftNegotiationMessage
+	offerRevisions          map[uint]map[shared.ClientID]shared.GiftNegotiationMessage
+	marketOrders            []shared.MarketOrder
+	intendedContribution    shared.IntendedContribution
+	withholdGifts           bool
 }
 
 func (c *mockClientIITO) GetGiftRequests() shared.GiftRequestDict {
@@ -37,12 +53,80 @@ func (c *mockClientIITO) UpdateGiftInfo(responses shared.GiftResponseDict) {
 	c.receivedResponses = responses
 }
 
+func (c *mockClientIITO) DecideGiftAmount(toTeam shared.ClientID, giftOffer shared.Resources) shared.Resources {
+	if c.withholdGifts {
+		return giftOffer / 2
+	}
+	return giftOffer
+}
+
+func (c *mockClientIITO) ShareIntendedContribution() shared.IntendedContribution {
+	return c.intendedContribution
+}
+
 func (c *mockClientIITO) ReceiveIntendedContribution(receivedIntendedContribution shared.ReceivedIntendedContributionDict) {
 	// You can check the other's common pool contributions like this
 	// intededContributions := c.intendedContribution
 	c.otherIslandContribution = receivedIntendedContribution
 
 }
+func (c *mockClientIITO) ProposeContracts() []shared.Contract {
+	return c.contractProposals
+}
+
+func (c *mockClientIITO) RespondToContract(proposal shared.Contract) bool {
+	return c.signContracts
+}
+
+func (c *mockClientIITO) DecideContractPayment(contract shared.Contract, obligation shared.ContractObligation) shared.Resources {
+	if c.withholdPayments {
+		return obligation.Amount / 2
+	}
+	return obligation.Amount
+}
+
+func (c *mockClientIITO) GetLoanOffers() []shared.LoanOffer {
+	return c.loanOffers
+}
+
+func (c *mockClientIITO) RespondToLoanOffer(offer shared.Loan) bool {
+	return c.acceptLoans
+}
+
+func (c *mockClientIITO) PetitionJudgeOverDefault(loan shared.Loan) bool {
+	return c.petitionDefaults
+}
+
+func (c *mockClientIITO) GetEscrowedGiftOffers() []shared.EscrowedGiftOffer {
+	return c.escrowedGiftOffers
+}
+
+func (c *mockClientIITO) NegotiateGiftOffers(offers shared.GiftOfferDict, round uint) map[shared.ClientID]shared.GiftNegotiationMessage {
+	return c.counterOffers[round]
+}
+
+func (c *mockClientIITO) RespondToCounterOffers(counterOffers map[shared.ClientID]shared.GiftNegotiationMessage, round uint) map[shared.ClientID]shared.GiftNegotiationMessage {
+	return c.offerRevisions[round]
+}
+
+func (c *mockClientIITO) GetMarketOrders() []shared.MarketOrder {
+	return c.marketOrders
+}
+
+func (c *mockClientIITO) ReceivedGift(received shared.Resources, from shared.ClientID) {
+	if c.giftsReceived == nil {
+		c.giftsReceived = map[shared.ClientID]shared.Resources{}
+	}
+	c.giftsReceived[from] += received
+}
+
+func (c *mockClientIITO) SentGift(sent shared.Resources, to shared.ClientID) {
+	if c.giftsSent == nil {
+		c.giftsSent = map[shared.ClientID]shared.Resources{}
+	}
+	c.giftsSent[to] += sent
+}
+
 func shareIntendedContribution(contribution shared.Resources, shareTo []shared.ClientID) shared.IntendedContribution {
 	if len(shareTo) > 0 {
 		return shared.IntendedContribution{
@@ -109,23 +193,163 @@ func TestServerGetGiftRequests(t *testing.T) {
 
 }
 
-func TestOfferKnapsackPacker(t *testing.T) {
-	offers := shared.GiftOfferDict{
-		shared.Team1: 200,
-		shared.Team2: 500,
-		shared.Team3: 500,
+// Test that islands barred from the gift session by a sanction can neither make nor receive requests and offers
+func TestGiftSessionExcludesSanctionedIslands(t *testing.T) {
+	clientInfos := map[shared.ClientID]gamestate.ClientInfo{
+		shared.Team1: {Resources: 500, LifeStatus: shared.Alive},
+		shared.Team2: {Resources: 500, LifeStatus: shared.Alive},
+		shared.Team3: {Resources: 500, LifeStatus: shared.Alive},
+	}
+
+	clientMap := map[shared.ClientID]baseclient.Client{
+		shared.Team1: &mockClientIITO{
+			requests: shared.GiftRequestDict{shared.Team2: 50, shared.Team3: 50},
+			offers:   shared.GiftOfferDict{shared.Team2: 20, shared.Team3: 20},
+		},
+		shared.Team2: &mockClientIITO{
+			offers: shared.GiftOfferDict{shared.Team1: 30, shared.Team3: 30},
+		},
+		// Team 3 is excluded from the gift session
+		shared.Team3: &mockClientIITO{
+			requests: shared.GiftRequestDict{shared.Team1: 50},
+			offers:   shared.GiftOfferDict{shared.Team1: 40},
+		},
+	}
+
+	s := &SOMASServer{
+		gameState: gamestate.GameState{
+			ClientInfos: clientInfos,
+			IIGOSanctionConsequences: map[shared.ClientID]map[shared.SanctionConsequence]uint{
+				shared.Team2: {shared.LoseVotingRight: 1},
+				shared.Team3: {shared.ExcludedFromGifts: 2},
+			},
+		},
+		clientMap: clientMap,
 	}
 
-	want := shared.GiftOffer(1000)
-	wantCombi := []shared.ClientID{shared.Team2, shared.Team3}
+	wantRequests := map[shared.ClientID]shared.GiftRequestDict{
+		shared.Team1: {shared.Team2: 50},
+	}
+	requests := s.getGiftRequests()
+	if !reflect.DeepEqual(wantRequests, requests) {
+		t.Errorf("want requests '%v' got '%v'", wantRequests, requests)
+	}
 
-	got, optimal := offersKnapsackSolver(1000, offers)
-	sort.Sort(shared.SortClientByID(optimal))
-	if !reflect.DeepEqual(want, got) {
-		t.Errorf("want '%v' got '%v'", want, got)
+	wantOffers := map[shared.ClientID]shared.GiftOfferDict{
+		shared.Team1: {shared.Team2: 20},
+		shared.Team2: {shared.Team1: 30},
 	}
-	if !reflect.DeepEqual(wantCombi, optimal) {
-		t.Errorf("want '%v' got '%v'", wantCombi, optimal)
+	offers := s.getGiftOffers(requests)
+	if !reflect.DeepEqual(wantOffers, offers) {
+		t.Errorf("want offers '%v' got '%v'", wantOffers, offers)
+	}
+}
+
+func TestOfferKnapsackPacker(t *testing.T) {
+	manyOffers := shared.GiftOfferDict{}
+	for i := 0; i < 60; i++ {
+		manyOffers[shared.ClientID(i)] = shared.GiftOffer(7 + 3*i)
+	}
+
+	cases := []struct {
+		name      string
+		capacity  shared.GiftOffer
+		offers    shared.GiftOfferDict
+		want      shared.GiftOffer
+		wantCombi []shared.ClientID
+	}{
+		{
+			name:     "basic",
+			capacity: 1000,
+			offers: shared.GiftOfferDict{
+				shared.Team1: 200,
+				shared.Team2: 500,
+				shared.Team3: 500,
+			},
+			want:      1000,
+			wantCombi: []shared.ClientID{shared.Team2, shared.Team3},
+		},
+		{
+			name:     "greedy is not optimal",
+			capacity: 100,
+			offers: shared.GiftOfferDict{
+				shared.Team1: 60,
+				shared.Team2: 50,
+				shared.Team3: 50,
+			},
+			want:      100,
+			wantCombi: []shared.ClientID{shared.Team2, shared.Team3},
+		},
+		{
+			name:     "fractional offers",
+			capacity: 10.5,
+			offers: shared.GiftOfferDict{
+				shared.Team1: 2.5,
+				shared.Team2: 8,
+				shared.Team3: 11,
+			},
+			want:      10.5,
+			wantCombi: []shared.ClientID{shared.Team1, shared.Team2},
+		},
+		{
+			name:     "large capacity",
+			capacity: 3e6,
+			offers: shared.GiftOfferDict{
+				shared.Team1: 1e6,
+				shared.Team2: 2e6,
+				shared.Team3: 2.5e6,
+			},
+			want:      3e6,
+			wantCombi: []shared.ClientID{shared.Team1, shared.Team2},
+		},
+		{
+			// Team 1 and Team 2 differ by less than a 1e5th of the capacity, so a solver
+			// rounding the offers to that resolution cannot tell which one fits with Team 3
+			name:     "offers closer than rounding",
+			capacity: 1,
+			offers: shared.GiftOfferDict{
+				shared.Team1: 0.25 + 1.0/(1<<20),
+				shared.Team2: 0.25 - 1.0/(1<<20),
+				shared.Team3: 0.75,
+			},
+			want:      1 - 1.0/(1<<20),
+			wantCombi: []shared.ClientID{shared.Team2, shared.Team3},
+		},
+		{
+			name:      "no capacity",
+			capacity:  0,
+			offers:    shared.GiftOfferDict{shared.Team1: 10},
+			want:      0,
+			wantCombi: []shared.ClientID{},
+		},
+		{
+			// Many combinations of the offers add up to exactly the capacity
+			name:      "many islands",
+			capacity:  3000,
+			offers:    manyOffers,
+			want:      3000,
+			wantCombi: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, optimal := offersKnapsackSolver(tc.capacity, tc.offers)
+			sort.Sort(shared.SortClientByID(optimal))
+			if !reflect.DeepEqual(tc.want, got) {
+				t.Errorf("want '%v' got '%v'", tc.want, got)
+			}
+			total := shared.GiftOffer(0)
+			for _, team := range optimal {
+				total += tc.offers[team]
+			}
+			if total != got {
+				t.Errorf("combination '%v' adds up to '%v' not '%v'", optimal, total, got)
+			}
+			if tc.wantCombi != nil && !reflect.DeepEqual(tc.wantCombi, optimal) {
+				t.Errorf("want '%v' got '%v'", tc.wantCombi, optimal)
+			}
+		})
 	}
 }
 
@@ -390,3 +614,764 @@ func TestDistributeContributions(t *testing.T) {
 	}
 
 }
+
+func TestNegotiateGiftOffers(t *testing.T) {
+	counter := func(amount shared.Resources, message string) shared.GiftNegotiationMessage {
+		return shared.GiftNegotiationMessage{Kind: shared.GiftCounterOffer, Amount: amount, Message: message}
+	}
+	revise := func(amount shared.Resources) shared.GiftNegotiationMessage {
+		return shared.GiftNegotiationMessage{Kind: shared.GiftOfferRevised, Amount: amount}
+	}
+	withdraw := shared.GiftNegotiationMessage{Kind: shared.GiftOfferWithdrawn, Amount: 5, Message: "never mind"}
+	opening := func(from shared.ClientID, to shared.ClientID, amount shared.Resources) shared.GiftNegotiationEntry {
+		return shared.GiftNegotiationEntry{From: from, To: to, GiftNegotiationMessage: shared.GiftNegotiationMessage{Kind: shared.GiftOpeningOffer, Amount: amount}}
+	}
+
+	cases := []struct {
+		name           string
+		rounds         uint
+		counterOffers  map[shared.ClientID]map[uint]map[shared.ClientID]shared.GiftNegotiationMessage
+		offerRevisions map[uint]map[shared.ClientID]shared.GiftNegotiationMessage
+		want           map[shared.ClientID]shared.GiftOfferDict
+		wantTranscript []shared.GiftNegotiationEntry
+	}{
+		{
+			name: "negotiation disabled",
+			counterOffers: map[shared.ClientID]map[uint]map[shared.ClientID]shared.GiftNegotiationMessage{
+				shared.Team2: {1: {shared.Team1: counter(30, "")}},
+			},
+			want: map[shared.ClientID]shared.GiftOfferDict{
+				shared.Team1: {shared.Team2: 10, shared.Team3: 20},
+			},
+			wantTranscript: []shared.GiftNegotiationEntry{},
+		},
+		{
+			name:   "counter-offers revised and withdrawn",
+			rounds: 3,
+			counterOffers: map[shared.ClientID]map[uint]map[shared.ClientID]shared.GiftNegotiationMessage{
+				shared.Team2: {
+					1: {
+						shared.Team1: counter(30, "we were hit hard"),
+						// No offer from Team 3
+						shared.Team3: counter(30, ""),
+					},
+					2: {shared.Team1: counter(28, "")},
+				},
+				shared.Team3: {
+					1: {shared.Team1: counter(25, "")},
+					// Invalid counter-offers
+					2: {shared.Team1: counter(0, "")},
+					3: {shared.Team1: revise(40)},
+				},
+			},
+			offerRevisions: map[uint]map[shared.ClientID]shared.GiftNegotiationMessage{
+				1: {shared.Team2: revise(25), shared.Team3: withdraw},
+				// Team 2 did not counter in round 3, and negative revisions are invalid
+				2: {shared.Team2: revise(-5)},
+				3: {shared.Team2: revise(28)},
+			},
+			want: map[shared.ClientID]shared.GiftOfferDict{
+				shared.Team1: {shared.Team2: 25},
+			},
+			wantTranscript: []shared.GiftNegotiationEntry{
+				opening(shared.Team1, shared.Team2, 10),
+				opening(shared.Team1, shared.Team3, 20),
+				{Round: 1, From: shared.Team2, To: shared.Team1, GiftNegotiationMessage: counter(30, "we were hit hard")},
+				{Round: 1, From: shared.Team3, To: shared.Team1, GiftNegotiationMessage: counter(25, "")},
+				{Round: 1, From: shared.Team1, To: shared.Team2, GiftNegotiationMessage: revise(25)},
+				{Round: 1, From: shared.Team1, To: shared.Team3, GiftNegotiationMessage: shared.GiftNegotiationMessage{Kind: shared.GiftOfferWithdrawn, Message: "never mind"}},
+				{Round: 2, From: shared.Team2, To: shared.Team1, GiftNegotiationMessage: counter(28, "")},
+			},
+		},
+		{
+			name:   "revised offers exceeding resources",
+			rounds: 1,
+			counterOffers: map[shared.ClientID]map[uint]map[shared.ClientID]shared.GiftNegotiationMessage{
+				shared.Team2: {1: {shared.Team1: counter(120, "")}},
+			},
+			offerRevisions: map[uint]map[shared.ClientID]shared.GiftNegotiationMessage{
+				1: {shared.Team2: revise(120)},
+			},
+			want: map[shared.ClientID]shared.GiftOfferDict{
+				shared.Team1: {shared.Team3: 20},
+			},
+			wantTranscript: []shared.GiftNegotiationEntry{
+				opening(shared.Team1, shared.Team2, 10),
+				opening(shared.Team1, shared.Team3, 20),
+				{Round: 1, From: shared.Team2, To: shared.Team1, GiftNegotiationMessage: counter(120, "")},
+				{Round: 1, From: shared.Team1, To: shared.Team2, GiftNegotiationMessage: revise(120)},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			clientMap := map[shared.ClientID]baseclient.Client{
+				shared.Team1: &mockClientIITO{offerRevisions: tc.offerRevisions},
+				shared.Team2: &mockClientIITO{counterOffers: tc.counterOffers[shared.Team2]},
+				shared.Team3: &mockClientIITO{counterOffers: tc.counterOffers[shared.Team3]},
+			}
+			s := &SOMASServer{
+				gameState: gamestate.GameState{
+					ClientInfos: map[shared.ClientID]gamestate.ClientInfo{
+						shared.Team1: {Resources: 100, LifeStatus: shared.Alive},
+						shared.Team2: {Resources: 100, LifeStatus: shared.Alive},
+						shared.Team3: {Resources: 100, LifeStatus: shared.Alive},
+					},
+				},
+				gameConfig: config.Config{
+					IITOConfig: config.IITOConfig{GiftNegotiationRounds: tc.rounds},
+				},
+				clientMap: clientMap,
+			}
+			offers := map[shared.ClientID]shared.GiftOfferDict{
+				shared.Team1: {shared.Team2: 10, shared.Team3: 20},
+			}
+
+			got := s.negotiateGiftOffers(offers)
+
+			if !reflect.DeepEqual(tc.want, got) {
+				t.Errorf("want offers '%v' got '%v'", tc.want, got)
+			}
+			if !reflect.DeepEqual(tc.wantTranscript, s.gameState.IITOGiftNegotiation) {
+				t.Errorf("want transcript '%v' got '%v'", tc.wantTranscript, s.gameState.IITOGiftNegotiation)
+			}
+		})
+	}
+}
+
+func TestRunContractSession(t *testing.T) {
+	validObligations := []shared.ContractObligation{
+		{From: shared.Team1, Amount: 10, Turns: 5},
+		{From: shared.Team2, Amount: 15, Turns: 1, AfterDisaster: true},
+	}
+	clientMap := map[shared.ClientID]baseclient.Client{
+		shared.Team1: &mockClientIITO{
+			contractProposals: []shared.Contract{
+				{Counterparty: shared.Team2, Obligations: validObligations},
+				// Declined by Team 3
+				{Counterparty: shared.Team3, Obligations: []shared.ContractObligation{{From: shared.Team1, Amount: 5, Turns: 1}}},
+				// Invalid proposals
+				{Counterparty: shared.Team1, Obligations: []shared.ContractObligation{{From: shared.Team1, Amount: 5, Turns: 1}}},
+				{Counterparty: shared.Team4, Obligations: []shared.ContractObligation{{From: shared.Team1, Amount: 5, Turns: 1}}},
+				{Counterparty: shared.Team2},
+				{Counterparty: shared.Team2, Obligations: []shared.ContractObligation{{From: shared.Team3, Amount: 5, Turns: 1}}},
+				{Counterparty: shared.Team2, Obligations: []shared.ContractObligation{{From: shared.Team2, Amount: -5, Turns: 1}}},
+				{Counterparty: shared.Team2, Obligations: []shared.ContractObligation{{From: shared.Team2, Amount: 5, Turns: 0}}},
+			},
+		},
+		shared.Team2: &mockClientIITO{signContracts: true},
+		shared.Team3: &mockClientIITO{},
+		shared.Team4: &mockClientIITO{signContracts: true},
+	}
+	s := &SOMASServer{
+		gameState: gamestate.GameState{
+			Turn:   3,
+			Season: 2,
+			ClientInfos: map[shared.ClientID]gamestate.ClientInfo{
+				shared.Team1: {Resources: 100, LifeStatus: shared.Alive},
+				shared.Team2: {Resources: 100, LifeStatus: shared.Alive},
+				shared.Team3: {Resources: 100, LifeStatus: shared.Critical},
+				shared.Team4: {Resources: 100, LifeStatus: shared.Alive},
+			},
+			// Team 4 is excluded from the gift session
+			IIGOSanctionConsequences: map[shared.ClientID]map[shared.SanctionConsequence]uint{
+				shared.Team4: {shared.ExcludedFromGifts: 1},
+			},
+			IITONextContractID: 4,
+		},
+		clientMap: clientMap,
+	}
+
+	s.runContractSession()
+
+	want := []shared.Contract{
+		{
+			ID:           4,
+			Proposer:     shared.Team1,
+			Counterparty: shared.Team2,
+			Obligations:  validObligations,
+			SignedTurn:   3,
+			SignedSeason: 2,
+			Status:       shared.ContractActive,
+		},
+	}
+	if !reflect.DeepEqual(want, s.gameState.IITOContracts) {
+		t.Errorf("want contracts '%v' got '%v'", want, s.gameState.IITOContracts)
+	}
+	// Both the signed and the declined proposal are given an ID
+	if s.gameState.IITONextContractID != 6 {
+		t.Errorf("want next contract ID 6 got %v", s.gameState.IITONextContractID)
+	}
+}
+
+func TestExecuteContracts(t *testing.T) {
+	contract := shared.Contract{
+		ID:           1,
+		Proposer:     shared.Team1,
+		Counterparty: shared.Team2,
+		Obligations: []shared.ContractObligation{
+			{From: shared.Team1, Amount: 10, Turns: 2},
+			{From: shared.Team2, Amount: 15, Turns: 1, AfterDisaster: true},
+		},
+		SignedTurn:   1,
+		SignedSeason: 1,
+		Status:       shared.ContractActive,
+	}
+	withPayments := func(c shared.Contract, payments ...uint) shared.Contract {
+		ret := c.Copy()
+		for i, paid := range payments {
+			ret.Obligations[i].PaymentsMade = paid
+		}
+		return ret
+	}
+
+	cases := []struct {
+		name             string
+		contract         shared.Contract
+		season           uint
+		withholding      map[shared.ClientID]bool
+		team2Status      shared.ClientLifeStatus
+		wantPayments     []uint
+		wantStatus       shared.ContractStatus
+		wantBrokenBy     shared.ClientID
+		wantResources    map[shared.ClientID]shared.Resources
+		wantBrokenCounts map[shared.ClientID]uint
+	}{
+		{
+			name:          "Recurring payment made",
+			contract:      contract,
+			season:        1,
+			wantPayments:  []uint{1, 0},
+			wantStatus:    shared.ContractActive,
+			wantResources: map[shared.ClientID]shared.Resources{shared.Team1: 90, shared.Team2: 110},
+		},
+		{
+			name:          "Payments after the next disaster",
+			contract:      withPayments(contract, 1),
+			season:        2,
+			wantPayments:  []uint{2, 1},
+			wantStatus:    shared.ContractFulfilled,
+			wantResources: map[shared.ClientID]shared.Resources{shared.Team1: 105, shared.Team2: 95},
+		},
+		{
+			name:          "Waiting for a disaster",
+			contract:      withPayments(contract, 2),
+			season:        1,
+			wantPayments:  []uint{2, 0},
+			wantStatus:    shared.ContractActive,
+			wantResources: map[shared.ClientID]shared.Resources{shared.Team1: 100, shared.Team2: 100},
+		},
+		{
+			name:             "Payment withheld",
+			contract:         withPayments(contract, 1),
+			season:           2,
+			withholding:      map[shared.ClientID]bool{shared.Team2: true},
+			wantPayments:     []uint{2, 0},
+			wantStatus:       shared.ContractBreached,
+			wantBrokenBy:     shared.Team2,
+			wantResources:    map[shared.ClientID]shared.Resources{shared.Team1: 90, shared.Team2: 110},
+			wantBrokenCounts: map[shared.ClientID]uint{shared.Team2: 1},
+		},
+		{
+			name:          "Party dead",
+			contract:      contract,
+			season:        1,
+			team2Status:   shared.Dead,
+			wantPayments:  []uint{0, 0},
+			wantStatus:    shared.ContractVoid,
+			wantResources: map[shared.ClientID]shared.Resources{shared.Team1: 100, shared.Team2: 100},
+		},
+		{
+			name:          "Ended contracts ignored",
+			contract:      withPayments(shared.Contract{Status: shared.ContractBreached, Obligations: contract.Obligations}),
+			season:        2,
+			wantPayments:  []uint{0, 0},
+			wantStatus:    shared.ContractBreached,
+			wantResources: map[shared.ClientID]shared.Resources{shared.Team1: 100, shared.Team2: 100},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			clientMap := map[shared.ClientID]baseclient.Client{
+				shared.Team1: &mockClientIITO{withholdPayments: tc.withholding[shared.Team1]},
+				shared.Team2: &mockClientIITO{withholdPayments: tc.withholding[shared.Team2]},
+			}
+			s := &SOMASServer{
+				gameState: gamestate.GameState{
+					Turn:   4,
+					Season: tc.season,
+					ClientInfos: map[shared.ClientID]gamestate.ClientInfo{
+						shared.Team1: {Resources: 100, LifeStatus: shared.Alive},
+						shared.Team2: {Resources: 100, LifeStatus: tc.team2Status},
+					},
+					IITOContracts: []shared.Contract{tc.contract.Copy()},
+					IIGOHistory:   map[uint][]shared.Accountability{},
+				},
+				clientMap: clientMap,
+			}
+
+			s.executeContracts()
+
+			got := s.gameState.IITOContracts[0]
+			if got.Status != tc.wantStatus {
+				t.Errorf("want status %v got %v", tc.wantStatus, got.Status)
+			}
+			if tc.wantStatus == shared.ContractBreached && got.BrokenBy != tc.wantBrokenBy {
+				t.Errorf("want contract broken by %v got %v", tc.wantBrokenBy, got.BrokenBy)
+			}
+			for i, want := range tc.wantPayments {
+				if got.Obligations[i].PaymentsMade != want {
+					t.Errorf("want %v payments for obligation %v got %v", want, i, got.Obligations[i].PaymentsMade)
+				}
+			}
+			for clientID, want := range tc.wantResources {
+				if got := s.gameState.ClientInfos[clientID].Resources; got != want {
+					t.Errorf("want %v resources for %v got %v", want, clientID, got)
+				}
+			}
+			if !reflect.DeepEqual(tc.wantBrokenCounts, s.gameState.IITOBrokenAgreements) {
+				t.Errorf("want broken agreements '%v' got '%v'", tc.wantBrokenCounts, s.gameState.IITOBrokenAgreements)
+			}
+			// Breaches are reported to the Judge
+			wantHistory := []shared.Accountability{}
+			for clientID, broken := range tc.wantBrokenCounts {
+				wantHistory = append(wantHistory, shared.Accountability{
+					ClientID: clientID,
+					Pairs: []rules.VariableValuePair{
+						{VariableName: rules.NumberOfBrokenAgreements, Values: []float64{float64(broken)}},
+					},
+				})
+			}
+			if gotHistory := s.gameState.IIGOHistory[4]; len(wantHistory) > 0 || len(gotHistory) > 0 {
+				if !reflect.DeepEqual(wantHistory, gotHistory) {
+					t.Errorf("want history '%v' got '%v'", wantHistory, gotHistory)
+				}
+			}
+		})
+	}
+}
+
+func TestRunLoanSession(t *testing.T) {
+	validOffer := shared.LoanOffer{Borrower: shared.Team2, Principal: 50, InterestRate: 0.2, Instalments: 3}
+	clientMap := map[shared.ClientID]baseclient.Client{
+		shared.Team1: &mockClientIITO{
+			loanOffers: []shared.LoanOffer{
+				validOffer,
+				// Declined by Team 3
+				{Borrower: shared.Team3, Principal: 10, Instalments: 1},
+				// More than Team 1 has left
+				{Borrower: shared.Team2, Principal: 60, Instalments: 1},
+				// Invalid offers
+				{Borrower: shared.Team1, Principal: 10, Instalments: 1},
+				{Borrower: shared.Team4, Principal: 10, Instalments: 1},
+				{Borrower: shared.Team2, Principal: 0, Instalments: 1},
+				{Borrower: shared.Team2, Principal: 10, InterestRate: -0.1, Instalments: 1},
+				{Borrower: shared.Team2, Principal: 10, Instalments: 0},
+			},
+		},
+		shared.Team2: &mockClientIITO{acceptLoans: true},
+		shared.Team3: &mockClientIITO{},
+		shared.Team4: &mockClientIITO{acceptLoans: true},
+	}
+	s := &SOMASServer{
+		gameState: gamestate.GameState{
+			Turn: 2,
+			ClientInfos: map[shared.ClientID]gamestate.ClientInfo{
+				shared.Team1: {Resources: 100, LifeStatus: shared.Alive},
+				shared.Team2: {Resources: 5, LifeStatus: shared.Critical},
+				shared.Team3: {Resources: 100, LifeStatus: shared.Alive},
+				shared.Team4: {Resources: 100, LifeStatus: shared.Dead},
+			},
+		},
+		clientMap: clientMap,
+	}
+
+	s.runLoanSession()
+
+	want := []shared.Loan{
+		{
+			LoanOffer:   validOffer,
+			ID:          0,
+			Lender:      shared.Team1,
+			TurnGranted: 2,
+			Status:      shared.LoanActive,
+		},
+	}
+	if !reflect.DeepEqual(want, s.gameState.IITOLoans) {
+		t.Errorf("want loans '%v' got '%v'", want, s.gameState.IITOLoans)
+	}
+	if s.gameState.IITONextLoanID != 3 {
+		t.Errorf("want next loan ID 3 got %v", s.gameState.IITONextLoanID)
+	}
+	if got := s.gameState.ClientInfos[shared.Team1].Resources; got != 50 {
+		t.Errorf("want 50 resources for the lender got %v", got)
+	}
+	if got := s.gameState.ClientInfos[shared.Team2].Resources; got != 55 {
+		t.Errorf("want 55 resources for the borrower got %v", got)
+	}
+}
+
+func TestExecuteLoanRepayments(t *testing.T) {
+	loan := shared.Loan{
+		LoanOffer:   shared.LoanOffer{Borrower: shared.Team2, Principal: 40, InterestRate: 0.5, Instalments: 2},
+		ID:          3,
+		Lender:      shared.Team1,
+		TurnGranted: 2,
+		Status:      shared.LoanActive,
+	}
+	withInstalmentsPaid := func(l shared.Loan, paid uint) shared.Loan {
+		l.InstalmentsPaid = paid
+		return l
+	}
+
+	cases := []struct {
+		name              string
+		loan              shared.Loan
+		turn              uint
+		borrowerResources shared.Resources
+		borrowerStatus    shared.ClientLifeStatus
+		lenderStatus      shared.ClientLifeStatus
+		petition          bool
+		wantLoan          shared.Loan
+		wantResources     map[shared.ClientID]shared.Resources
+		wantDefaults      map[shared.ClientID]uint
+		wantPetitioned    bool
+	}{
+		{
+			name:              "Nothing due in the turn the loan is made",
+			loan:              loan,
+			turn:              2,
+			borrowerResources: 100,
+			wantLoan:          loan,
+			wantResources:     map[shared.ClientID]shared.Resources{shared.Team1: 100, shared.Team2: 100},
+		},
+		{
+			name:              "Instalment repaid",
+			loan:              loan,
+			turn:              3,
+			borrowerResources: 100,
+			wantLoan:          withInstalmentsPaid(loan, 1),
+			wantResources:     map[shared.ClientID]shared.Resources{shared.Team1: 130, shared.Team2: 70},
+		},
+		{
+			name:              "Loan repaid",
+			loan:              withInstalmentsPaid(loan, 1),
+			turn:              4,
+			borrowerResources: 100,
+			wantLoan: shared.Loan{
+				LoanOffer:       loan.LoanOffer,
+				ID:              3,
+				Lender:          shared.Team1,
+				TurnGranted:     2,
+				InstalmentsPaid: 2,
+				Status:          shared.LoanRepaid,
+			},
+			wantResources: map[shared.ClientID]shared.Resources{shared.Team1: 130, shared.Team2: 70},
+		},
+		{
+			name:              "Default without petition",
+			loan:              loan,
+			turn:              3,
+			borrowerResources: 20,
+			wantLoan: shared.Loan{
+				LoanOffer:   loan.LoanOffer,
+				ID:          3,
+				Lender:      shared.Team1,
+				TurnGranted: 2,
+				Status:      shared.LoanDefaulted,
+			},
+			wantResources: map[shared.ClientID]shared.Resources{shared.Team1: 100, shared.Team2: 20},
+			wantDefaults:  map[shared.ClientID]uint{shared.Team2: 1},
+		},
+		{
+			name:              "Default petitioned to the Judge",
+			loan:              loan,
+			turn:              3,
+			borrowerResources: 20,
+			petition:          true,
+			wantLoan: shared.Loan{
+				LoanOffer:   loan.LoanOffer,
+				ID:          3,
+				Lender:      shared.Team1,
+				TurnGranted: 2,
+				Status:      shared.LoanDefaulted,
+				Petitioned:  true,
+			},
+			wantResources:  map[shared.ClientID]shared.Resources{shared.Team1: 100, shared.Team2: 20},
+			wantDefaults:   map[shared.ClientID]uint{shared.Team2: 1},
+			wantPetitioned: true,
+		},
+		{
+			name:              "Dead borrower defaults",
+			loan:              loan,
+			turn:              3,
+			borrowerResources: 100,
+			borrowerStatus:    shared.Dead,
+			petition:          true,
+			wantLoan: shared.Loan{
+				LoanOffer:   loan.LoanOffer,
+				ID:          3,
+				Lender:      shared.Team1,
+				TurnGranted: 2,
+				Status:      shared.LoanDefaulted,
+			},
+			wantResources: map[shared.ClientID]shared.Resources{shared.Team1: 100, shared.Team2: 100},
+			wantDefaults:  map[shared.ClientID]uint{shared.Team2: 1},
+		},
+		{
+			name:              "Dead lender writes the loan off",
+			loan:              loan,
+			turn:              3,
+			borrowerResources: 100,
+			lenderStatus:      shared.Dead,
+			wantLoan: shared.Loan{
+				LoanOffer:   loan.LoanOffer,
+				ID:          3,
+				Lender:      shared.Team1,
+				TurnGranted: 2,
+				Status:      shared.LoanWrittenOff,
+			},
+			wantResources: map[shared.ClientID]shared.Resources{shared.Team1: 100, shared.Team2: 100},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &SOMASServer{
+				gameState: gamestate.GameState{
+					Turn: tc.turn,
+					ClientInfos: map[shared.ClientID]gamestate.ClientInfo{
+						shared.Team1: {Resources: 100, LifeStatus: tc.lenderStatus},
+						shared.Team2: {Resources: tc.borrowerResources, LifeStatus: tc.borrowerStatus},
+					},
+					IITOLoans:   []shared.Loan{tc.loan},
+					IIGOHistory: map[uint][]shared.Accountability{},
+				},
+				clientMap: map[shared.ClientID]baseclient.Client{
+					shared.Team1: &mockClientIITO{petitionDefaults: tc.petition},
+					shared.Team2: &mockClientIITO{},
+				},
+			}
+
+			s.executeLoanRepayments()
+
+			if !reflect.DeepEqual(tc.wantLoan, s.gameState.IITOLoans[0]) {
+				t.Errorf("want loan '%v' got '%v'", tc.wantLoan, s.gameState.IITOLoans[0])
+			}
+			for clientID, want := range tc.wantResources {
+				if got := s.gameState.ClientInfos[clientID].Resources; got != want {
+					t.Errorf("want %v resources for %v got %v", want, clientID, got)
+				}
+			}
+			if !reflect.DeepEqual(tc.wantDefaults, s.gameState.IITOLoanDefaults) {
+				t.Errorf("want loan defaults '%v' got '%v'", tc.wantDefaults, s.gameState.IITOLoanDefaults)
+			}
+			// Every default is reported to the Judge, with whether the lender petitioned over it
+			var wantHistory []shared.Accountability
+			if tc.wantDefaults != nil {
+				petitioned := 0.0
+				if tc.wantPetitioned {
+					petitioned = 1
+				}
+				wantHistory = []shared.Accountability{
+					{
+						ClientID: shared.Team2,
+						Pairs: []rules.VariableValuePair{
+							{VariableName: rules.NumberOfLoanDefaults, Values: []float64{1}},
+							{VariableName: rules.NumberOfPetitionedLoanDefaults, Values: []float64{petitioned}},
+						},
+					},
+				}
+			}
+			if !reflect.DeepEqual(wantHistory, s.gameState.IIGOHistory[tc.turn]) {
+				t.Errorf("want history '%v' got '%v'", wantHistory, s.gameState.IIGOHistory[tc.turn])
+			}
+		})
+	}
+}
+
+func TestRunEscrowSession(t *testing.T) {
+	insurance := shared.EscrowedGiftOffer{To: shared.Team2, Amount: 30, Condition: shared.RecipientHitByDisaster, ExpiryTurns: 5}
+	bribe := shared.EscrowedGiftOffer{To: shared.Team3, Amount: 20, Condition: shared.RecipientVotedOnRule, ExpiryTurns: 1, RuleName: "rule_a", Vote: shared.Reject}
+	clientMap := map[shared.ClientID]baseclient.Client{
+		shared.Team1: &mockClientIITO{
+			escrowedGiftOffers: []shared.EscrowedGiftOffer{
+				insurance,
+				bribe,
+				// More than Team 1 has left
+				{To: shared.Team2, Amount: 60, Condition: shared.RecipientCritical, ExpiryTurns: 1},
+				// Invalid gifts
+				{To: shared.Team1, Amount: 10, Condition: shared.RecipientCritical, ExpiryTurns: 1},
+				{To: shared.Team4, Amount: 10, Condition: shared.RecipientCritical, ExpiryTurns: 1},
+				{To: shared.Team2, Amount: 0, Condition: shared.RecipientCritical, ExpiryTurns: 1},
+				{To: shared.Team2, Amount: 10, Condition: shared.RecipientCritical, ExpiryTurns: 0},
+				{To: shared.Team2, Amount: 10, Condition: shared.RecipientVotedOnRule, ExpiryTurns: 1},
+				{To: shared.Team2, Amount: 10, Condition: shared.EscrowCondition(7), ExpiryTurns: 1},
+			},
+		},
+		shared.Team2: &mockClientIITO{},
+		shared.Team3: &mockClientIITO{},
+		shared.Team4: &mockClientIITO{},
+	}
+	s := &SOMASServer{
+		gameState: gamestate.GameState{
+			Turn: 6,
+			ClientInfos: map[shared.ClientID]gamestate.ClientInfo{
+				shared.Team1: {Resources: 100, LifeStatus: shared.Alive},
+				shared.Team2: {Resources: 100, LifeStatus: shared.Alive},
+				shared.Team3: {Resources: 100, LifeStatus: shared.Alive},
+				shared.Team4: {Resources: 100, LifeStatus: shared.Dead},
+			},
+		},
+		clientMap: clientMap,
+	}
+
+	s.runEscrowSession()
+
+	want := []shared.EscrowedGift{
+		{EscrowedGiftOffer: insurance, ID: 0, From: shared.Team1, TurnDeposited: 6, Status: shared.EscrowHeld},
+		{EscrowedGiftOffer: bribe, ID: 1, From: shared.Team1, TurnDeposited: 6, Status: shared.EscrowHeld},
+	}
+	if !reflect.DeepEqual(want, s.gameState.IITOEscrowedGifts) {
+		t.Errorf("want escrowed gifts '%v' got '%v'", want, s.gameState.IITOEscrowedGifts)
+	}
+	if got := s.gameState.ClientInfos[shared.Team1].Resources; got != 50 {
+		t.Errorf("want 50 resources for the giver got %v", got)
+	}
+	// Nothing is given until the gifts are released
+	if got := s.gameState.ClientInfos[shared.Team2].Resources; got != 100 {
+		t.Errorf("want 100 resources for the recipient got %v", got)
+	}
+}
+
+func TestResolveEscrowedGifts(t *testing.T) {
+	held := func(condition shared.EscrowCondition) shared.EscrowedGift {
+		return shared.EscrowedGift{
+			EscrowedGiftOffer: shared.EscrowedGiftOffer{
+				To:          shared.Team2,
+				Amount:      30,
+				Condition:   condition,
+				ExpiryTurns: 3,
+				RuleName:    "rule_a",
+				Vote:        shared.Reject,
+			},
+			From:          shared.Team1,
+			TurnDeposited: 4,
+			Status:        shared.EscrowHeld,
+		}
+	}
+
+	cases := []struct {
+		name            string
+		gift            shared.EscrowedGift
+		turn            uint
+		disasterEffects map[shared.ClientID]shared.Magnitude
+		recipientStatus shared.ClientLifeStatus
+		ballots         map[string]map[shared.ClientID]shared.RuleVoteType
+		wantStatus      shared.EscrowStatus
+		wantResources   map[shared.ClientID]shared.Resources
+	}{
+		{
+			name:            "Disaster hit the recipient",
+			gift:            held(shared.RecipientHitByDisaster),
+			turn:            5,
+			disasterEffects: map[shared.ClientID]shared.Magnitude{shared.Team1: 0.5, shared.Team2: 0.2},
+			wantStatus:      shared.EscrowReleased,
+			wantResources:   map[shared.ClientID]shared.Resources{shared.Team1: 100, shared.Team2: 130},
+		},
+		{
+			name:            "Disaster missed the recipient",
+			gift:            held(shared.RecipientHitByDisaster),
+			turn:            5,
+			disasterEffects: map[shared.ClientID]shared.Magnitude{shared.Team1: 0.5, shared.Team2: 0},
+			wantStatus:      shared.EscrowHeld,
+			wantResources:   map[shared.ClientID]shared.Resources{shared.Team1: 100, shared.Team2: 100},
+		},
+		{
+			name:            "Recipient critical",
+			gift:            held(shared.RecipientCritical),
+			turn:            4,
+			recipientStatus: shared.Critical,
+			wantStatus:      shared.EscrowReleased,
+			wantResources:   map[shared.ClientID]shared.Resources{shared.Team1: 100, shared.Team2: 130},
+		},
+		{
+			name:          "Recipient voted as agreed",
+			gift:          held(shared.RecipientVotedOnRule),
+			turn:          4,
+			ballots:       map[string]map[shared.ClientID]shared.RuleVoteType{"rule_a": {shared.Team2: shared.Reject}},
+			wantStatus:    shared.EscrowReleased,
+			wantResources: map[shared.ClientID]shared.Resources{shared.Team1: 100, shared.Team2: 130},
+		},
+		{
+			name:          "Recipient voted otherwise",
+			gift:          held(shared.RecipientVotedOnRule),
+			turn:          4,
+			ballots:       map[string]map[shared.ClientID]shared.RuleVoteType{"rule_a": {shared.Team2: shared.Approve}},
+			wantStatus:    shared.EscrowHeld,
+			wantResources: map[shared.ClientID]shared.Resources{shared.Team1: 100, shared.Team2: 100},
+		},
+		{
+			name:          "Expired gift returned",
+			gift:          held(shared.RecipientCritical),
+			turn:          6,
+			wantStatus:    shared.EscrowReturned,
+			wantResources: map[shared.ClientID]shared.Resources{shared.Team1: 130, shared.Team2: 100},
+		},
+		{
+			name:            "Dead recipient's gift returned",
+			gift:            held(shared.RecipientHitByDisaster),
+			turn:            5,
+			disasterEffects: map[shared.ClientID]shared.Magnitude{shared.Team2: 1},
+			recipientStatus: shared.Dead,
+			wantStatus:      shared.EscrowReturned,
+			wantResources:   map[shared.ClientID]shared.Resources{shared.Team1: 130, shared.Team2: 100},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			giver := &mockClientIITO{}
+			recipient := &mockClientIITO{}
+			s := &SOMASServer{
+				gameState: gamestate.GameState{
+					Turn: tc.turn,
+					ClientInfos: map[shared.ClientID]gamestate.ClientInfo{
+						shared.Team1: {Resources: 100, LifeStatus: shared.Alive},
+						shared.Team2: {Resources: 100, LifeStatus: tc.recipientStatus},
+					},
+					IIGORuleBallots:   tc.ballots,
+					IITOEscrowedGifts: []shared.EscrowedGift{tc.gift},
+				},
+				clientMap: map[shared.ClientID]baseclient.Client{
+					shared.Team1: giver,
+					shared.Team2: recipient,
+				},
+			}
+
+			s.resolveEscrowedGifts(tc.disasterEffects)
+
+			got := s.gameState.IITOEscrowedGifts[0]
+			if got.Status != tc.wantStatus {
+				t.Errorf("want status %v got %v", tc.wantStatus, got.Status)
+			}
+			if got.Status != shared.EscrowHeld && got.TurnResolved != tc.turn {
+				t.Errorf("want gift resolved in turn %v got %v", tc.turn, got.TurnResolved)
+			}
+			for clientID, want := range tc.wantResources {
+				if got := s.gameState.ClientInfos[clientID].Resources; got != want {
+					t.Errorf("want %v resources for %v got %v", want, clientID, got)
+				}
+			}
+			released := tc.wantStatus == shared.EscrowReleased
+			if gotReceived := recipient.giftsReceived[shared.Team1]; released != (gotReceived == 30) {
+				t.Errorf("want recipient notified of release: %v, got %v received", released, gotReceived)
+			}
+			if gotSent := giver.giftsSent[shared.Team2]; released != (gotSent == 30) {
+				t.Errorf("want giver notified of release: %v, got %v sent", released, gotSent)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package server
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+// bookOrder is an order in the order book of the market, with the island which posted it
+type bookOrder struct {
+	shared.MarketOrder
+	clientID shared.ClientID
+}
+
+// runMarketSession collects the orders islands post to the market and clears the order book of each asset
+// by a uniform-price double auction. Buyers pay sellers at the clearing price straight away, and a trade the buyer
+// cannot pay for is not recorded or settled.
+func (s *SOMASServer) runMarketSession() {
+	s.logf("start runMarketSession")
+	defer s.logf("finish runMarketSession")
+
+	books := map[shared.MarketAsset]map[shared.MarketSide][]bookOrder{}
+	for _, asset := range shared.MarketAssets {
+		books[asset] = map[shared.MarketSide][]bookOrder{}
+	}
+
+	clientIDs := s.getGiftSessionClientIDs()
+	sort.Sort(shared.SortClientByID(clientIDs))
+	for _, clientID := range clientIDs {
+		for _, order := range s.sanitiseMarketOrders(s.clientMap[clientID].GetMarketOrders(), clientID) {
+			books[order.Asset][order.Side] = append(books[order.Asset][order.Side], bookOrder{MarketOrder: order, clientID: clientID})
+		}
+	}
+
+	for _, asset := range shared.MarketAssets {
+		price, trades := clearDoubleAuction(books[asset][shared.Bid], books[asset][shared.Ask])
+		volume := s.recordMarketTrades(asset, price, trades)
+		s.logf("[IITO]: %v market cleared at %v with a volume of %v", asset, price, volume)
+		s.gameState.IITOMarketClearings = append(s.gameState.IITOMarketClearings, shared.MarketClearing{
+			Turn:   s.gameState.Turn,
+			Asset:  asset,
+			Price:  price,
+			Volume: volume,
+		})
+	}
+}
+
+// sanitiseMarketOrders drops invalid orders and orders beyond what the island can afford or sell: the cost of
+// its bids cannot exceed its resources, and it cannot sell more than 100 foraging share units (its whole return).
+// An island cannot both bid and ask for the same asset, so its asks are dropped if it does.
+func (s *SOMASServer) sanitiseMarketOrders(orders []shared.MarketOrder, clientID shared.ClientID) []shared.MarketOrder {
+	budget := s.gameState.ClientInfos[clientID].Resources
+	foragingShares := 0.0
+	bidAssets := map[shared.MarketAsset]bool{}
+	valid := []shared.MarketOrder{}
+	for _, order := range orders {
+		if order.Asset != shared.ForagingShare && order.Asset != shared.IOU ||
+			order.Side != shared.Bid && order.Side != shared.Ask ||
+			!(order.Quantity > 0) || math.IsInf(order.Quantity, 1) ||
+			!(order.Price > 0) || math.IsInf(float64(order.Price), 1) {
+			s.logf("[IITO]: %v posted an invalid market order: %v", clientID, order)
+			continue
+		}
+		if order.Side == shared.Bid {
+			cost := order.Price * shared.Resources(order.Quantity)
+			if cost > budget {
+				s.logf("[IITO]: %v cannot afford its market bid: %v", clientID, order)
+				continue
+			}
+			budget -= cost
+			bidAssets[order.Asset] = true
+		} else if order.Asset == shared.ForagingShare {
+			if foragingShares+order.Quantity > 100 {
+				s.logf("[IITO]: %v cannot sell more than its whole foraging return: %v", clientID, order)
+				continue
+			}
+			foragingShares += order.Quantity
+		}
+		valid = append(valid, order)
+	}
+
+	ret := []shared.MarketOrder{}
+	for _, order := range valid {
+		if order.Side == shared.Ask && bidAssets[order.Asset] {
+			s.logf("[IITO]: %v cannot bid and ask for %v in the same turn: %v", clientID, order.Asset, order)
+			continue
+		}
+		ret = append(ret, order)
+	}
+	return ret
+}
+
+// clearDoubleAuction matches the highest bids with the lowest asks for as long as the bid price is at least
+// the ask price. All trades are made at a single price, halfway between the last bid and ask matched, which
+// every matched bid is willing to pay and every matched ask is willing to accept. Orders at the same price
+// keep the order they were posted in. It returns 0 and no trades if no bid meets an ask.
+func clearDoubleAuction(bids []bookOrder, asks []bookOrder) (shared.Resources, []shared.MarketTrade) {
+	bids = append([]bookOrder{}, bids...)
+	asks = append([]bookOrder{}, asks...)
+	sort.SliceStable(bids, func(i, j int) bool { return bids[i].Price > bids[j].Price })
+	sort.SliceStable(asks, func(i, j int) bool { return asks[i].Price < asks[j].Price })
+
+	trades := []shared.MarketTrade{}
+	lastBid, lastAsk := shared.Resources(0), shared.Resources(0)
+	i, j := 0, 0
+	for i < len(bids) && j < len(asks) && bids[i].Price >= asks[j].Price {
+		quantity := math.Min(bids[i].Quantity, asks[j].Quantity)
+		trades = append(trades, shared.MarketTrade{
+			Buyer:    bids[i].clientID,
+			Seller:   asks[j].clientID,
+			Quantity: quantity,
+		})
+		lastBid, lastAsk = bids[i].Price, asks[j].Price
+		bids[i].Quantity -= quantity
+		asks[j].Quantity -= quantity
+		if bids[i].Quantity <= 0 {
+			i++
+		}
+		if asks[j].Quantity <= 0 {
+			j++
+		}
+	}
+	return (lastBid + lastAsk) / 2, trades
+}
+
+// recordMarketTrades pays for the trades of an asset cleared at price and records those which were paid for,
+// returning the volume traded. A trade the buyer cannot pay for is dropped, so nothing is delivered for it.
+func (s *SOMASServer) recordMarketTrades(asset shared.MarketAsset, price shared.Resources, trades []shared.MarketTrade) float64 {
+	volume := 0.0
+	for _, trade := range trades {
+		trade.Turn = s.gameState.Turn
+		trade.Asset = asset
+		trade.Price = price
+		if !s.payForMarketTrade(trade) {
+			continue
+		}
+		s.gameState.IITOMarketTrades = append(s.gameState.IITOMarketTrades, trade)
+		volume += trade.Quantity
+	}
+	return volume
+}
+
+// payForMarketTrade transfers the price of a trade from the buyer to the seller, returning whether the buyer paid
+func (s *SOMASServer) payForMarketTrade(trade shared.MarketTrade) bool {
+	cost := trade.Price * shared.Resources(trade.Quantity)
+	transactionMsg := fmt.Sprintf("[IITO]: %v bought %v %v from %v for %v", trade.Buyer, trade.Quantity, trade.Asset, trade.Seller, cost)
+	if err := s.takeResources(trade.Buyer, cost, "TAKE: "+transactionMsg); err != nil {
+		s.logf("[IITO]: Dropping market trade the buyer cannot pay for: %v", err)
+		return false
+	}
+	if err := s.giveResources(trade.Seller, cost, "GIVE: "+transactionMsg); err != nil {
+		s.logf("Ignoring failure to give resources in payForMarketTrade: %v", err)
+	}
+	return true
+}
+
+// settleMarketTrades delivers the assets sold on the market this turn: the share of the seller's foraging return
+// or the resources promised by its IOUs. A seller failing to deliver breaks an agreement. Trades with a dead
+// party are settled with nothing delivered.
+func (s *SOMASServer) settleMarketTrades() {
+	s.logf("start settleMarketTrades")
+	defer s.logf("finish settleMarketTrades")
+
+	brokenAgreements := map[shared.ClientID]uint{}
+	for i := range s.gameState.IITOMarketTrades {
+		trade := &s.gameState.IITOMarketTrades[i]
+		if trade.Settled || trade.Turn != s.gameState.Turn {
+			continue
+		}
+		trade.Settled = true
+		if s.gameState.ClientInfos[trade.Buyer].LifeStatus == shared.Dead ||
+			s.gameState.ClientInfos[trade.Seller].LifeStatus == shared.Dead {
+			s.logf("[IITO]: %v trade between %v and %v is void", trade.Asset, trade.Seller, trade.Buyer)
+			continue
+		}
+
+		amount := shared.Resources(trade.Quantity)
+		if trade.Asset == shared.ForagingShare {
+			amount = s.gameState.ForagingReturns[trade.Seller] * shared.Resources(trade.Quantity/100)
+		}
+		if amount == 0 {
+			continue
+		}
+		transactionMsg := fmt.Sprintf("[IITO]: %v received %v %v from %v: %v", trade.Buyer, trade.Quantity, trade.Asset, trade.Seller, amount)
+		if err := s.takeResources(trade.Seller, amount, "TAKE: "+transactionMsg); err != nil {
+			s.logf("[IITO]: %v failed to deliver %v %v to %v: %v", trade.Seller, trade.Quantity, trade.Asset, trade.Buyer, err)
+			brokenAgreements[trade.Seller]++
+			continue
+		}
+		if err := s.giveResources(trade.Buyer, amount, "GIVE: "+transactionMsg); err != nil {
+			s.logf("Ignoring failure to give resources in settleMarketTrades: %v", err)
+		}
+		trade.Delivered = amount
+	}
+	s.recordBrokenAgreements(brokenAgreements)
+}
@@ -0,0 +1,257 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/baseclient"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/gamestate"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/rules"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+func TestClearDoubleAuction(t *testing.T) {
+	order := func(clientID shared.ClientID, quantity float64, price shared.Resources) bookOrder {
+		return bookOrder{MarketOrder: shared.MarketOrder{Quantity: quantity, Price: price}, clientID: clientID}
+	}
+
+	cases := []struct {
+		name       string
+		bids       []bookOrder
+		asks       []bookOrder
+		wantPrice  shared.Resources
+		wantTrades []shared.MarketTrade
+	}{
+		{
+			name:       "empty book",
+			wantPrice:  0,
+			wantTrades: []shared.MarketTrade{},
+		},
+		{
+			name:       "no bid meets an ask",
+			bids:       []bookOrder{order(shared.Team1, 10, 0.8)},
+			asks:       []bookOrder{order(shared.Team2, 10, 1)},
+			wantPrice:  0,
+			wantTrades: []shared.MarketTrade{},
+		},
+		{
+			name: "partial fills",
+			bids: []bookOrder{order(shared.Team2, 5, 1), order(shared.Team1, 10, 1.5)},
+			asks: []bookOrder{order(shared.Team4, 10, 1.25), order(shared.Team3, 8, 0.5)},
+			// The last bid and ask matched are Team 1's at 1.5 and Team 4's at 1.25
+			wantPrice: 1.375,
+			wantTrades: []shared.MarketTrade{
+				{Buyer: shared.Team1, Seller: shared.Team3, Quantity: 8},
+				{Buyer: shared.Team1, Seller: shared.Team4, Quantity: 2},
+			},
+		},
+		{
+			name:      "ties in posting order",
+			bids:      []bookOrder{order(shared.Team2, 5, 1), order(shared.Team1, 5, 1)},
+			asks:      []bookOrder{order(shared.Team3, 5, 1)},
+			wantPrice: 1,
+			wantTrades: []shared.MarketTrade{
+				{Buyer: shared.Team2, Seller: shared.Team3, Quantity: 5},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			price, trades := clearDoubleAuction(tc.bids, tc.asks)
+			if price != tc.wantPrice {
+				t.Errorf("want price %v got %v", tc.wantPrice, price)
+			}
+			if !reflect.DeepEqual(tc.wantTrades, trades) {
+				t.Errorf("want trades '%v' got '%v'", tc.wantTrades, trades)
+			}
+		})
+	}
+}
+
+func TestRunMarketSession(t *testing.T) {
+	clientMap := map[shared.ClientID]baseclient.Client{
+		shared.Team1: &mockClientIITO{
+			marketOrders: []shared.MarketOrder{
+				{Asset: shared.IOU, Side: shared.Bid, Quantity: 10, Price: 1.25},
+				// More than Team 1 can afford
+				{Asset: shared.IOU, Side: shared.Bid, Quantity: 1000, Price: 1},
+				{Asset: shared.ForagingShare, Side: shared.Bid, Quantity: 30, Price: 2},
+			},
+		},
+		shared.Team2: &mockClientIITO{
+			marketOrders: []shared.MarketOrder{
+				{Asset: shared.IOU, Side: shared.Ask, Quantity: 20, Price: 0.75},
+			},
+		},
+		shared.Team3: &mockClientIITO{
+			marketOrders: []shared.MarketOrder{
+				{Asset: shared.ForagingShare, Side: shared.Ask, Quantity: 60, Price: 1},
+				// More than Team 3's whole foraging return
+				{Asset: shared.ForagingShare, Side: shared.Ask, Quantity: 50, Price: 0.5},
+			},
+		},
+		shared.Team4: &mockClientIITO{
+			marketOrders: []shared.MarketOrder{
+				{Asset: shared.IOU, Side: shared.Bid, Quantity: 5, Price: 0.5},
+				// Team 4 already bids for IOUs
+				{Asset: shared.IOU, Side: shared.Ask, Quantity: 5, Price: 0.1},
+				// Invalid orders
+				{Asset: shared.IOU, Side: shared.Ask, Quantity: 5, Price: 0},
+				{Asset: shared.IOU, Side: shared.Ask, Quantity: -5, Price: 1},
+				{Asset: shared.MarketAsset(7), Side: shared.Ask, Quantity: 5, Price: 1},
+				{Asset: shared.ForagingShare, Side: shared.MarketSide(7), Quantity: 5, Price: 1},
+			},
+		},
+	}
+	s := &SOMASServer{
+		gameState: gamestate.GameState{
+			Turn: 4,
+			ClientInfos: map[shared.ClientID]gamestate.ClientInfo{
+				shared.Team1: {Resources: 100, LifeStatus: shared.Alive},
+				shared.Team2: {Resources: 100, LifeStatus: shared.Alive},
+				shared.Team3: {Resources: 100, LifeStatus: shared.Alive},
+				shared.Team4: {Resources: 100, LifeStatus: shared.Alive},
+			},
+		},
+		clientMap: clientMap,
+	}
+
+	s.runMarketSession()
+
+	wantTrades := []shared.MarketTrade{
+		{Turn: 4, Asset: shared.ForagingShare, Buyer: shared.Team1, Seller: shared.Team3, Quantity: 30, Price: 1.5},
+		{Turn: 4, Asset: shared.IOU, Buyer: shared.Team1, Seller: shared.Team2, Quantity: 10, Price: 1},
+	}
+	if !reflect.DeepEqual(wantTrades, s.gameState.IITOMarketTrades) {
+		t.Errorf("want trades '%v' got '%v'", wantTrades, s.gameState.IITOMarketTrades)
+	}
+	wantClearings := []shared.MarketClearing{
+		{Turn: 4, Asset: shared.ForagingShare, Price: 1.5, Volume: 30},
+		{Turn: 4, Asset: shared.IOU, Price: 1, Volume: 10},
+	}
+	if !reflect.DeepEqual(wantClearings, s.gameState.IITOMarketClearings) {
+		t.Errorf("want clearings '%v' got '%v'", wantClearings, s.gameState.IITOMarketClearings)
+	}
+	wantResources := map[shared.ClientID]shared.Resources{
+		shared.Team1: 45,
+		shared.Team2: 110,
+		shared.Team3: 145,
+		shared.Team4: 100,
+	}
+	for clientID, want := range wantResources {
+		if got := s.gameState.ClientInfos[clientID].Resources; got != want {
+			t.Errorf("want %v resources for %v got %v", want, clientID, got)
+		}
+	}
+}
+
+func TestRecordMarketTradesDropsUnpaidTrades(t *testing.T) {
+	s := &SOMASServer{
+		gameState: gamestate.GameState{
+			Turn: 4,
+			ClientInfos: map[shared.ClientID]gamestate.ClientInfo{
+				shared.Team1: {Resources: 100, LifeStatus: shared.Alive},
+				// Team 2 cannot cover the clearing price of its trade
+				shared.Team2: {Resources: 10, LifeStatus: shared.Alive},
+				shared.Team3: {Resources: 100, LifeStatus: shared.Alive},
+			},
+		},
+	}
+	trades := []shared.MarketTrade{
+		{Buyer: shared.Team1, Seller: shared.Team3, Quantity: 20},
+		{Buyer: shared.Team2, Seller: shared.Team3, Quantity: 20},
+	}
+
+	volume := s.recordMarketTrades(shared.ForagingShare, 1.5, trades)
+
+	if volume != 20 {
+		t.Errorf("want volume 20 got %v", volume)
+	}
+	wantTrades := []shared.MarketTrade{
+		{Turn: 4, Asset: shared.ForagingShare, Buyer: shared.Team1, Seller: shared.Team3, Quantity: 20, Price: 1.5},
+	}
+	if !reflect.DeepEqual(wantTrades, s.gameState.IITOMarketTrades) {
+		t.Errorf("want trades '%v' got '%v'", wantTrades, s.gameState.IITOMarketTrades)
+	}
+	wantResources := map[shared.ClientID]shared.Resources{
+		shared.Team1: 70,
+		shared.Team2: 10,
+		shared.Team3: 130,
+	}
+	for clientID, want := range wantResources {
+		if got := s.gameState.ClientInfos[clientID].Resources; got != want {
+			t.Errorf("want %v resources for %v got %v", want, clientID, got)
+		}
+	}
+}
+
+func TestSettleMarketTrades(t *testing.T) {
+	s := &SOMASServer{
+		gameState: gamestate.GameState{
+			Turn: 5,
+			ClientInfos: map[shared.ClientID]gamestate.ClientInfo{
+				shared.Team1: {Resources: 100, LifeStatus: shared.Alive},
+				shared.Team2: {Resources: 100, LifeStatus: shared.Alive},
+				shared.Team3: {Resources: 100, LifeStatus: shared.Alive},
+				shared.Team4: {Resources: 100, LifeStatus: shared.Alive},
+				shared.Team5: {Resources: 100, LifeStatus: shared.Dead},
+			},
+			ForagingReturns: map[shared.ClientID]shared.Resources{shared.Team3: 50},
+			IITOMarketTrades: []shared.MarketTrade{
+				{Turn: 5, Asset: shared.IOU, Buyer: shared.Team1, Seller: shared.Team2, Quantity: 10, Price: 1},
+				{Turn: 5, Asset: shared.ForagingShare, Buyer: shared.Team1, Seller: shared.Team3, Quantity: 30, Price: 1},
+				// More than Team 4 has
+				{Turn: 5, Asset: shared.IOU, Buyer: shared.Team1, Seller: shared.Team4, Quantity: 500, Price: 1},
+				// Team 2 did not forage
+				{Turn: 5, Asset: shared.ForagingShare, Buyer: shared.Team3, Seller: shared.Team2, Quantity: 10, Price: 1},
+				// Team 5 is dead
+				{Turn: 5, Asset: shared.IOU, Buyer: shared.Team5, Seller: shared.Team2, Quantity: 10, Price: 1},
+				// Settled in an earlier turn
+				{Turn: 4, Asset: shared.IOU, Buyer: shared.Team1, Seller: shared.Team2, Quantity: 10, Price: 1, Settled: true, Delivered: 10},
+			},
+			IIGOHistory: map[uint][]shared.Accountability{},
+		},
+		clientMap: map[shared.ClientID]baseclient.Client{},
+	}
+
+	s.settleMarketTrades()
+
+	wantTrades := []shared.MarketTrade{
+		{Turn: 5, Asset: shared.IOU, Buyer: shared.Team1, Seller: shared.Team2, Quantity: 10, Price: 1, Settled: true, Delivered: 10},
+		{Turn: 5, Asset: shared.ForagingShare, Buyer: shared.Team1, Seller: shared.Team3, Quantity: 30, Price: 1, Settled: true, Delivered: 15},
+		{Turn: 5, Asset: shared.IOU, Buyer: shared.Team1, Seller: shared.Team4, Quantity: 500, Price: 1, Settled: true},
+		{Turn: 5, Asset: shared.ForagingShare, Buyer: shared.Team3, Seller: shared.Team2, Quantity: 10, Price: 1, Settled: true},
+		{Turn: 5, Asset: shared.IOU, Buyer: shared.Team5, Seller: shared.Team2, Quantity: 10, Price: 1, Settled: true},
+		{Turn: 4, Asset: shared.IOU, Buyer: shared.Team1, Seller: shared.Team2, Quantity: 10, Price: 1, Settled: true, Delivered: 10},
+	}
+	if !reflect.DeepEqual(wantTrades, s.gameState.IITOMarketTrades) {
+		t.Errorf("want trades '%v' got '%v'", wantTrades, s.gameState.IITOMarketTrades)
+	}
+	wantResources := map[shared.ClientID]shared.Resources{
+		shared.Team1: 125,
+		shared.Team2: 90,
+		shared.Team3: 85,
+		shared.Team4: 100,
+	}
+	for clientID, want := range wantResources {
+		if got := s.gameState.ClientInfos[clientID].Resources; got != want {
+			t.Errorf("want %v resources for %v got %v", want, clientID, got)
+		}
+	}
+	wantBroken := map[shared.ClientID]uint{shared.Team4: 1}
+	if !reflect.DeepEqual(wantBroken, s.gameState.IITOBrokenAgreements) {
+		t.Errorf("want broken agreements '%v' got '%v'", wantBroken, s.gameState.IITOBrokenAgreements)
+	}
+	wantHistory := []shared.Accountability{
+		{
+			ClientID: shared.Team4,
+			Pairs: []rules.VariableValuePair{
+				{VariableName: rules.NumberOfBrokenAgreements, Values: []float64{1}},
+			},
+		},
+	}
+	if !reflect.DeepEqual(wantHistory, s.gameState.IIGOHistory[5]) {
+		t.Errorf("want history '%v' got '%v'", wantHistory, s.gameState.IIGOHistory[5])
+	}
+}
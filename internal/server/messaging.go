@@ -0,0 +1,78 @@
+package server
+
+import (
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+// runMessagingSession collects the messages alive islands send each other, in the order of the islands' IDs,
+// then delivers each island the messages sent to it. Messages are logged in the game state for this turn.
+func (s *SOMASServer) runMessagingSession() {
+	s.logf("start runMessagingSession")
+	defer s.logf("finish runMessagingSession")
+
+	s.gameState.Messages = []shared.MessageLogEntry{}
+	maxMessages := s.gameConfig.MaxMessagesPerTurn
+	if maxMessages == 0 {
+		return
+	}
+
+	aliveClientIDs := []shared.ClientID{}
+	for _, clientID := range shared.TeamIDs {
+		if clientInfo, ok := s.gameState.ClientInfos[clientID]; ok && clientInfo.LifeStatus != shared.Dead {
+			aliveClientIDs = append(aliveClientIDs, clientID)
+		}
+	}
+
+	for _, sender := range aliveClientIDs {
+		messages := s.clientMap[sender].GetMessages()
+		if uint(len(messages)) > maxMessages {
+			s.logf("%v sent %v messages, only the first %v are delivered", sender, len(messages), maxMessages)
+			messages = messages[:maxMessages]
+		}
+		for _, message := range messages {
+			to := getMessageRecipients(sender, message.Recipients, aliveClientIDs)
+			if message.Kind < shared.TextMessage || message.Kind > shared.PromiseMessage || len(to) == 0 {
+				s.logf("%v sent an invalid message: %v", sender, message)
+				continue
+			}
+			message.Recipients = append([]shared.ClientID{}, message.Recipients...)
+			s.gameState.Messages = append(s.gameState.Messages, shared.MessageLogEntry{
+				Turn:    s.gameState.Turn,
+				From:    sender,
+				To:      to,
+				Message: message,
+			})
+		}
+	}
+
+	// Each recipient gets its own copy of the lists of recipients, which it could modify
+	inboxes := map[shared.ClientID][]shared.MessageLogEntry{}
+	for _, entry := range s.gameState.Messages {
+		for _, recipient := range entry.To {
+			delivered := entry
+			delivered.To = append([]shared.ClientID{}, entry.To...)
+			delivered.Recipients = append([]shared.ClientID{}, entry.Recipients...)
+			inboxes[recipient] = append(inboxes[recipient], delivered)
+		}
+	}
+	for _, clientID := range aliveClientIDs {
+		if len(inboxes[clientID]) > 0 {
+			s.clientMap[clientID].ReceiveMessages(inboxes[clientID])
+		}
+	}
+}
+
+// getMessageRecipients returns the alive islands a message is delivered to: every island other than the sender
+// if it has no recipients, or else its recipients without the sender, dead islands and duplicates
+func getMessageRecipients(sender shared.ClientID, recipients []shared.ClientID, aliveClientIDs []shared.ClientID) []shared.ClientID {
+	if len(recipients) == 0 {
+		recipients = aliveClientIDs
+	}
+	to := []shared.ClientID{}
+	for _, recipient := range recipients {
+		if recipient != sender && clientArrayContains(aliveClientIDs, recipient) && !clientArrayContains(to, recipient) {
+			to = append(to, recipient)
+		}
+	}
+	return to
+}
@@ -0,0 +1,160 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/baseclient"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/gamestate"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+type mockClientMessaging struct {
+	baseclient.Client
+	messages []shared.Message
+	received []shared.MessageLogEntry
+}
+
+func (c *mockClientMessaging) GetMessages() []shared.Message {
+	return c.messages
+}
+
+func (c *mockClientMessaging) ReceiveMessages(messages []shared.MessageLogEntry) {
+	c.received = messages
+}
+
+func TestRunMessagingSession(t *testing.T) {
+	broadcast := shared.Message{Kind: shared.TextMessage, Text: "hello"}
+	threat := shared.Message{Kind: shared.ThreatMessage, Recipients: []shared.ClientID{shared.Team3, shared.Team3, shared.Team4}, Amount: 10}
+	promise := shared.Message{Kind: shared.PromiseMessage, Recipients: []shared.ClientID{shared.Team1}, Amount: 5}
+	// The server keeps its own copy of the recipients
+	logged := shared.Message{Kind: shared.TextMessage, Recipients: []shared.ClientID{}, Text: "hello"}
+
+	cases := []struct {
+		name               string
+		maxMessagesPerTurn uint
+		messages           map[shared.ClientID][]shared.Message
+		wantLog            []shared.MessageLogEntry
+		wantReceived       map[shared.ClientID][]shared.MessageLogEntry
+	}{
+		{
+			name:               "delivers messages",
+			maxMessagesPerTurn: 3,
+			messages: map[shared.ClientID][]shared.Message{
+				shared.Team1: {
+					broadcast,
+					threat,
+					// To itself only
+					promise,
+					// Over the limit
+					{Kind: shared.TextMessage},
+				},
+				shared.Team2: {
+					// Invalid kind
+					{Kind: shared.MessageKind(-1)},
+					// To itself and a dead island only
+					{Kind: shared.ProposalMessage, Recipients: []shared.ClientID{shared.Team2, shared.Team4}},
+					promise,
+				},
+				// Dead islands cannot send messages
+				shared.Team4: {broadcast},
+			},
+			wantLog: []shared.MessageLogEntry{
+				{Turn: 3, From: shared.Team1, To: []shared.ClientID{shared.Team2, shared.Team3}, Message: logged},
+				{Turn: 3, From: shared.Team1, To: []shared.ClientID{shared.Team3}, Message: threat},
+				{Turn: 3, From: shared.Team2, To: []shared.ClientID{shared.Team1}, Message: promise},
+			},
+			wantReceived: map[shared.ClientID][]shared.MessageLogEntry{
+				shared.Team1: {
+					{Turn: 3, From: shared.Team2, To: []shared.ClientID{shared.Team1}, Message: promise},
+				},
+				shared.Team2: {
+					{Turn: 3, From: shared.Team1, To: []shared.ClientID{shared.Team2, shared.Team3}, Message: logged},
+				},
+				shared.Team3: {
+					{Turn: 3, From: shared.Team1, To: []shared.ClientID{shared.Team2, shared.Team3}, Message: logged},
+					{Turn: 3, From: shared.Team1, To: []shared.ClientID{shared.Team3}, Message: threat},
+				},
+			},
+		},
+		{
+			name:               "messaging disabled",
+			maxMessagesPerTurn: 0,
+			messages: map[shared.ClientID][]shared.Message{
+				shared.Team1: {broadcast},
+			},
+			wantLog:      []shared.MessageLogEntry{},
+			wantReceived: map[shared.ClientID][]shared.MessageLogEntry{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			clients := map[shared.ClientID]*mockClientMessaging{}
+			clientMap := map[shared.ClientID]baseclient.Client{}
+			for _, clientID := range []shared.ClientID{shared.Team1, shared.Team2, shared.Team3, shared.Team4} {
+				clients[clientID] = &mockClientMessaging{messages: tc.messages[clientID]}
+				clientMap[clientID] = clients[clientID]
+			}
+			s := &SOMASServer{
+				gameConfig: config.Config{MaxMessagesPerTurn: tc.maxMessagesPerTurn},
+				gameState: gamestate.GameState{
+					Turn: 3,
+					ClientInfos: map[shared.ClientID]gamestate.ClientInfo{
+						shared.Team1: {LifeStatus: shared.Alive},
+						shared.Team2: {LifeStatus: shared.Critical},
+						shared.Team3: {LifeStatus: shared.Alive},
+						shared.Team4: {LifeStatus: shared.Dead},
+					},
+					// Messages of the previous turn are dropped
+					Messages: []shared.MessageLogEntry{{Turn: 2, From: shared.Team1}},
+				},
+				clientMap: clientMap,
+			}
+
+			s.runMessagingSession()
+
+			if !reflect.DeepEqual(tc.wantLog, s.gameState.Messages) {
+				t.Errorf("want log '%v' got '%v'", tc.wantLog, s.gameState.Messages)
+			}
+			for clientID, client := range clients {
+				if !reflect.DeepEqual(tc.wantReceived[clientID], client.received) {
+					t.Errorf("%v: want received '%v' got '%v'", clientID, tc.wantReceived[clientID], client.received)
+				}
+			}
+		})
+	}
+}
+
+func TestGetMessageRecipients(t *testing.T) {
+	alive := []shared.ClientID{shared.Team1, shared.Team2, shared.Team3}
+	cases := []struct {
+		name       string
+		recipients []shared.ClientID
+		want       []shared.ClientID
+	}{
+		{
+			name: "broadcast",
+			want: []shared.ClientID{shared.Team2, shared.Team3},
+		},
+		{
+			name:       "group",
+			recipients: []shared.ClientID{shared.Team3, shared.Team2, shared.Team3},
+			want:       []shared.ClientID{shared.Team3, shared.Team2},
+		},
+		{
+			name:       "dead or self only",
+			recipients: []shared.ClientID{shared.Team1, shared.Team5},
+			want:       []shared.ClientID{},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := getMessageRecipients(shared.Team1, tc.recipients, alive)
+			if !reflect.DeepEqual(tc.want, got) {
+				t.Errorf("want '%v' got '%v'", tc.want, got)
+			}
+		})
+	}
+}
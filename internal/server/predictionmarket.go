@@ -0,0 +1,208 @@
+package server
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+// runPredictionMarketSession collects the shares islands buy in the disaster prediction markets. Orders are filled
+// one after the other by the market maker, in the order of the islands' IDs, and a market is opened for a contract
+// no open market covers yet.
+func (s *SOMASServer) runPredictionMarketSession() {
+	s.logf("start runPredictionMarketSession")
+	defer s.logf("finish runPredictionMarketSession")
+
+	if !(s.gameConfig.IIFOConfig.PredictionMarketLiquidity > 0) {
+		return
+	}
+
+	for _, clientID := range shared.TeamIDs {
+		clientInfo, ok := s.gameState.ClientInfos[clientID]
+		if !ok || clientInfo.LifeStatus == shared.Dead {
+			continue
+		}
+		for _, order := range s.clientMap[clientID].GetPredictionMarketOrders() {
+			s.fillPredictionMarketOrder(clientID, order)
+		}
+	}
+}
+
+// fillPredictionMarketOrder sells the shares of an order to an island at the cost set by the market maker,
+// if the order is valid and the island can afford it
+func (s *SOMASServer) fillPredictionMarketOrder(clientID shared.ClientID, order shared.PredictionMarketOrder) {
+	contract := order.Contract
+	if !(order.Shares > 0) || math.IsInf(order.Shares, 1) ||
+		contract.Kind == shared.DisasterWithinTurns && contract.Turns == 0 ||
+		contract.Kind == shared.MagnitudeAbove && (!(contract.Magnitude >= 0) || math.IsInf(contract.Magnitude, 1)) ||
+		contract.Kind != shared.DisasterWithinTurns && contract.Kind != shared.MagnitudeAbove {
+		s.logf("[IIFO]: %v posted an invalid prediction market order: %v", clientID, order)
+		return
+	}
+
+	deadline, magnitude := s.predictionMarketEvent(contract)
+	market := s.findPredictionMarket(contract.Kind, deadline, magnitude)
+	var yesShares, noShares float64
+	liquidity := s.gameConfig.IIFOConfig.PredictionMarketLiquidity
+	if market != nil {
+		yesShares, noShares, liquidity = market.YesShares, market.NoShares, market.Liquidity
+	}
+	cost := -shared.Resources(lmsrCost(yesShares, noShares, liquidity))
+	if order.Yes {
+		yesShares += order.Shares
+	} else {
+		noShares += order.Shares
+	}
+	cost += shared.Resources(lmsrCost(yesShares, noShares, liquidity))
+	if cost > s.gameState.ClientInfos[clientID].Resources {
+		s.logf("[IIFO]: %v cannot afford its prediction market order: %v", clientID, order)
+		return
+	}
+
+	if market == nil {
+		market = s.openPredictionMarket(contract.Kind, deadline, magnitude)
+		if market == nil {
+			return
+		}
+	}
+	transactionMsg := fmt.Sprintf("[IIFO]: %v bought %v shares of prediction market %v for %v", clientID, order.Shares, market.ID, cost)
+	if err := s.takeResources(clientID, cost, "TAKE: "+transactionMsg); err != nil {
+		s.logf("[IIFO]: Error deducting prediction market payment: %v", err)
+		return
+	}
+	market.YesShares, market.NoShares = yesShares, noShares
+	market.Price = lmsrPrice(yesShares, noShares, market.Liquidity)
+	market.Escrow += cost
+
+	holding := s.predictionMarketHolding(market.ID, clientID)
+	if order.Yes {
+		holding.YesShares += order.Shares
+	} else {
+		holding.NoShares += order.Shares
+	}
+	holding.Cost += cost
+}
+
+// predictionMarketEvent returns the deadline and magnitude threshold of the market trading a contract bought this
+// turn. A disaster within N turns is one by the end of turn Turn + N - 1.
+func (s *SOMASServer) predictionMarketEvent(contract shared.DisasterContract) (uint, shared.Magnitude) {
+	if contract.Kind == shared.DisasterWithinTurns {
+		return s.gameState.Turn + contract.Turns - 1, 0
+	}
+	return 0, contract.Magnitude
+}
+
+// findPredictionMarket returns the open market on an event, or nil if there is none
+func (s *SOMASServer) findPredictionMarket(kind shared.DisasterContractKind, deadline uint, magnitude shared.Magnitude) *shared.PredictionMarket {
+	for i := range s.gameState.IIFOPredictionMarkets {
+		market := &s.gameState.IIFOPredictionMarkets[i]
+		if !market.Resolved && market.Kind == kind && market.Deadline == deadline && market.Magnitude == magnitude {
+			return market
+		}
+	}
+	return nil
+}
+
+// openPredictionMarket opens a market on an event. The largest possible loss of the market maker, Liquidity * ln 2, is
+// taken from the common pool to fund the market. It returns nil if the common pool cannot afford it.
+func (s *SOMASServer) openPredictionMarket(kind shared.DisasterContractKind, deadline uint, magnitude shared.Magnitude) *shared.PredictionMarket {
+	liquidity := s.gameConfig.IIFOConfig.PredictionMarketLiquidity
+	subsidy := shared.Resources(liquidity * math.Ln2)
+	if subsidy > s.gameState.CommonPool-s.gameState.IIGOCommonPoolReserve {
+		s.logf("[IIFO]: Common pool cannot afford to open a %v prediction market", kind)
+		return nil
+	}
+	s.gameState.CommonPool -= subsidy
+	s.gameState.IIFOPredictionMarkets = append(s.gameState.IIFOPredictionMarkets, shared.PredictionMarket{
+		ID:         uint(len(s.gameState.IIFOPredictionMarkets)),
+		Kind:       kind,
+		Deadline:   deadline,
+		Magnitude:  magnitude,
+		OpenedTurn: s.gameState.Turn,
+		Liquidity:  liquidity,
+		Price:      lmsrPrice(0, 0, liquidity),
+		Escrow:     subsidy,
+	})
+	return &s.gameState.IIFOPredictionMarkets[len(s.gameState.IIFOPredictionMarkets)-1]
+}
+
+// predictionMarketHolding returns the shares of a market held by an island, adding an empty holding if it has none
+func (s *SOMASServer) predictionMarketHolding(marketID uint, clientID shared.ClientID) *shared.PredictionMarketHolding {
+	for i := range s.gameState.IIFOPredictionMarketHoldings {
+		holding := &s.gameState.IIFOPredictionMarketHoldings[i]
+		if holding.MarketID == marketID && holding.Holder == clientID {
+			return holding
+		}
+	}
+	s.gameState.IIFOPredictionMarketHoldings = append(s.gameState.IIFOPredictionMarketHoldings, shared.PredictionMarketHolding{
+		MarketID: marketID,
+		Holder:   clientID,
+	})
+	return &s.gameState.IIFOPredictionMarketHoldings[len(s.gameState.IIFOPredictionMarketHoldings)-1]
+}
+
+// settlePredictionMarkets settles the markets whose event is decided by this turn's outcome: DisasterWithinTurns
+// markets once a disaster happens or their deadline passes, and MagnitudeAbove markets once a disaster happens.
+// Winning shares are paid 1 resource each from the market's escrow, and the rest of the escrow goes back to the
+// common pool. Dead islands are not paid.
+func (s *SOMASServer) settlePredictionMarkets(disasterHappened bool) {
+	s.logf("start settlePredictionMarkets")
+	defer s.logf("finish settlePredictionMarkets")
+
+	turn := s.gameState.Turn
+	magnitude := s.gameState.Environment.LastDisasterReport.Magnitude
+	for i := range s.gameState.IIFOPredictionMarkets {
+		market := &s.gameState.IIFOPredictionMarkets[i]
+		if market.Resolved {
+			continue
+		}
+		switch {
+		case market.Kind == shared.DisasterWithinTurns && disasterHappened:
+			market.Outcome = true
+		case market.Kind == shared.DisasterWithinTurns && turn >= market.Deadline:
+			market.Outcome = false
+		case market.Kind == shared.MagnitudeAbove && disasterHappened:
+			market.Outcome = magnitude > market.Magnitude
+		default:
+			continue
+		}
+		market.Resolved = true
+		market.TurnResolved = turn
+		s.logf("[IIFO]: Prediction market %v settled with outcome %v", market.ID, market.Outcome)
+
+		for j := range s.gameState.IIFOPredictionMarketHoldings {
+			holding := &s.gameState.IIFOPredictionMarketHoldings[j]
+			if holding.MarketID != market.ID || s.gameState.ClientInfos[holding.Holder].LifeStatus == shared.Dead {
+				continue
+			}
+			payout := shared.Resources(holding.NoShares)
+			if market.Outcome {
+				payout = shared.Resources(holding.YesShares)
+			}
+			if payout == 0 {
+				continue
+			}
+			if err := s.giveResources(holding.Holder, payout, fmt.Sprintf("[IIFO]: prediction market %v payout", market.ID)); err != nil {
+				s.logf("Ignoring failure to give resources in settlePredictionMarkets: %v", err)
+				continue
+			}
+			holding.Payout = payout
+			market.Escrow -= payout
+		}
+		s.gameState.CommonPool += market.Escrow
+		market.Escrow = 0
+	}
+}
+
+// lmsrCost is the cost function of the LMSR market maker, b * ln(e^(yes/b) + e^(no/b)).
+// Buying shares costs the difference in the cost function before and after the purchase.
+func lmsrCost(yesShares float64, noShares float64, liquidity float64) float64 {
+	maxShares := math.Max(yesShares, noShares)
+	return maxShares + liquidity*math.Log(math.Exp((yesShares-maxShares)/liquidity)+math.Exp((noShares-maxShares)/liquidity))
+}
+
+// lmsrPrice is the price of a Yes share of the LMSR market maker, e^(yes/b) / (e^(yes/b) + e^(no/b))
+func lmsrPrice(yesShares float64, noShares float64, liquidity float64) float64 {
+	return 1 / (1 + math.Exp((noShares-yesShares)/liquidity))
+}
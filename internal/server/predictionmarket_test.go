@@ -0,0 +1,266 @@
+package server
+
+import (
+	"math"
+	"testing"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/baseclient"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/disasters"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/gamestate"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+type mockClientPredictionMarket struct {
+	baseclient.Client
+	orders []shared.PredictionMarketOrder
+}
+
+func (c *mockClientPredictionMarket) GetPredictionMarketOrders() []shared.PredictionMarketOrder {
+	return c.orders
+}
+
+// closeTo compares floats which went through the exponentials and logarithms of the market maker
+func closeTo(want float64, got float64) bool {
+	return math.Abs(want-got) < 1e-9
+}
+
+func TestLMSR(t *testing.T) {
+	cases := []struct {
+		name      string
+		yesShares float64
+		noShares  float64
+		wantCost  float64
+		wantPrice float64
+	}{
+		{
+			name:      "no shares sold",
+			wantCost:  10 * math.Ln2,
+			wantPrice: 0.5,
+		},
+		{
+			name:      "as many yes as no shares",
+			yesShares: 30,
+			noShares:  30,
+			wantCost:  30 + 10*math.Ln2,
+			wantPrice: 0.5,
+		},
+		{
+			name:      "more yes shares",
+			yesShares: 10,
+			wantCost:  10 * math.Log(math.E+1),
+			wantPrice: math.E / (math.E + 1),
+		},
+		{
+			name:      "more no shares",
+			noShares:  10,
+			wantCost:  10 * math.Log(math.E+1),
+			wantPrice: 1 / (math.E + 1),
+		},
+		{
+			name:      "large quantities",
+			yesShares: 10000,
+			noShares:  9990,
+			wantCost:  9990 + 10*math.Log(math.E+1),
+			wantPrice: math.E / (math.E + 1),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if cost := lmsrCost(tc.yesShares, tc.noShares, 10); !closeTo(tc.wantCost, cost) {
+				t.Errorf("want cost %v got %v", tc.wantCost, cost)
+			}
+			if price := lmsrPrice(tc.yesShares, tc.noShares, 10); !closeTo(tc.wantPrice, price) {
+				t.Errorf("want price %v got %v", tc.wantPrice, price)
+			}
+		})
+	}
+}
+
+func TestRunPredictionMarketSession(t *testing.T) {
+	withinTwoTurns := shared.DisasterContract{Kind: shared.DisasterWithinTurns, Turns: 2}
+	aboveOne := shared.DisasterContract{Kind: shared.MagnitudeAbove, Magnitude: 1}
+	clientMap := map[shared.ClientID]baseclient.Client{
+		shared.Team1: &mockClientPredictionMarket{
+			orders: []shared.PredictionMarketOrder{
+				{Contract: withinTwoTurns, Yes: true, Shares: 10},
+				// No market can be opened on a disaster within 0 turns
+				{Contract: shared.DisasterContract{Kind: shared.DisasterWithinTurns}, Yes: true, Shares: 10},
+				{Contract: aboveOne, Yes: false, Shares: 5},
+			},
+		},
+		shared.Team2: &mockClientPredictionMarket{
+			orders: []shared.PredictionMarketOrder{
+				{Contract: withinTwoTurns, Yes: false, Shares: 10},
+				// More than Team 2 can afford
+				{Contract: withinTwoTurns, Yes: true, Shares: 1000},
+				// No market is opened for an order its island cannot afford
+				{Contract: shared.DisasterContract{Kind: shared.MagnitudeAbove, Magnitude: 3}, Yes: true, Shares: 1000},
+				{Contract: withinTwoTurns, Yes: true, Shares: -10},
+			},
+		},
+		shared.Team3: &mockClientPredictionMarket{
+			orders: []shared.PredictionMarketOrder{
+				{Contract: withinTwoTurns, Yes: true, Shares: 10},
+			},
+		},
+	}
+
+	s := &SOMASServer{
+		gameState: gamestate.GameState{
+			Turn:       4,
+			CommonPool: 100,
+			ClientInfos: map[shared.ClientID]gamestate.ClientInfo{
+				shared.Team1: {LifeStatus: shared.Alive, Resources: 100},
+				shared.Team2: {LifeStatus: shared.Critical, Resources: 100},
+				shared.Team3: {LifeStatus: shared.Dead, Resources: 100},
+			},
+		},
+		gameConfig: config.Config{
+			IIFOConfig: config.IIFOConfig{PredictionMarketLiquidity: 10},
+		},
+		clientMap: clientMap,
+	}
+
+	s.runPredictionMarketSession()
+
+	markets := s.gameState.IIFOPredictionMarkets
+	if len(markets) != 2 {
+		t.Fatalf("want 2 markets got '%v'", markets)
+	}
+	within, above := markets[0], markets[1]
+	if within.Kind != shared.DisasterWithinTurns || within.Deadline != 5 || within.OpenedTurn != 4 ||
+		within.YesShares != 10 || within.NoShares != 10 || !closeTo(0.5, within.Price) ||
+		!closeTo(lmsrCost(10, 10, 10), float64(within.Escrow)) {
+		t.Errorf("unexpected market on a disaster within 2 turns: '%v'", within)
+	}
+	if above.Kind != shared.MagnitudeAbove || above.Magnitude != 1 || above.YesShares != 0 || above.NoShares != 5 ||
+		!closeTo(lmsrPrice(0, 5, 10), above.Price) || !closeTo(lmsrCost(0, 5, 10), float64(above.Escrow)) {
+		t.Errorf("unexpected market on a magnitude above 1: '%v'", above)
+	}
+
+	wantHoldings := []shared.PredictionMarketHolding{
+		{MarketID: 0, Holder: shared.Team1, YesShares: 10},
+		{MarketID: 1, Holder: shared.Team1, NoShares: 5},
+		{MarketID: 0, Holder: shared.Team2, NoShares: 10},
+	}
+	holdings := s.gameState.IIFOPredictionMarketHoldings
+	if len(holdings) != len(wantHoldings) {
+		t.Fatalf("want holdings '%v' got '%v'", wantHoldings, holdings)
+	}
+	for i, want := range wantHoldings {
+		got := holdings[i]
+		if got.MarketID != want.MarketID || got.Holder != want.Holder || got.YesShares != want.YesShares || got.NoShares != want.NoShares {
+			t.Errorf("want holding '%v' got '%v'", want, got)
+		}
+	}
+
+	wantResources := map[shared.ClientID]float64{
+		shared.Team1: 100 - (lmsrCost(10, 0, 10) - lmsrCost(0, 0, 10)) - (lmsrCost(0, 5, 10) - lmsrCost(0, 0, 10)),
+		shared.Team2: 100 - (lmsrCost(10, 10, 10) - lmsrCost(10, 0, 10)),
+		shared.Team3: 100,
+	}
+	for clientID, want := range wantResources {
+		if got := float64(s.gameState.ClientInfos[clientID].Resources); !closeTo(want, got) {
+			t.Errorf("%v: want resources %v got %v", clientID, want, got)
+		}
+	}
+	if !closeTo(100-20*math.Ln2, float64(s.gameState.CommonPool)) {
+		t.Errorf("want common pool %v got %v", 100-20*math.Ln2, s.gameState.CommonPool)
+	}
+}
+
+func TestSettlePredictionMarkets(t *testing.T) {
+	markets := func() []shared.PredictionMarket {
+		return []shared.PredictionMarket{
+			{ID: 0, Kind: shared.DisasterWithinTurns, Deadline: 5, Liquidity: 10, YesShares: 10, NoShares: 10, Escrow: shared.Resources(lmsrCost(10, 10, 10))},
+			{ID: 1, Kind: shared.MagnitudeAbove, Magnitude: 1, Liquidity: 10, NoShares: 5, Escrow: shared.Resources(lmsrCost(0, 5, 10))},
+			{ID: 2, Kind: shared.DisasterWithinTurns, Deadline: 7, Liquidity: 10, YesShares: 3, Escrow: shared.Resources(lmsrCost(3, 0, 10))},
+			{ID: 3, Kind: shared.DisasterWithinTurns, Deadline: 2, Liquidity: 10, Resolved: true, TurnResolved: 2},
+		}
+	}
+	holdings := func() []shared.PredictionMarketHolding {
+		return []shared.PredictionMarketHolding{
+			{MarketID: 0, Holder: shared.Team1, YesShares: 10},
+			{MarketID: 0, Holder: shared.Team2, NoShares: 10},
+			{MarketID: 1, Holder: shared.Team1, NoShares: 5},
+			{MarketID: 2, Holder: shared.Team3, YesShares: 3},
+		}
+	}
+
+	cases := []struct {
+		name             string
+		disasterHappened bool
+		wantResolved     []bool
+		wantOutcomes     []bool
+		wantPayouts      []shared.Resources
+		wantResources    map[shared.ClientID]float64
+		wantCommonPool   float64
+	}{
+		{
+			name:           "deadline passes without a disaster",
+			wantResolved:   []bool{true, false, false, true},
+			wantOutcomes:   []bool{false, false, false, false},
+			wantPayouts:    []shared.Resources{0, 10, 0, 0},
+			wantResources:  map[shared.ClientID]float64{shared.Team1: 0, shared.Team2: 10, shared.Team3: 0},
+			wantCommonPool: lmsrCost(10, 10, 10) - 10,
+		},
+		{
+			name:             "disaster settles all markets",
+			disasterHappened: true,
+			wantResolved:     []bool{true, true, true, true},
+			wantOutcomes:     []bool{true, true, true, false},
+			wantPayouts:      []shared.Resources{10, 0, 0, 0},
+			// Team 3 is dead so it is not paid for its winning shares
+			wantResources:  map[shared.ClientID]float64{shared.Team1: 10, shared.Team2: 0, shared.Team3: 0},
+			wantCommonPool: lmsrCost(10, 10, 10) - 10 + lmsrCost(0, 5, 10) + lmsrCost(3, 0, 10),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			report := disasters.DisasterReport{}
+			if tc.disasterHappened {
+				report = disasters.DisasterReport{Magnitude: 2}
+			}
+			s := &SOMASServer{
+				gameState: gamestate.GameState{
+					Turn: 5,
+					ClientInfos: map[shared.ClientID]gamestate.ClientInfo{
+						shared.Team1: {LifeStatus: shared.Alive},
+						shared.Team2: {LifeStatus: shared.Alive},
+						shared.Team3: {LifeStatus: shared.Dead},
+					},
+					Environment:                  disasters.Environment{LastDisasterReport: report},
+					IIFOPredictionMarkets:        markets(),
+					IIFOPredictionMarketHoldings: holdings(),
+				},
+			}
+
+			s.settlePredictionMarkets(tc.disasterHappened)
+
+			for i, market := range s.gameState.IIFOPredictionMarkets {
+				if market.Resolved != tc.wantResolved[i] || market.Outcome != tc.wantOutcomes[i] {
+					t.Errorf("market %v: want resolved %v with outcome %v got '%v'", i, tc.wantResolved[i], tc.wantOutcomes[i], market)
+				}
+				if market.Resolved && i != 3 && (market.TurnResolved != 5 || market.Escrow != 0) {
+					t.Errorf("market %v: want escrow returned in turn 5 got '%v'", i, market)
+				}
+			}
+			for i, holding := range s.gameState.IIFOPredictionMarketHoldings {
+				if holding.Payout != tc.wantPayouts[i] {
+					t.Errorf("holding %v: want payout %v got %v", i, tc.wantPayouts[i], holding.Payout)
+				}
+			}
+			for clientID, want := range tc.wantResources {
+				if got := float64(s.gameState.ClientInfos[clientID].Resources); !closeTo(want, got) {
+					t.Errorf("%v: want resources %v got %v", clientID, want, got)
+				}
+			}
+			if !closeTo(tc.wantCommonPool, float64(s.gameState.CommonPool)) {
+				t.Errorf("want common pool %v got %v", tc.wantCommonPool, s.gameState.CommonPool)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"math"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/gamestate"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+// reputationRecord returns the island's reputation record for this turn, adding it to the ledger if there is none yet.
+// The pointer is only valid until the next record is added for the island.
+func (s *SOMASServer) reputationRecord(clientID shared.ClientID) *gamestate.ReputationRecord {
+	if s.gameState.ReputationLedger == nil {
+		s.gameState.ReputationLedger = gamestate.ReputationLedger{}
+	}
+	records := s.gameState.ReputationLedger[clientID]
+	if len(records) == 0 || records[len(records)-1].Turn != s.gameState.Turn {
+		records = append(records, gamestate.ReputationRecord{Turn: s.gameState.Turn})
+		s.gameState.ReputationLedger[clientID] = records
+	}
+	return &records[len(records)-1]
+}
+
+// GetReputationLedger returns a copy of the reputation ledger, with the facts about every island's conduct
+// verified by the server
+func (s ServerForClient) GetReputationLedger() gamestate.ReputationLedger {
+	return s.server.gameState.ReputationLedger.Copy()
+}
+
+// updateContributionHonestyScores sets each island's honesty score to the share of the common pool contributions
+// it announced over the game that it actually paid. Islands which never announced a contribution score 1.
+func (s *SOMASServer) updateContributionHonestyScores() {
+	scores := map[shared.ClientID]float64{}
+	for clientID, records := range s.gameState.ReputationLedger {
+		intended, kept := 0.0, 0.0
+		for _, record := range records {
+			if !record.ContributionAnnounced || !(record.IntendedContribution > 0) {
+				continue
+			}
+			intended += float64(record.IntendedContribution)
+			kept += math.Min(float64(record.ActualContribution), float64(record.IntendedContribution))
+		}
+		scores[clientID] = 1
+		if intended > 0 {
+			scores[clientID] = kept / intended
+		}
+	}
+	s.gameState.ContributionHonestyScores = scores
+}
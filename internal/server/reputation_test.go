@@ -0,0 +1,163 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/SOMAS2020/SOMAS2020/internal/common/baseclient"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/gamestate"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/rules"
+	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
+)
+
+func TestReputationRecord(t *testing.T) {
+	s := &SOMASServer{
+		gameState: gamestate.GameState{Turn: 3},
+	}
+
+	s.reputationRecord(shared.Team1).AgreementsBroken++
+	s.reputationRecord(shared.Team1).LoanDefaults++
+	s.gameState.Turn = 4
+	s.reputationRecord(shared.Team1).AgreementsBroken++
+	s.reputationRecord(shared.Team2).SanctionDue = 10
+
+	want := gamestate.ReputationLedger{
+		shared.Team1: {
+			{Turn: 3, AgreementsBroken: 1, LoanDefaults: 1},
+			{Turn: 4, AgreementsBroken: 1},
+		},
+		shared.Team2: {
+			{Turn: 4, SanctionDue: 10},
+		},
+	}
+	if !reflect.DeepEqual(want, s.gameState.ReputationLedger) {
+		t.Errorf("want ledger '%v' got '%v'", want, s.gameState.ReputationLedger)
+	}
+
+	// Clients only get a copy of the ledger
+	ledger := ServerForClient{clientID: shared.Team3, server: s}.GetReputationLedger()
+	ledger[shared.Team1][0].AgreementsBroken = 5
+	if !reflect.DeepEqual(want, s.gameState.ReputationLedger) {
+		t.Errorf("ledger changed through its copy: '%v'", s.gameState.ReputationLedger)
+	}
+}
+
+func TestReputationOfGiftsAndIntendedContributions(t *testing.T) {
+	clientMap := map[shared.ClientID]baseclient.Client{
+		shared.Team1: &mockClientIITO{
+			intendedContribution: shared.IntendedContribution{Contribution: 30, TeamsOfferedTo: []shared.ClientID{shared.Team2}},
+		},
+		shared.Team2: &mockClientIITO{
+			withholdGifts: true,
+			// Not shared with any other island
+			intendedContribution: shared.IntendedContribution{Contribution: 50, TeamsOfferedTo: []shared.ClientID{shared.Team2}},
+		},
+	}
+	s := &SOMASServer{
+		gameState: gamestate.GameState{
+			Turn: 2,
+			ClientInfos: map[shared.ClientID]gamestate.ClientInfo{
+				shared.Team1: {Resources: 100, LifeStatus: shared.Alive},
+				shared.Team2: {Resources: 100, LifeStatus: shared.Alive},
+			},
+		},
+		clientMap: clientMap,
+	}
+
+	s.getIntendedContribution()
+	s.executeTransactions(map[shared.ClientID]shared.GiftResponseDict{
+		shared.Team1: {shared.Team2: {AcceptedAmount: 10, Reason: shared.Accept}},
+		shared.Team2: {shared.Team1: {AcceptedAmount: 40, Reason: shared.Accept}},
+	})
+
+	want := gamestate.ReputationLedger{
+		shared.Team1: {
+			{Turn: 2, ContributionAnnounced: true, IntendedContribution: 30, GiftsPromised: 10, GiftsGiven: 10},
+		},
+		shared.Team2: {
+			{Turn: 2, GiftsPromised: 40, GiftsGiven: 20},
+		},
+	}
+	if !reflect.DeepEqual(want, s.gameState.ReputationLedger) {
+		t.Errorf("want ledger '%v' got '%v'", want, s.gameState.ReputationLedger)
+	}
+}
+
+type mockClientTax struct {
+	baseclient.BaseClient
+	tax shared.Resources
+}
+
+func (c *mockClientTax) GetTaxContribution() shared.Resources {
+	return c.tax
+}
+
+func (c *mockClientTax) GetSanctionPayment() shared.Resources {
+	return 0
+}
+
+func TestContributionGaps(t *testing.T) {
+	s := &SOMASServer{
+		gameState: gamestate.GameState{
+			Turn: 3,
+			ClientInfos: map[shared.ClientID]gamestate.ClientInfo{
+				shared.Team1: {Resources: 100, LifeStatus: shared.Alive},
+				shared.Team2: {Resources: 100, LifeStatus: shared.Alive},
+				shared.Team3: {Resources: 100, LifeStatus: shared.Alive},
+			},
+			ReputationLedger: gamestate.ReputationLedger{
+				shared.Team1: {
+					{Turn: 2, ContributionAnnounced: true, IntendedContribution: 20, ActualContribution: 20},
+					{Turn: 3, ContributionAnnounced: true, IntendedContribution: 30},
+				},
+				shared.Team2: {
+					{Turn: 3, ContributionAnnounced: true, IntendedContribution: 10},
+				},
+			},
+			IIGOHistory: map[uint][]shared.Accountability{},
+		},
+		clientMap: map[shared.ClientID]baseclient.Client{
+			shared.Team1: &mockClientTax{tax: 15},
+			shared.Team2: &mockClientTax{tax: 12},
+			shared.Team3: &mockClientTax{tax: 5},
+		},
+	}
+
+	err := s.runIIGOTax()
+	if err != nil {
+		t.Fatalf("runIIGOTax error: %v", err)
+	}
+
+	wantGaps := map[shared.ClientID]shared.Resources{
+		shared.Team1: 15,
+		shared.Team2: -2,
+	}
+	for _, clientID := range []shared.ClientID{shared.Team1, shared.Team2, shared.Team3} {
+		records := s.gameState.ReputationLedger[clientID]
+		record := records[len(records)-1]
+		if record.Turn != 3 || record.ContributionGap != wantGaps[clientID] {
+			t.Errorf("want gap %v for %v in turn 3 got %v in turn %v", wantGaps[clientID], clientID, record.ContributionGap, record.Turn)
+		}
+	}
+
+	gotGaps := map[shared.ClientID]shared.Resources{}
+	for _, entry := range s.gameState.IIGOHistory[3] {
+		for _, pair := range entry.Pairs {
+			if pair.VariableName == rules.IntendedContributionGap {
+				gotGaps[entry.ClientID] = shared.Resources(pair.Values[0])
+			}
+		}
+	}
+	if !reflect.DeepEqual(wantGaps, gotGaps) {
+		t.Errorf("want gaps reported '%v' got '%v'", wantGaps, gotGaps)
+	}
+
+	wantScores := map[shared.ClientID]float64{
+		shared.Team1: 0.7,
+		shared.Team2: 1,
+		shared.Team3: 1,
+	}
+	if !reflect.DeepEqual(wantScores, s.gameState.ContributionHonestyScores) {
+		t.Errorf("want honesty scores '%v' got '%v'", wantScores, s.gameState.ContributionHonestyScores)
+	}
+}
@@ -36,6 +36,10 @@ type SOMASServer struct {
 
 	// prevent the same instance from being run twice
 	ran bool
+
+	// deerHunts holds the deer caught by each deer hunt this turn, which are removed from the
+	// deer population in a single step of the population model at the end of the turn
+	deerHunts []uint
 }
 
 // NewSOMASServer returns an instance of the main server we use.
@@ -71,10 +75,16 @@ func createSOMASServer(
 	}
 
 	availableRules, rulesInPlay := rules.InitialRuleRegistration(gameConfig.IIGOConfig.StartWithRulesInPlay)
+	iigointernal.SetGovernedRules(availableRules, rulesInPlay, &gameConfig.IIGOConfig)
 	initRoles, err := getNRandClientIDsUniqueIfPossible(clientIDs, 3)
 	if err != nil {
 		return nil, errors.Errorf("Cannot initialise IIGO roles: %v", err)
 	}
+	additionalRoles := gameConfig.IIGOConfig.GetRoles()[3:]
+	additionalRoleIDs, err := getNRandClientIDsUniqueIfPossible(clientIDs, len(additionalRoles))
+	if err != nil {
+		return nil, errors.Errorf("Cannot initialise additional IIGO roles: %v", err)
+	}
 
 	server := &SOMASServer{
 		clientMap:  clientMap,
@@ -99,6 +109,11 @@ func createSOMASServer(
 				shared.Judge:     0,
 				shared.Speaker:   0,
 			},
+			IIGOTenures: map[shared.Role]gamestate.IIGOTenure{
+				shared.President: {Holder: initRoles[2], ConsecutiveTerms: 1, LeftOffice: map[shared.ClientID]uint{}},
+				shared.Judge:     {Holder: initRoles[1], ConsecutiveTerms: 1, LeftOffice: map[shared.ClientID]uint{}},
+				shared.Speaker:   {Holder: shared.Team1, ConsecutiveTerms: 1, LeftOffice: map[shared.ClientID]uint{}},
+			},
 			SpeakerID:   shared.Team1,
 			JudgeID:     initRoles[1],
 			PresidentID: initRoles[2],
@@ -112,6 +127,14 @@ func createSOMASServer(
 		ran: false,
 	}
 
+	server.gameState.IIGOAdditionalRoleIDs = map[shared.Role]shared.ClientID{}
+	for i, role := range additionalRoles {
+		server.gameState.IIGOAdditionalRoleIDs[role] = additionalRoleIDs[i]
+		server.gameState.IIGORolesBudget[role] = 0
+		server.gameState.IIGOTurnsInPower[role] = 0
+		server.gameState.IIGOTenures[role] = gamestate.IIGOTenure{Holder: additionalRoleIDs[i], ConsecutiveTerms: 1, LeftOffice: map[shared.ClientID]uint{}}
+	}
+
 	server.gameState.DeerPopulation = foraging.CreateDeerPopulationModel(gameConfig.ForagingConfig.DeerHuntConfig, server.logf)
 
 	for _, client := range clientMap {
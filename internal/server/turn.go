@@ -14,6 +14,8 @@ func (s *SOMASServer) runTurn() error {
 
 	s.startOfTurn()
 
+	s.runMessagingSession()
+
 	// run all orgs
 	err := s.runOrgs()
 	if err != nil {
@@ -79,6 +81,9 @@ func (s *SOMASServer) endOfTurn() error {
 		return errors.Errorf("IITO EndOfTurn error: %v", err)
 	}
 
+	// after the deer hunts of the islands and the coalitions
+	s.updateDeerPopulation()
+
 	if err := s.runIIGOTax(); err != nil {
 		return errors.Errorf("Failed to put taxes into common pool at end of turn: %v", err)
 	}
@@ -92,10 +97,15 @@ func (s *SOMASServer) endOfTurn() error {
 	// increment turn & season if needed
 	disasterHappened := updatedEnv.LastDisasterReport.Magnitude > 0
 
+	var disasterEffects map[shared.ClientID]shared.Magnitude
 	if disasterHappened {
 		s.applyDisasterEffects()    // compute effects taking into account CP and deduct resources accordingly
 		s.notifyClientsOfDisaster() // sends disaster report and effects to all non-dead clients
+		disasterEffects = updatedEnv.ComputeDisasterEffects(s.gameState.CommonPool, s.gameConfig.DisasterConfig).Absolute
 	}
+	s.resolveEscrowedGifts(disasterEffects)
+	s.scoreDisasterPredictions(disasterHappened)
+	s.settlePredictionMarkets(disasterHappened)
 	s.incrementTurnAndSeason(disasterHappened)
 
 	// deduct cost of living
@@ -92,6 +92,7 @@ func main() {
 		timeEnd := time.Now()
 		err = outputJSON(output{
 			GameStates: gameStates,
+			MessageLog: getMessageLog(gameStates),
 			Config:     gameConfig,
 			GitInfo:    getGitInfo(),
 			AuxInfo:    getAuxInfo(),
@@ -88,6 +88,7 @@ func RunGame(this js.Value, args []js.Value) (ret interface{}) {
 	timeEnd := time.Now()
 	o = output{
 		GameStates: gameStates,
+		MessageLog: getMessageLog(gameStates),
 		Config:     gameConfig,
 		// no git info
 		AuxInfo: getAuxInfo(),
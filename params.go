@@ -2,6 +2,8 @@ package main
 
 import (
 	"flag"
+	"strconv"
+	"strings"
 
 	"github.com/SOMAS2020/SOMAS2020/internal/common/config"
 	"github.com/SOMAS2020/SOMAS2020/internal/common/shared"
@@ -48,6 +50,11 @@ var (
 		3,
 		"The maximum consecutive turns an island can be in the critical state.",
 	)
+	maxMessagesPerTurn = flag.Uint(
+		"maxMessagesPerTurn",
+		10,
+		"The maximum number of messages an island can send to other islands each turn (0 disables messaging).",
+	)
 
 	// config.ForagingConfig.DeerHuntConfig
 	foragingDeerMaxPerHunt = flag.Uint(
@@ -303,6 +310,52 @@ var (
 		"Sanction length for all sanctions",
 	)
 
+	iigoSanctionLadder = flag.String(
+		"iigoSanctionLadder",
+		"",
+		"';' separated sanction tiers from the mildest, each a ',' separated list of threshold, rule, constant, rate, duration and '+' separated consequences, "+
+			"e.g. 'threshold=1,rule=iigo_economic_sanction_1;threshold=10,rate=0.5,duration=8,consequences=LoseVotingRight+IneligibleForOffice'. "+
+			"Empty for the five iigo_economic_sanction tiers",
+	)
+
+	iigoRuleVoteMajority = flag.Float64(
+		"iigoRuleVoteMajority",
+		0.5,
+		"Share of the votes cast needed to pass an ordinary rule vote. 0.5 or less means a simple majority",
+	)
+
+	iigoElectionVotingMethods = flag.String(
+		"iigoElectionVotingMethods",
+		"",
+		"Comma separated 'Role:Method' pairs fixing the voting method of the elections for the President, Speaker and Judge, e.g. 'President:Runoff'. "+
+			"Roles left out let the role running the election choose",
+	)
+
+	iigoConstitution = flag.String(
+		"iigoConstitution",
+		"rule=iigo_term_length_president,majority=2/3,sessions=2;"+
+			"rule=iigo_term_length_speaker,majority=2/3,sessions=2;"+
+			"rule=iigo_term_length_judge,majority=2/3,sessions=2;"+
+			"rule=iigo_rule_vote_majority,majority=1;"+
+			"rule=iigo_election_voting_method_president,majority=2/3,sessions=2;"+
+			"rule=iigo_election_voting_method_speaker,majority=2/3,sessions=2;"+
+			"rule=iigo_election_voting_method_judge,majority=2/3,sessions=2",
+		"';' separated constitutional rules, each a ',' separated list of the rule, the majority needed to change it "+
+			"(a share of the votes cast, 1 for unanimity) and the number of consecutive sessions that must pass the change",
+	)
+
+	iigoCollectiveSanctionRules = flag.String(
+		"iigoCollectiveSanctionRules",
+		"collective_free_rider_surcharge",
+		"',' separated rules evaluated against every island at the end of the turn, whose output is charged as a surcharge into the common pool",
+	)
+
+	iigoAnonymiseElectionBallots = flag.Bool(
+		"iigoAnonymiseElectionBallots",
+		false,
+		"Whether recorded election ballots are detached from the islands that cast them",
+	)
+
 	// config.IIGOConfig - Legislative branch
 	iigoSetVotingResultActionCost = flag.Float64(
 		"iigoSetVotingResultActionCost",
@@ -334,6 +387,60 @@ var (
 		"IIGO action cost for appointNextJudge action",
 	)
 
+	iigoLegislativeSessionBudget = flag.Float64(
+		"iigoLegislativeSessionBudget",
+		6,
+		"Maximum spent on votes in a legislative session, each agenda item or amendment costing iigoSetRuleToVoteActionCost",
+	)
+
+	iigoAppealFee = flag.Float64(
+		"iigoAppealFee",
+		10,
+		"Fee paid into the common pool by an island appealing its sanction. Refunded if the sanction is voided",
+	)
+
+	iigoAppealPanelSize = flag.Uint(
+		"iigoAppealPanelSize",
+		3,
+		"Number of randomly selected islands on the panel hearing a sanction appeal",
+	)
+
+	iigoRecallCoSignersRequired = flag.Uint(
+		"iigoRecallCoSignersRequired",
+		2,
+		"Number of islands that must co-sign a recall petition to force an election for an IIGO role",
+	)
+
+	iigoMaxConsecutiveTerms = flag.Uint(
+		"iigoMaxConsecutiveTerms",
+		0,
+		"Maximum number of consecutive elections an island can win for the same IIGO role (0 for no limit)",
+	)
+
+	iigoTermCoolingOffPeriod = flag.Uint(
+		"iigoTermCoolingOffPeriod",
+		0,
+		"Number of turns a former IIGO role holder must wait before standing for the same role again",
+	)
+
+	iigoCandidateMinimumResources = flag.Float64(
+		"iigoCandidateMinimumResources",
+		0,
+		"Minimum private resources an island needs to stand in an IIGO election",
+	)
+
+	iigoCandidatesMustNotBeSanctioned = flag.Bool(
+		"iigoCandidatesMustNotBeSanctioned",
+		false,
+		"Whether islands under sanction are barred from standing in IIGO elections",
+	)
+
+	iigoCandidatesMustNotBeCritical = flag.Bool(
+		"iigoCandidatesMustNotBeCritical",
+		false,
+		"Whether islands in critical state are barred from standing in IIGO elections",
+	)
+
 	iigoTermLengthPresident = flag.Uint(
 		"iigoTermLengthPresident",
 		4,
@@ -352,11 +459,115 @@ var (
 		"Length of the term for the Judge",
 	)
 
+	// config.IIGOConfig - Branch structure
+	iigoEnableTreasurer = flag.Bool(
+		"iigoEnableTreasurer",
+		false,
+		"Whether the IIGO has a Treasurer setting a reserve the President cannot allocate from the common pool",
+	)
+
+	iigoEnableAuditor = flag.Bool(
+		"iigoEnableAuditor",
+		false,
+		"Whether the IIGO has an Auditor independently checking the President's allocations",
+	)
+
+	iigoTreasurerAppointedBy = flag.String(
+		"iigoTreasurerAppointedBy",
+		"President",
+		"IIGO role running the elections for the Treasurer",
+	)
+
+	iigoAuditorAppointedBy = flag.String(
+		"iigoAuditorAppointedBy",
+		"Judge",
+		"IIGO role running the elections for the Auditor",
+	)
+
+	iigoTermLengthTreasurer = flag.Uint(
+		"iigoTermLengthTreasurer",
+		4,
+		"Length of the term for the Treasurer",
+	)
+
+	iigoTermLengthAuditor = flag.Uint(
+		"iigoTermLengthAuditor",
+		4,
+		"Length of the term for the Auditor",
+	)
+
+	iigoAdditionalRoleBudgetIncrement = flag.Float64(
+		"iigoAdditionalRoleBudgetIncrement",
+		100,
+		"Budget added every turn to each additional IIGO role",
+	)
+
+	iigoSetCommonPoolReserveActionCost = flag.Float64(
+		"iigoSetCommonPoolReserveActionCost",
+		2,
+		"IIGO action cost for the Treasurer's setCommonPoolReserve action",
+	)
+
+	iigoAuditAllocationActionCost = flag.Float64(
+		"iigoAuditAllocationActionCost",
+		2,
+		"IIGO action cost for the Auditor's auditAllocations action",
+	)
+
+	iigoAppointNextTreasurerActionCost = flag.Float64(
+		"iigoAppointNextTreasurerActionCost",
+		2,
+		"IIGO action cost for appointNextTreasurer action",
+	)
+
+	iigoAppointNextAuditorActionCost = flag.Float64(
+		"iigoAppointNextAuditorActionCost",
+		2,
+		"IIGO action cost for appointNextAuditor action",
+	)
+
+	iigoMonitoringGraph = flag.String(
+		"iigoMonitoringGraph",
+		"Speaker:President,President:Judge,Judge:Speaker",
+		"Comma separated 'Monitor:Monitored' IIGO role pairs, e.g. add ',Treasurer:Auditor,Auditor:Treasurer' for the additional roles",
+	)
+
 	startWithRulesInPlay = flag.Bool(
 		"startWithRulesInPlay",
 		true,
 		"Pull all available rules into play at start of run",
 	)
+
+	// config.IIFOConfig
+	iifoPredictionReward = flag.Float64(
+		"iifoPredictionReward",
+		0,
+		"Resources paid from the common pool to the most accurate disaster forecaster when predictions are scored (0 disables the reward)",
+	)
+
+	iifoPredictionMarketLiquidity = flag.Float64(
+		"iifoPredictionMarketLiquidity",
+		10,
+		"Liquidity of the disaster prediction market maker, funded from the common pool (0 disables the market)",
+	)
+
+	iifoVerifyForageSharing = flag.Bool(
+		"iifoVerifyForageSharing",
+		false,
+		"If true, the server checks shared foraging information against the foraging history and attaches the result",
+	)
+	iifoForageAuditCost = flag.Float64(
+		"iifoForageAuditCost",
+		5,
+		"Resources paid into the common pool by an island for each audit of foraging information shared with it",
+	)
+
+	// config.IITOConfig
+	iitoGiftNegotiationRounds = flag.Uint(
+		"iitoGiftNegotiationRounds",
+		0,
+		"Number of rounds in which islands can counter-offer, revise and withdraw gift offers (0 disables negotiation)",
+	)
 )
 
 func parseConfig() (config.Config, error) {
@@ -429,10 +640,49 @@ func parseConfig() (config.Config, error) {
 		StochasticPeriodVisible:     *disasterStochasticPeriodVisible,
 	}
 
+	additionalRoles, err := parseAdditionalIIGORoles()
+	if err != nil {
+		return config.Config{}, errors.Errorf("Error parsing additional IIGO roles: %v", err)
+	}
+
+	monitoringGraph, err := shared.ParseMonitoringGraph(*iigoMonitoringGraph)
+	if err != nil {
+		return config.Config{}, errors.Errorf("Error parsing iigoMonitoringGraph: %v", err)
+	}
+	for monitor, monitored := range monitoringGraph {
+		for _, role := range []shared.Role{monitor, monitored} {
+			if _, ok := additionalRoles[role]; role > shared.Judge && !ok {
+				return config.Config{}, errors.Errorf("Error parsing iigoMonitoringGraph: %v is not enabled", role)
+			}
+		}
+	}
+
+	sanctionLadder, err := parseSanctionLadder(*iigoSanctionLadder)
+	if err != nil {
+		return config.Config{}, errors.Errorf("Error parsing iigoSanctionLadder: %v", err)
+	}
+
+	electionVotingMethods, err := shared.ParseElectionVotingMethods(*iigoElectionVotingMethods)
+	if err != nil {
+		return config.Config{}, errors.Errorf("Error parsing iigoElectionVotingMethods: %v", err)
+	}
+	for role := range electionVotingMethods {
+		if role > shared.Judge {
+			return config.Config{}, errors.Errorf("Error parsing iigoElectionVotingMethods: %v elections always use %v", role, shared.Runoff)
+		}
+	}
+
+	constitution, err := parseConstitution(*iigoConstitution)
+	if err != nil {
+		return config.Config{}, errors.Errorf("Error parsing iigoConstitution: %v", err)
+	}
+
 	iigoConf := config.IIGOConfig{
 		IIGOTermLengths: map[shared.Role]uint{shared.President: *iigoTermLengthPresident,
-			shared.Speaker: *iigoTermLengthSpeaker,
-			shared.Judge:   *iigoTermLengthJudge},
+			shared.Speaker:   *iigoTermLengthSpeaker,
+			shared.Judge:     *iigoTermLengthJudge,
+			shared.Treasurer: *iigoTermLengthTreasurer,
+			shared.Auditor:   *iigoTermLengthAuditor},
 		// Executive branch
 		GetRuleForSpeakerActionCost:        shared.Resources(*iigoGetRuleForSpeakerActionCost),
 		BroadcastTaxationActionCost:        shared.Resources(*iigoBroadcastTaxationActionCost),
@@ -451,12 +701,29 @@ func parseConfig() (config.Config, error) {
 		HistoryCacheDepth:               *iigoHistoryCacheDepth,
 		AssumedResourcesNoReport:        shared.Resources(*iigoAssumedResourcesNoReport),
 		SanctionLength:                  *iigoSanctionLength,
+		SanctionLadder:                  sanctionLadder,
+		RuleVoteMajority:                *iigoRuleVoteMajority,
+		ElectionVotingMethods:           electionVotingMethods,
+		Constitution:                    constitution,
+		CollectiveSanctionRules:         parseRuleNames(*iigoCollectiveSanctionRules),
+		AnonymiseElectionBallots:        *iigoAnonymiseElectionBallots,
+		AppealFee:                       shared.Resources(*iigoAppealFee),
+		AppealPanelSize:                 *iigoAppealPanelSize,
 		// Legislative branch
 		SetVotingResultActionCost:      shared.Resources(*iigoSetVotingResultActionCost),
 		SetRuleToVoteActionCost:        shared.Resources(*iigoSetRuleToVoteActionCost),
 		AnnounceVotingResultActionCost: shared.Resources(*iigoAnnounceVotingResultActionCost),
 		UpdateRulesActionCost:          shared.Resources(*iigoUpdateRulesActionCost),
 		AppointNextJudgeActionCost:     shared.Resources(*iigoAppointNextJudgeActionCost),
+		LegislativeSessionBudget:       shared.Resources(*iigoLegislativeSessionBudget),
+		RecallCoSignersRequired:        *iigoRecallCoSignersRequired,
+		MaxConsecutiveTerms:            *iigoMaxConsecutiveTerms,
+		TermCoolingOffPeriod:           *iigoTermCoolingOffPeriod,
+		CandidateMinimumResources:      shared.Resources(*iigoCandidateMinimumResources),
+		CandidatesMustNotBeSanctioned:  *iigoCandidatesMustNotBeSanctioned,
+		CandidatesMustNotBeCritical:    *iigoCandidatesMustNotBeCritical,
+		AdditionalRoles:                additionalRoles,
+		MonitoringGraph:                monitoringGraph,
 		StartWithRulesInPlay:           *startWithRulesInPlay,
 	}
 
@@ -468,8 +735,192 @@ func parseConfig() (config.Config, error) {
 		CostOfLiving:                shared.Resources(*costOfLiving),
 		MinimumResourceThreshold:    shared.Resources(*minimumResourceThreshold),
 		MaxCriticalConsecutiveTurns: *maxCriticalConsecutiveTurns,
+		MaxMessagesPerTurn:          *maxMessagesPerTurn,
 		ForagingConfig:              foragingConf,
 		DisasterConfig:              disasterConf,
 		IIGOConfig:                  iigoConf,
+		IIFOConfig: config.IIFOConfig{
+			PredictionReward:          shared.Resources(*iifoPredictionReward),
+			PredictionMarketLiquidity: *iifoPredictionMarketLiquidity,
+			VerifyForageSharing:       *iifoVerifyForageSharing,
+			ForageAuditCost:           shared.Resources(*iifoForageAuditCost),
+		},
+		IITOConfig: config.IITOConfig{
+			GiftNegotiationRounds: *iitoGiftNegotiationRounds,
+		},
 	}, nil
 }
+
+// parseAdditionalIIGORoles builds the config of the enabled additional IIGO roles.
+// Additional roles can only be appointed by the President, Speaker or Judge.
+func parseAdditionalIIGORoles() (map[shared.Role]config.IIGORoleConfig, error) {
+	type roleFlags struct {
+		enabled           bool
+		appointedBy       string
+		actionCost        float64
+		appointActionCost float64
+	}
+	flags := map[shared.Role]roleFlags{
+		shared.Treasurer: {*iigoEnableTreasurer, *iigoTreasurerAppointedBy, *iigoSetCommonPoolReserveActionCost, *iigoAppointNextTreasurerActionCost},
+		shared.Auditor:   {*iigoEnableAuditor, *iigoAuditorAppointedBy, *iigoAuditAllocationActionCost, *iigoAppointNextAuditorActionCost},
+	}
+
+	additionalRoles := map[shared.Role]config.IIGORoleConfig{}
+	for role, f := range flags {
+		if !f.enabled {
+			continue
+		}
+		appointedBy, err := shared.ParseRole(f.appointedBy)
+		if err != nil {
+			return nil, err
+		}
+		if appointedBy > shared.Judge {
+			return nil, errors.Errorf("%v cannot be appointed by %v", role, appointedBy)
+		}
+		additionalRoles[role] = config.IIGORoleConfig{
+			AppointedBy:       appointedBy,
+			BudgetIncrement:   shared.Resources(*iigoAdditionalRoleBudgetIncrement),
+			ActionCost:        shared.Resources(f.actionCost),
+			AppointActionCost: shared.Resources(f.appointActionCost),
+		}
+	}
+	return additionalRoles, nil
+}
+
+// parseSanctionLadder parses a ladder of ';' separated tiers, from the mildest. Each tier is a ',' separated list of
+// key=value pairs: threshold, rule, constant, rate, duration and consequences ('+' separated).
+// e.g. "threshold=1,rule=iigo_economic_sanction_1;threshold=10,rate=0.5,duration=8,consequences=LoseVotingRight"
+// An empty string gives a nil ladder, which means the default ladder (see config.DefaultSanctionLadder).
+func parseSanctionLadder(s string) ([]config.SanctionTierConfig, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	ladder := []config.SanctionTierConfig{}
+	for i, tierStr := range strings.Split(s, ";") {
+		tier := config.SanctionTierConfig{}
+		for _, field := range strings.Split(tierStr, ",") {
+			pair := strings.SplitN(strings.TrimSpace(field), "=", 2)
+			if len(pair) != 2 {
+				return nil, errors.Errorf("Invalid sanction tier field: '%v'. Expected 'key=value'.", field)
+			}
+			if err := parseSanctionTierField(&tier, pair[0], pair[1]); err != nil {
+				return nil, err
+			}
+		}
+		if tier.PenaltyRule != "" && tier.PenaltyRate != 0 {
+			return nil, errors.Errorf("Sanction tier %v has both a penalty rule and a penalty rate", i+1)
+		}
+		if i > 0 && tier.Threshold < ladder[i-1].Threshold {
+			return nil, errors.Errorf("Sanction tier %v has a lower threshold than the tier below it", i+1)
+		}
+		ladder = append(ladder, tier)
+	}
+	return ladder, nil
+}
+
+func parseSanctionTierField(tier *config.SanctionTierConfig, key string, value string) error {
+	switch key {
+	case "threshold":
+		threshold, err := strconv.Atoi(value)
+		if err != nil {
+			return errors.Errorf("Invalid sanction threshold '%v': %v", value, err)
+		}
+		tier.Threshold = shared.IIGOSanctionsScore(threshold)
+	case "rule":
+		tier.PenaltyRule = value
+	case "constant":
+		constant, err := strconv.ParseFloat(value, 64)
+		if err != nil || constant < 0 {
+			return errors.Errorf("Invalid sanction penalty constant '%v'", value)
+		}
+		tier.PenaltyConstant = shared.Resources(constant)
+	case "rate":
+		rate, err := strconv.ParseFloat(value, 64)
+		if err != nil || rate < 0 {
+			return errors.Errorf("Invalid sanction penalty rate '%v'", value)
+		}
+		tier.PenaltyRate = rate
+	case "duration":
+		duration, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return errors.Errorf("Invalid sanction duration '%v': %v", value, err)
+		}
+		tier.Duration = uint(duration)
+	case "consequences":
+		for _, name := range strings.Split(value, "+") {
+			consequence, err := shared.ParseSanctionConsequence(name)
+			if err != nil {
+				return err
+			}
+			tier.Consequences = append(tier.Consequences, consequence)
+		}
+	default:
+		return errors.Errorf("Unknown sanction tier field: '%v'", key)
+	}
+	return nil
+}
+
+func parseConstitution(s string) (map[string]config.AmendmentProcedure, error) {
+	constitution := map[string]config.AmendmentProcedure{}
+	if strings.TrimSpace(s) == "" {
+		return constitution, nil
+	}
+	for _, entryStr := range strings.Split(s, ";") {
+		ruleName := ""
+		procedure := config.AmendmentProcedure{Sessions: 1}
+		for _, field := range strings.Split(entryStr, ",") {
+			pair := strings.SplitN(strings.TrimSpace(field), "=", 2)
+			if len(pair) != 2 {
+				return nil, errors.Errorf("Invalid constitution field: '%v'. Expected 'key=value'.", field)
+			}
+			switch key, value := pair[0], pair[1]; key {
+			case "rule":
+				ruleName = value
+			case "majority":
+				majority, err := parseShare(value)
+				if err != nil || majority < 0 || majority > 1 {
+					return nil, errors.Errorf("Invalid constitutional majority '%v'", value)
+				}
+				procedure.Majority = majority
+			case "sessions":
+				sessions, err := strconv.ParseUint(value, 10, 32)
+				if err != nil || sessions == 0 {
+					return nil, errors.Errorf("Invalid constitutional sessions '%v'", value)
+				}
+				procedure.Sessions = uint(sessions)
+			default:
+				return nil, errors.Errorf("Unknown constitution field: '%v'", key)
+			}
+		}
+		if ruleName == "" {
+			return nil, errors.Errorf("Constitution entry '%v' has no rule", entryStr)
+		}
+		constitution[ruleName] = procedure
+	}
+	return constitution, nil
+}
+
+// parseShare parses a share given as a decimal or as a fraction such as 2/3
+func parseShare(s string) (float64, error) {
+	parts := strings.SplitN(s, "/", 2)
+	numerator, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil || len(parts) == 1 {
+		return numerator, err
+	}
+	denominator, err := strconv.ParseFloat(parts[1], 64)
+	if err != nil || denominator == 0 {
+		return 0, errors.Errorf("Invalid denominator in '%v'", s)
+	}
+	return numerator / denominator, nil
+}
+
+// parseRuleNames splits a ',' separated list of rule names, ignoring blanks
+func parseRuleNames(s string) []string {
+	var ruleNames []string
+	for _, name := range strings.Split(s, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			ruleNames = append(ruleNames, name)
+		}
+	}
+	return ruleNames
+}
@@ -41,4 +41,15 @@ type output struct {
 	RunInfo    runInfo
 	AuxInfo    auxInfo
 	GameStates []gamestate.GameState
+	// MessageLog lists the messages islands sent each other during the whole game
+	MessageLog []shared.MessageLogEntry
+}
+
+// getMessageLog concatenates the messages of every turn, which are kept in the game state of that turn
+func getMessageLog(gameStates []gamestate.GameState) []shared.MessageLogEntry {
+	messageLog := []shared.MessageLogEntry{}
+	for _, gameState := range gameStates {
+		messageLog = append(messageLog, gameState.Messages...)
+	}
+	return messageLog
 }